@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"desadangdang/internal/app"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "inspect the configuration",
+}
+
+var configCheckCmd = &cobra.Command{
+	Use:          "check",
+	Short:        "print the effective config and validate it",
+	Long:         `print every setting with the source it was read from (env, file or default), secrets are redacted. Exits with an error listing every missing or malformed setting.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunConfigCheck()
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configCheckCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"desadangdang/internal/app"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var mediaCmd = &cobra.Command{
+	Use:   "media",
+	Short: "manage uploaded media",
+}
+
+var mediaCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "delete media that is not used by any record",
+	Long:  `delete uploaded files that no record references anymore, files younger than --older-than are kept so uploads still being attached to a form survive`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		olderThan, _ := cmd.Flags().GetDuration("older-than")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return app.RunMediaCleanup(olderThan, dryRun)
+	},
+}
+
+func init() {
+	mediaCleanupCmd.Flags().Duration("older-than", 24*time.Hour, "only delete media uploaded before this long ago")
+	mediaCleanupCmd.Flags().Bool("dry-run", false, "list orphan media without deleting it")
+
+	mediaCmd.AddCommand(mediaCleanupCmd)
+	rootCmd.AddCommand(mediaCmd)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"desadangdang/internal/app"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var openapiCmd = &cobra.Command{
+	Use:          "openapi",
+	Short:        "print the openapi document",
+	Long:         `print the OpenAPI 3 document served at /openapi.json, for client generators.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunOpenAPI(os.Stdout)
+	},
+}
+
+var openapiCheckCmd = &cobra.Command{
+	Use:          "check",
+	Short:        "check every route is documented",
+	Long:         `register the routes without connecting to anything and exit with an error listing every route missing from the openapi document, or documented but not registered. Run it in CI next to go vet.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return app.RunOpenAPICheck()
+	},
+}
+
+func init() {
+	openapiCmd.AddCommand(openapiCheckCmd)
+	rootCmd.AddCommand(openapiCmd)
+}
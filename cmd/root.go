@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"desadangdang/config"
+	"desadangdang/utils/logger"
 	"fmt"
 	"os"
 
@@ -12,6 +14,8 @@ var cfgFile string
 var rootCmd = &cobra.Command{
 	Use:   "core-api",
 	Short: "this api for compro",
+	// Execute prints the returned error, cobra would print it a second time.
+	SilenceErrors: true,
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Run(startCmd, nil)
 	},
@@ -24,22 +28,27 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .env)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file: .env, yaml or toml (default is the first of .env, config.yaml, config.yml, config.toml)")
 
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 
 }
 
 func initConfig() {
-	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
-	} else {
-		viper.SetConfigFile(`.env`)
-	}
-
 	viper.AutomaticEnv()
 
-	if err := viper.ReadInConfig(); err != nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	path := cfgFile
+	if path == "" {
+		path = config.FindFile()
 	}
+	if path != "" {
+		if err := config.ReadFile(path); err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading config file:", path, err)
+			if cfgFile != "" {
+				os.Exit(1)
+			}
+		}
+	}
+
+	logger.Setup(logger.New(config.NewConfig()))
 }
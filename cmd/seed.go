@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"desadangdang/database/seeds"
+	"desadangdang/internal/app"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed [seeder...]",
+	Short: "insert the admin user and demo data",
+	Long: `run the named seeders, or all of them when none is given. Seeders are idempotent.
+Demo seeders are skipped in production and naming one there is an error.
+The admin account is read from --admin-name, --admin-email and --admin-password,
+falling back to SEED_ADMIN_NAME, SEED_ADMIN_EMAIL and SEED_ADMIN_PASSWORD.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if list, _ := cmd.Flags().GetBool("list"); list {
+			for _, seeder := range seeds.Seeders() {
+				fmt.Printf("%-14s %s\n", seeder.Name, seeder.Description)
+			}
+			return nil
+		}
+
+		opts := seeds.Options{
+			AdminName:     flagOrEnv(cmd, "admin-name", "SEED_ADMIN_NAME"),
+			AdminEmail:    flagOrEnv(cmd, "admin-email", "SEED_ADMIN_EMAIL"),
+			AdminPassword: flagOrEnv(cmd, "admin-password", "SEED_ADMIN_PASSWORD"),
+		}
+		return app.RunSeed(args, opts)
+	},
+}
+
+func flagOrEnv(cmd *cobra.Command, flag, key string) string {
+	if cmd.Flags().Changed(flag) {
+		value, _ := cmd.Flags().GetString(flag)
+		return value
+	}
+	return viper.GetString(key)
+}
+
+func init() {
+	seedCmd.Flags().Bool("list", false, "list the available seeders")
+	seedCmd.Flags().String("admin-name", "", "name of the admin user")
+	seedCmd.Flags().String("admin-email", "", "email of the admin user")
+	seedCmd.Flags().String("admin-password", "", "password of the admin user")
+
+	rootCmd.AddCommand(seedCmd)
+}
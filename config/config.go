@@ -5,9 +5,58 @@ import "github.com/spf13/viper"
 type App struct {
 	AppPort string `json:"app_port"`
 	AppEnv  string `json:"app_env"`
+	AppName string `json:"app_name"`
 
 	JwtSecretKey string `json:"jwt_secret_key"`
 	JwtIssuer    string `json:"jwt_issuer"`
+
+	// HealthTimeout bounds every health check, ShutdownDelay is how long
+	// /readyz fails before the server stops accepting requests. Both in seconds.
+	HealthTimeout int `json:"health_timeout"`
+	ShutdownDelay int `json:"shutdown_delay"`
+
+	// TrustedProxies are the ip ranges of the proxies in front of the app,
+	// X-Forwarded-For is only read from them. Empty trusts no header and uses
+	// the address of the connection.
+	TrustedProxies []string `json:"trusted_proxies"`
+}
+
+// Log format is json or console.
+type Log struct {
+	Level  string `json:"level"`
+	Format string `json:"format"`
+}
+
+// Tracing exports spans to stdout or an OTLP/HTTP collector, Endpoint is
+// host:port or a full url. Insecure sends them without TLS.
+type Tracing struct {
+	Exporter string `json:"exporter"`
+	Endpoint string `json:"endpoint"`
+	Insecure bool   `json:"insecure"`
+}
+
+// Metrics are served on /metrics when enabled, scrapers send Token as a
+// bearer token.
+type Metrics struct {
+	Enabled bool   `json:"enabled"`
+	Token   string `json:"token"`
+}
+
+// API keeps serving the paths from before /api/v1 when LegacyRoutes is set,
+// marked deprecated until LegacySunset, a YYYY-MM-DD date. HomeCacheTTL is
+// how long /home is cached in seconds, 0 disables the cache.
+type API struct {
+	LegacyRoutes bool   `json:"legacy_routes"`
+	LegacySunset string `json:"legacy_sunset"`
+	HomeCacheTTL int    `json:"home_cache_ttl"`
+}
+
+// HTTPCache is the Cache-Control of the public GET routes, Control unless
+// Groups has one for the route group, like posts or home. Admin routes always
+// send no-store.
+type HTTPCache struct {
+	Control string            `json:"control"`
+	Groups  map[string]string `json:"groups"`
 }
 
 type PsqlDB struct {
@@ -18,6 +67,8 @@ type PsqlDB struct {
 	DBName    string `json:"db_name"`
 	DBMaxOpen int    `json:"db_max_open"`
 	DBMaxIdle int    `json:"db_max_idle"`
+	// RequestTimeout in seconds bounds the queries of one request, 0 disables it.
+	RequestTimeout int `json:"request_timeout"`
 }
 
 type Supabase struct {
@@ -26,52 +77,168 @@ type Supabase struct {
 	StorageBucket string `json:"storage_bucket"`
 }
 
+// Storage selects the upload backend: supabase, local or s3.
+type Storage struct {
+	Driver    string `json:"driver"`
+	LocalDir  string `json:"local_dir"`
+	PublicURL string `json:"public_url"`
+}
+
+// Upload limits are in megabytes, zero keeps the default per purpose.
+type Upload struct {
+	MaxImageSize int64 `json:"max_image_size"`
+	MaxPDFSize   int64 `json:"max_pdf_size"`
+	MaxDocxSize  int64 `json:"max_docx_size"`
+	MaxVideoSize int64 `json:"max_video_size"`
+
+	SigningKey string `json:"signing_key"`
+}
+
+type S3 struct {
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+	Bucket    string `json:"bucket"`
+	Region    string `json:"region"`
+	UseSSL    bool   `json:"use_ssl"`
+}
+
 type EmailConfig struct {
 	Host     string `json:"host"`
 	Port     int    `json:"port"`
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Reciever string `json:"reciever"`
+	Sender   string `json:"sender"`
 	IsTLS    bool   `json:"is_tls"`
+
+	Transport string `json:"transport"`
+	FileDir   string `json:"file_dir"`
+
+	OutboxInterval    int `json:"outbox_interval"`
+	OutboxBatchSize   int `json:"outbox_batch_size"`
+	OutboxMaxAttempts int `json:"outbox_max_attempts"`
 }
 
 type Config struct {
-	App      App
-	Psql     PsqlDB
-	Supabase Supabase
-	Email    EmailConfig
+	App       App
+	Log       Log
+	Tracing   Tracing
+	Metrics   Metrics
+	API       API
+	HTTPCache HTTPCache
+	Psql      PsqlDB
+	Supabase  Supabase
+	Storage   Storage
+	S3        S3
+	Upload    Upload
+	Email     EmailConfig
 }
 
+// NewConfig reads the config without validating it, see Load.
 func NewConfig() *Config {
+	register()
+
+	// malformed pairs are reported by Validate
+	groups, _ := parsePairs(viper.GetString("http_cache.groups"))
+
 	return &Config{
 		App: App{
-			AppPort: viper.GetString("APP_PORT"),
-			AppEnv:  viper.GetString("APP_PORT"),
+			AppPort: viper.GetString("app.port"),
+			AppEnv:  viper.GetString("app.env"),
+			AppName: viper.GetString("app.name"),
+
+			JwtSecretKey: viper.GetString("app.jwt_secret_key"),
+			JwtIssuer:    viper.GetString("app.jwt_issuer"),
+
+			HealthTimeout: viper.GetInt("app.health_timeout"),
+			ShutdownDelay: viper.GetInt("app.shutdown_delay"),
 
-			JwtSecretKey: viper.GetString("JWT_SECRET_KEY"),
-			JwtIssuer:    viper.GetString("JWT_ISSUER"),
+			TrustedProxies: splitList(viper.GetString("app.trusted_proxies")),
+		},
+		Log: Log{
+			Level:  viper.GetString("log.level"),
+			Format: viper.GetString("log.format"),
+		},
+		Tracing: Tracing{
+			Exporter: viper.GetString("tracing.exporter"),
+			Endpoint: viper.GetString("tracing.endpoint"),
+			Insecure: viper.GetBool("tracing.insecure"),
+		},
+		Metrics: Metrics{
+			Enabled: viper.GetBool("metrics.enabled"),
+			Token:   viper.GetString("metrics.token"),
+		},
+		API: API{
+			LegacyRoutes: viper.GetBool("api.legacy_routes"),
+			LegacySunset: viper.GetString("api.legacy_sunset"),
+			HomeCacheTTL: viper.GetInt("api.home_cache_ttl"),
+		},
+		HTTPCache: HTTPCache{
+			Control: viper.GetString("http_cache.control"),
+			Groups:  groups,
 		},
 		Psql: PsqlDB{
-			Host:      viper.GetString("DATABASE_HOST"),
-			Port:      viper.GetString("DATABASE_PORT"),
-			User:      viper.GetString("DATABASE_USER"),
-			Password:  viper.GetString("DATABASE_PASSWORD"),
-			DBName:    viper.GetString("DATABASE_NAME"),
-			DBMaxOpen: viper.GetInt("DATABASE_MAX_OPEN_CONNECTION"),
-			DBMaxIdle: viper.GetInt("DATABASE_MAX_IDLE_CONNECTION"),
+			Host:      viper.GetString("database.host"),
+			Port:      viper.GetString("database.port"),
+			User:      viper.GetString("database.user"),
+			Password:  viper.GetString("database.password"),
+			DBName:    viper.GetString("database.name"),
+			DBMaxOpen: viper.GetInt("database.max_open_connection"),
+			DBMaxIdle: viper.GetInt("database.max_idle_connection"),
+
+			RequestTimeout: viper.GetInt("database.request_timeout"),
 		},
 		Supabase: Supabase{
-			StorageUrl:    viper.GetString("SUPABASE_STORAGE_URL"),
-			StorageKey:    viper.GetString("SUPABASE_STORAGE_KEY"),
-			StorageBucket: viper.GetString("SUPABASE_STORAGE_BUCKET"),
+			StorageUrl:    viper.GetString("supabase.storage_url"),
+			StorageKey:    viper.GetString("supabase.storage_key"),
+			StorageBucket: viper.GetString("supabase.storage_bucket"),
+		},
+		Storage: Storage{
+			Driver:    viper.GetString("storage.driver"),
+			LocalDir:  viper.GetString("storage.local_dir"),
+			PublicURL: viper.GetString("storage.public_url"),
+		},
+		S3: S3{
+			Endpoint:  viper.GetString("s3.endpoint"),
+			AccessKey: viper.GetString("s3.access_key"),
+			SecretKey: viper.GetString("s3.secret_key"),
+			Bucket:    viper.GetString("s3.bucket"),
+			Region:    viper.GetString("s3.region"),
+			UseSSL:    viper.GetBool("s3.use_ssl"),
+		},
+		Upload: Upload{
+			MaxImageSize: viper.GetInt64("upload.max_image_size"),
+			MaxPDFSize:   viper.GetInt64("upload.max_pdf_size"),
+			MaxDocxSize:  viper.GetInt64("upload.max_docx_size"),
+			MaxVideoSize: viper.GetInt64("upload.max_video_size"),
+
+			SigningKey: viper.GetString("upload.signing_key"),
 		},
 		Email: EmailConfig{
-			Host:     viper.GetString("EMAIL_HOST"),
-			Port:     viper.GetInt("EMAIL_PORT"),
-			Username: viper.GetString("EMAIL_USERNAME"),
-			Password: viper.GetString("EMAIL_PASSWORD"),
-			Reciever: viper.GetString("EMAIL_RECEIVER"),
-			IsTLS:    viper.GetBool("EMAIL_IS_TLS"),
+			Host:     viper.GetString("email.host"),
+			Port:     viper.GetInt("email.port"),
+			Username: viper.GetString("email.username"),
+			Password: viper.GetString("email.password"),
+			Reciever: viper.GetString("email.receiver"),
+			Sender:   viper.GetString("email.sender"),
+			IsTLS:    viper.GetBool("email.is_tls"),
+
+			Transport: viper.GetString("email.transport"),
+			FileDir:   viper.GetString("email.file_dir"),
+
+			OutboxInterval:    viper.GetInt("email.outbox_interval"),
+			OutboxBatchSize:   viper.GetInt("email.outbox_batch_size"),
+			OutboxMaxAttempts: viper.GetInt("email.outbox_max_attempts"),
 		},
 	}
 }
+
+// Load reads the config and validates the given groups, or every group when
+// none is given, so a command fails at startup with all problems listed.
+func Load(groups ...string) (*Config, error) {
+	if err := Validate(groups...); err != nil {
+		return nil, err
+	}
+	return NewConfig(), nil
+}
@@ -1,7 +1,6 @@
 package config
 
 import (
-	"desadangdang/database/seeds"
 	"fmt"
 
 	"github.com/rs/zerolog/log"
@@ -23,18 +22,16 @@ func (cfg Config) ConnectionPostgres() (*Postgres, error) {
 
 	db, err := gorm.Open(postgres.Open(dbConnString), &gorm.Config{})
 	if err != nil {
-		log.Error().Err(err).Msg("[ConnectionPostgres-1] Failed to connect to database " + cfg.Psql.Host)
+		log.Error().Err(err).Str("op", "ConnectionPostgres").Int("step", 1).Str("host", cfg.Psql.Host).Msg("failed to connect to database")
 		return nil, err
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Error().Err(err).Msg("[ConnectionPostgres-2] Failed to get database connection")
+		log.Error().Err(err).Str("op", "ConnectionPostgres").Int("step", 2).Msg("failed to get database connection")
 		return nil, err
 	}
 
-	seeds.SeedAdmin(db)
-
 	sqlDB.SetMaxOpenConns(cfg.Psql.DBMaxOpen)
 	sqlDB.SetMaxIdleConns(cfg.Psql.DBMaxIdle)
 
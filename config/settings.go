@@ -0,0 +1,343 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindInt
+	kindBool
+	kindDate
+	// kindPairs is "key=value; key=value", the values may hold commas.
+	kindPairs
+	// kindCIDRs is a comma separated list of ip ranges like 10.0.0.0/8.
+	kindCIDRs
+)
+
+// Groups select which part of the config a command needs validated.
+const (
+	GroupApp      = "app"
+	GroupDatabase = "database"
+	GroupStorage  = "storage"
+	GroupUpload   = "upload"
+	GroupEmail    = "email"
+)
+
+// setting is a single config value. Key is the nested key used in yaml and
+// toml files, Env is the environment variable and .env key overriding it.
+type setting struct {
+	Key      string
+	Env      string
+	Group    string
+	Kind     kind
+	Default  any
+	Required bool
+	Secret   bool
+	OneOf    []string
+	// RequiredWhen makes the setting required when another key has a value.
+	RequiredWhen [2]string
+}
+
+var settings = []setting{
+	{Key: "app.port", Env: "APP_PORT", Group: GroupApp, Kind: kindInt, Default: "8080", Required: true},
+	{Key: "app.env", Env: "APP_ENV", Group: GroupApp, Default: "development"},
+	{Key: "app.name", Env: "APP_NAME", Group: GroupApp, Default: "Desa Dangdang"},
+	{Key: "app.jwt_secret_key", Env: "JWT_SECRET_KEY", Group: GroupApp, Required: true, Secret: true},
+	{Key: "app.jwt_issuer", Env: "JWT_ISSUER", Group: GroupApp},
+	{Key: "app.health_timeout", Env: "APP_HEALTH_TIMEOUT", Group: GroupApp, Kind: kindInt, Default: 2},
+	{Key: "app.shutdown_delay", Env: "APP_SHUTDOWN_DELAY", Group: GroupApp, Kind: kindInt, Default: 0},
+	{Key: "app.trusted_proxies", Env: "APP_TRUSTED_PROXIES", Group: GroupApp, Kind: kindCIDRs},
+	{Key: "log.level", Env: "LOG_LEVEL", Group: GroupApp, Default: "info", OneOf: []string{"trace", "debug", "info", "warn", "error"}},
+	{Key: "log.format", Env: "LOG_FORMAT", Group: GroupApp, Default: "json", OneOf: []string{"json", "console"}},
+	{Key: "tracing.exporter", Env: "TRACING_EXPORTER", Group: GroupApp, Default: "none", OneOf: []string{"none", "stdout", "otlp"}},
+	{Key: "tracing.endpoint", Env: "TRACING_ENDPOINT", Group: GroupApp, RequiredWhen: [2]string{"tracing.exporter", "otlp"}},
+	{Key: "tracing.insecure", Env: "TRACING_INSECURE", Group: GroupApp, Kind: kindBool, Default: false},
+	{Key: "metrics.enabled", Env: "METRICS_ENABLED", Group: GroupApp, Kind: kindBool, Default: false},
+	{Key: "metrics.token", Env: "METRICS_TOKEN", Group: GroupApp, Secret: true, RequiredWhen: [2]string{"metrics.enabled", "true"}},
+	{Key: "api.legacy_routes", Env: "API_LEGACY_ROUTES", Group: GroupApp, Kind: kindBool, Default: true},
+	{Key: "api.legacy_sunset", Env: "API_LEGACY_SUNSET", Group: GroupApp, Kind: kindDate, Default: "2027-04-30", RequiredWhen: [2]string{"api.legacy_routes", "true"}},
+	{Key: "api.home_cache_ttl", Env: "API_HOME_CACHE_TTL", Group: GroupApp, Kind: kindInt, Default: 300},
+	{Key: "http_cache.control", Env: "HTTP_CACHE_CONTROL", Group: GroupApp, Default: "public, max-age=60"},
+	{Key: "http_cache.groups", Env: "HTTP_CACHE_GROUPS", Group: GroupApp, Kind: kindPairs},
+
+	{Key: "database.host", Env: "DATABASE_HOST", Group: GroupDatabase, Default: "localhost", Required: true},
+	{Key: "database.port", Env: "DATABASE_PORT", Group: GroupDatabase, Kind: kindInt, Default: "5432", Required: true},
+	{Key: "database.user", Env: "DATABASE_USER", Group: GroupDatabase, Default: "postgres", Required: true},
+	{Key: "database.password", Env: "DATABASE_PASSWORD", Group: GroupDatabase, Secret: true},
+	{Key: "database.name", Env: "DATABASE_NAME", Group: GroupDatabase, Required: true},
+	{Key: "database.max_open_connection", Env: "DATABASE_MAX_OPEN_CONNECTION", Group: GroupDatabase, Kind: kindInt, Default: 10},
+	{Key: "database.max_idle_connection", Env: "DATABASE_MAX_IDLE_CONNECTION", Group: GroupDatabase, Kind: kindInt, Default: 10},
+	{Key: "database.request_timeout", Env: "DATABASE_REQUEST_TIMEOUT", Group: GroupDatabase, Kind: kindInt, Default: 10},
+
+	{Key: "storage.driver", Env: "STORAGE_DRIVER", Group: GroupStorage, Default: "supabase", OneOf: []string{"supabase", "local", "s3"}},
+	{Key: "storage.local_dir", Env: "STORAGE_LOCAL_DIR", Group: GroupStorage, Default: "storage/uploads"},
+	{Key: "storage.public_url", Env: "STORAGE_PUBLIC_URL", Group: GroupStorage},
+	{Key: "supabase.storage_url", Env: "SUPABASE_STORAGE_URL", Group: GroupStorage, RequiredWhen: [2]string{"storage.driver", "supabase"}},
+	{Key: "supabase.storage_key", Env: "SUPABASE_STORAGE_KEY", Group: GroupStorage, Secret: true, RequiredWhen: [2]string{"storage.driver", "supabase"}},
+	{Key: "supabase.storage_bucket", Env: "SUPABASE_STORAGE_BUCKET", Group: GroupStorage, RequiredWhen: [2]string{"storage.driver", "supabase"}},
+	{Key: "s3.endpoint", Env: "S3_ENDPOINT", Group: GroupStorage, RequiredWhen: [2]string{"storage.driver", "s3"}},
+	{Key: "s3.access_key", Env: "S3_ACCESS_KEY", Group: GroupStorage, RequiredWhen: [2]string{"storage.driver", "s3"}},
+	{Key: "s3.secret_key", Env: "S3_SECRET_KEY", Group: GroupStorage, Secret: true, RequiredWhen: [2]string{"storage.driver", "s3"}},
+	{Key: "s3.bucket", Env: "S3_BUCKET", Group: GroupStorage, RequiredWhen: [2]string{"storage.driver", "s3"}},
+	{Key: "s3.region", Env: "S3_REGION", Group: GroupStorage},
+	{Key: "s3.use_ssl", Env: "S3_USE_SSL", Group: GroupStorage, Kind: kindBool, Default: true},
+
+	{Key: "upload.max_image_size", Env: "UPLOAD_MAX_IMAGE_SIZE", Group: GroupUpload, Kind: kindInt, Default: 5},
+	{Key: "upload.max_pdf_size", Env: "UPLOAD_MAX_PDF_SIZE", Group: GroupUpload, Kind: kindInt, Default: 20},
+	{Key: "upload.max_docx_size", Env: "UPLOAD_MAX_DOCX_SIZE", Group: GroupUpload, Kind: kindInt, Default: 20},
+	{Key: "upload.max_video_size", Env: "UPLOAD_MAX_VIDEO_SIZE", Group: GroupUpload, Kind: kindInt, Default: 200},
+	{Key: "upload.signing_key", Env: "UPLOAD_SIGNING_KEY", Group: GroupUpload, Secret: true},
+
+	{Key: "email.transport", Env: "EMAIL_TRANSPORT", Group: GroupEmail, Default: "smtp", OneOf: []string{"smtp", "file", "log"}},
+	{Key: "email.host", Env: "EMAIL_HOST", Group: GroupEmail, RequiredWhen: [2]string{"email.transport", "smtp"}},
+	{Key: "email.port", Env: "EMAIL_PORT", Group: GroupEmail, Kind: kindInt, Default: 587},
+	{Key: "email.username", Env: "EMAIL_USERNAME", Group: GroupEmail},
+	{Key: "email.password", Env: "EMAIL_PASSWORD", Group: GroupEmail, Secret: true},
+	{Key: "email.receiver", Env: "EMAIL_RECEIVER", Group: GroupEmail},
+	{Key: "email.sender", Env: "EMAIL_SENDER", Group: GroupEmail, RequiredWhen: [2]string{"email.transport", "smtp"}},
+	{Key: "email.is_tls", Env: "EMAIL_IS_TLS", Group: GroupEmail, Kind: kindBool, Default: true},
+	{Key: "email.file_dir", Env: "EMAIL_FILE_DIR", Group: GroupEmail, Default: "storage/mail"},
+	{Key: "email.outbox_interval", Env: "EMAIL_OUTBOX_INTERVAL", Group: GroupEmail, Kind: kindInt, Default: 10},
+	{Key: "email.outbox_batch_size", Env: "EMAIL_OUTBOX_BATCH_SIZE", Group: GroupEmail, Kind: kindInt, Default: 20},
+	{Key: "email.outbox_max_attempts", Env: "EMAIL_OUTBOX_MAX_ATTEMPTS", Group: GroupEmail, Kind: kindInt, Default: 5},
+}
+
+var (
+	registerOnce sync.Once
+	fileUsed     string
+)
+
+// register sets the defaults and binds every setting to its environment
+// variable, so env wins over the config file which wins over the default.
+func register() {
+	registerOnce.Do(func() {
+		for _, s := range settings {
+			if s.Default != nil {
+				viper.SetDefault(s.Key, s.Default)
+			}
+			viper.BindEnv(s.Key, s.Env)
+		}
+	})
+}
+
+// DefaultFiles are tried in order when no config file is given.
+var DefaultFiles = []string{".env", "config.yaml", "config.yml", "config.toml"}
+
+// ReadFile loads a .env, yaml, toml or json config file. The flat keys of a
+// .env file are mapped onto the nested keys used by the other formats.
+func ReadFile(path string) error {
+	register()
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if isDotenv(path) {
+		v.SetConfigType("env")
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return err
+	}
+
+	values := v.AllSettings()
+	if isDotenv(path) {
+		for _, s := range settings {
+			// An empty value keeps the default, like an empty env variable.
+			flat := strings.ToLower(s.Env)
+			if value := v.GetString(flat); value != "" {
+				setNested(values, s.Key, value)
+			}
+		}
+	}
+	if err := viper.MergeConfigMap(values); err != nil {
+		return err
+	}
+	fileUsed = path
+	return nil
+}
+
+// FileUsed returns the config file read by ReadFile, if any.
+func FileUsed() string {
+	return fileUsed
+}
+
+// FindFile returns the first default config file that exists.
+func FindFile() string {
+	for _, path := range DefaultFiles {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func isDotenv(path string) bool {
+	base := filepath.Base(path)
+	return base == ".env" || strings.HasSuffix(base, ".env") || strings.HasPrefix(base, ".env.")
+}
+
+func setNested(values map[string]any, key string, value any) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := values[part].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			values[part] = next
+		}
+		values = next
+	}
+	values[parts[len(parts)-1]] = value
+}
+
+// ValidationError lists every missing or malformed setting.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate checks the settings of the given groups, or of every group when
+// none is given.
+func Validate(groups ...string) error {
+	register()
+
+	wanted := map[string]bool{}
+	for _, g := range groups {
+		wanted[g] = true
+	}
+
+	problems := []string{}
+	for _, s := range settings {
+		if len(groups) > 0 && !wanted[s.Group] {
+			continue
+		}
+
+		value := strings.TrimSpace(cast.ToString(viper.Get(s.Key)))
+		required := s.Required
+		if when := s.RequiredWhen; when[0] != "" && viper.GetString(when[0]) == when[1] {
+			required = true
+		}
+		if value == "" {
+			if required {
+				problems = append(problems, fmt.Sprintf("%s (%s) is required", s.Env, s.Key))
+			}
+			continue
+		}
+
+		switch s.Kind {
+		case kindInt:
+			if _, err := cast.ToIntE(value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s (%s) must be a number, got %q", s.Env, s.Key, value))
+			}
+		case kindBool:
+			if _, err := cast.ToBoolE(value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s (%s) must be true or false, got %q", s.Env, s.Key, value))
+			}
+		case kindPairs:
+			if _, err := parsePairs(value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s (%s) %v, got %q", s.Env, s.Key, err, value))
+			}
+		case kindCIDRs:
+			for _, cidr := range splitList(value) {
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					problems = append(problems, fmt.Sprintf("%s (%s) must be ip ranges like 10.0.0.0/8 separated by commas, got %q", s.Env, s.Key, cidr))
+				}
+			}
+		case kindDate:
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s (%s) must be a date formatted as YYYY-MM-DD, got %q", s.Env, s.Key, value))
+			}
+		}
+		if len(s.OneOf) > 0 && !contains(s.OneOf, value) {
+			problems = append(problems, fmt.Sprintf("%s (%s) must be one of %s, got %q", s.Env, s.Key, strings.Join(s.OneOf, ", "), value))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// parsePairs reads a kindPairs value.
+func parsePairs(value string) (map[string]string, error) {
+	pairs := map[string]string{}
+	for _, pair := range strings.Split(value, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || key == "" || val == "" {
+			return nil, fmt.Errorf("must be key=value pairs separated by ;")
+		}
+		pairs[key] = val
+	}
+	return pairs, nil
+}
+
+// splitList reads a comma separated value, empty items are dropped.
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Effective is a resolved setting as shown by `config check`.
+type Effective struct {
+	Key    string
+	Env    string
+	Value  string
+	Source string
+}
+
+// EffectiveSettings returns every setting with its value and where it came
+// from. Secrets that are set are redacted.
+func EffectiveSettings() []Effective {
+	register()
+
+	results := make([]Effective, 0, len(settings))
+	for _, s := range settings {
+		value := cast.ToString(viper.Get(s.Key))
+
+		source := "default"
+		if _, ok := os.LookupEnv(s.Env); ok {
+			source = "env"
+		} else if viper.InConfig(s.Key) {
+			source = "file"
+		} else if s.Default == nil {
+			source = "unset"
+		}
+
+		if s.Secret && value != "" {
+			value = "********"
+		}
+		results = append(results, Effective{Key: s.Key, Env: s.Env, Value: value, Source: source})
+	}
+	return results
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// UploadSigningKey keys the upload ticket and local upload HMACs. Without
+// UPLOAD_SIGNING_KEY it is derived from the jwt secret, so a signature made
+// for one purpose is never valid for the other.
+func (cfg Config) UploadSigningKey() string {
+	if cfg.Upload.SigningKey != "" {
+		return cfg.Upload.SigningKey
+	}
+	key, err := hkdf.Key(sha256.New, []byte(cfg.App.JwtSecretKey), nil, "upload", sha256.Size)
+	if err != nil {
+		// only reachable when the length exceeds 255 hash blocks
+		panic(err)
+	}
+	return hex.EncodeToString(key)
+}
@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestUploadSigningKey(t *testing.T) {
+	cfg := Config{App: App{JwtSecretKey: "jwt-secret"}}
+
+	derived := cfg.UploadSigningKey()
+	if derived == "" || derived == cfg.App.JwtSecretKey {
+		t.Fatalf("derived key = %q, want a key apart from the jwt secret", derived)
+	}
+	if again := cfg.UploadSigningKey(); again != derived {
+		t.Errorf("derived key changed between calls: %q != %q", again, derived)
+	}
+
+	other := Config{App: App{JwtSecretKey: "other-secret"}}
+	if other.UploadSigningKey() == derived {
+		t.Error("different jwt secrets derived the same upload key")
+	}
+
+	cfg.Upload.SigningKey = "upload-secret"
+	if got := cfg.UploadSigningKey(); got != "upload-secret" {
+		t.Errorf("UploadSigningKey = %q, want the configured key", got)
+	}
+}
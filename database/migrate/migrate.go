@@ -0,0 +1,352 @@
+// Package migrate applies the versioned sql migrations and records every
+// applied version in the schema_versions table.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Table holds one row per applied version.
+	Table = "schema_versions"
+	// lockKey is the postgres advisory lock held while migrating so two
+	// deployments starting together don't apply the same version twice.
+	lockKey int64 = 7_311_022_451
+)
+
+var (
+	ErrInvalidName = errors.New("migration name may only contain letters, digits and underscores")
+	ErrNoDownFile  = errors.New("migration has no down file")
+
+	fileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	nameRegex = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Migration is a version found in the migration files, AppliedAt is nil
+// while it is pending.
+type Migration struct {
+	Version   int64
+	Name      string
+	Up        string
+	Down      string
+	AppliedAt *time.Time
+	// Missing is set for an applied version without a file.
+	Missing bool
+}
+
+type Migrator struct {
+	db    *sql.DB
+	files fs.FS
+}
+
+func New(db *sql.DB, files fs.FS) *Migrator {
+	return &Migrator{db: db, files: files}
+}
+
+// Up applies every pending migration in order and returns the applied ones.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		migrations, err := m.load(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range migrations {
+			if mig.AppliedAt != nil || mig.Missing {
+				continue
+			}
+			err = inTx(ctx, conn, mig.Up, "INSERT INTO "+Table+" (version, name) VALUES ($1, $2)", mig.Version, mig.Name)
+			if err != nil {
+				return fmt.Errorf("apply %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the last n applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		migrations, err := m.load(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && len(done) < n; i-- {
+			mig := migrations[i]
+			if mig.AppliedAt == nil {
+				continue
+			}
+			if mig.Missing || strings.TrimSpace(mig.Down) == "" {
+				return fmt.Errorf("roll back %d_%s: %w", mig.Version, mig.Name, ErrNoDownFile)
+			}
+			err = inTx(ctx, conn, mig.Down, "DELETE FROM "+Table+" WHERE version = $1", mig.Version)
+			if err != nil {
+				return fmt.Errorf("roll back %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status lists every migration file together with applied versions whose
+// file no longer exists.
+func (m *Migrator) Status(ctx context.Context) ([]Migration, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err = m.ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+	return m.load(ctx, conn)
+}
+
+// Version returns the newest applied version and the newest version in the
+// migration files. Unlike Status it never creates the version table.
+func (m *Migrator) Version(ctx context.Context) (int64, int64, error) {
+	migrations, err := parse(m.files)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var latest int64
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+
+	var applied int64
+	err = m.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM "+Table).Scan(&applied)
+	if err != nil {
+		return 0, latest, err
+	}
+	return applied, latest, nil
+}
+
+// Create writes an empty up and down file for the next version into dir and
+// returns their paths. The binary must be rebuilt to embed them.
+func Create(dir, name string) (string, string, error) {
+	name = strings.Trim(nameRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", ErrInvalidName
+	}
+
+	migrations, err := parse(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", version, name))
+	up, down := base+".up.sql", base+".down.sql"
+	for _, path := range []string{up, down} {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", "", err
+		}
+		if err = f.Close(); err != nil {
+			return "", "", err
+		}
+	}
+	return up, down, nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	// The lock belongs to the session, release it even when ctx is done.
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+
+	if err = m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// load merges the migration files with the applied versions.
+func (m *Migrator) load(ctx context.Context, conn *sql.Conn) ([]Migration, error) {
+	migrations, err := parse(m.files)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, applied_at FROM "+Table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	index := make(map[int64]int, len(migrations))
+	for i, mig := range migrations {
+		index[mig.Version] = i
+	}
+	for rows.Next() {
+		var (
+			version   int64
+			name      string
+			appliedAt time.Time
+		)
+		if err = rows.Scan(&version, &name, &appliedAt); err != nil {
+			return nil, err
+		}
+		if i, ok := index[version]; ok {
+			migrations[i].AppliedAt = &appliedAt
+			continue
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, AppliedAt: &appliedAt, Missing: true})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// parse reads the up and down files of every version in files.
+func parse(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = mig
+		} else if mig.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, mig.Name, match[2])
+		}
+
+		body, err := fs.ReadFile(files, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ensureTable creates the version table. A database migrated earlier with the
+// golang-migrate cli is taken over from its schema_migrations table.
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+Table+` (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", Table, err)
+	}
+
+	var legacy sql.NullString
+	if err = conn.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations')::text").Scan(&legacy); err != nil {
+		return err
+	}
+	if !legacy.Valid {
+		return nil
+	}
+
+	var count int64
+	if err = conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+Table).Scan(&count); err != nil || count > 0 {
+		return err
+	}
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err = conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("schema_migrations is dirty at version %d, fix it before migrating", version)
+	}
+
+	migrations, err := parse(m.files)
+	if err != nil {
+		return err
+	}
+	for _, mig := range migrations {
+		if mig.Version > version {
+			break
+		}
+		if _, err = conn.ExecContext(ctx, "INSERT INTO "+Table+" (version, name) VALUES ($1, $2)", mig.Version, mig.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, body, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if strings.TrimSpace(body) != "" {
+		if _, err = tx.ExecContext(ctx, body); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
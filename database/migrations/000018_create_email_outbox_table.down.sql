@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "email_outbox";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE email_outbox DROP COLUMN IF EXISTS text_body;
\ No newline at end of file
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS inquiries (
+    id SERIAL PRIMARY KEY,
+    name varchar(150) NOT NULL,
+    email varchar(150) NOT NULL,
+    phone_number varchar(17) NULL,
+    subject varchar(255) NOT NULL,
+    message text NOT NULL,
+    ip_address varchar(45) NULL,
+    read_at TIMESTAMP NULL,
+    archived_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_inquiries_read_at ON inquiries(read_at);
+
+CREATE TABLE IF NOT EXISTS inquiry_replies (
+    id SERIAL PRIMARY KEY,
+    inquiry_id INT REFERENCES inquiries(id) ON DELETE CASCADE,
+    user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    message text NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_inquiry_replies_inquiry_id ON inquiry_replies(inquiry_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS media (
+    id SERIAL PRIMARY KEY,
+    user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    file_key varchar(100) NOT NULL UNIQUE,
+    path text NOT NULL,
+    url text NOT NULL,
+    purpose varchar(20) NOT NULL,
+    content_type varchar(150) NOT NULL,
+    size bigint NOT NULL DEFAULT 0,
+    width INT NULL,
+    height INT NULL,
+    alt_text varchar(255) NULL,
+    original_name varchar(255) NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_media_purpose ON media(purpose);
+CREATE INDEX idx_media_created_at ON media(created_at);
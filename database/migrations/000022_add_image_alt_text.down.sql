@@ -0,0 +1,6 @@
+ALTER TABLE posts DROP COLUMN IF EXISTS featured_image_alt, DROP COLUMN IF EXISTS featured_image_caption;
+ALTER TABLE portofolio_sections DROP COLUMN IF EXISTS thumbnail_alt, DROP COLUMN IF EXISTS thumbnail_caption;
+ALTER TABLE about_company_keynotes DROP COLUMN IF EXISTS image_alt, DROP COLUMN IF EXISTS image_caption;
+ALTER TABLE our_teams DROP COLUMN IF EXISTS photo_alt, DROP COLUMN IF EXISTS photo_caption;
+ALTER TABLE client_sections DROP COLUMN IF EXISTS icon_alt, DROP COLUMN IF EXISTS icon_caption;
+ALTER TABLE hero_sections DROP COLUMN IF EXISTS banner_alt, DROP COLUMN IF EXISTS banner_caption;
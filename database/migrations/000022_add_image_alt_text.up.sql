@@ -0,0 +1,6 @@
+ALTER TABLE hero_sections ADD COLUMN banner_alt varchar(255) NULL, ADD COLUMN banner_caption varchar(255) NULL;
+ALTER TABLE client_sections ADD COLUMN icon_alt varchar(255) NULL, ADD COLUMN icon_caption varchar(255) NULL;
+ALTER TABLE our_teams ADD COLUMN photo_alt varchar(255) NULL, ADD COLUMN photo_caption varchar(255) NULL;
+ALTER TABLE about_company_keynotes ADD COLUMN image_alt varchar(255) NULL, ADD COLUMN image_caption varchar(255) NULL;
+ALTER TABLE portofolio_sections ADD COLUMN thumbnail_alt varchar(255) NULL, ADD COLUMN thumbnail_caption varchar(255) NULL;
+ALTER TABLE posts ADD COLUMN featured_image_alt varchar(255) NULL, ADD COLUMN featured_image_caption varchar(255) NULL;
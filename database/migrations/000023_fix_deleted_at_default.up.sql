@@ -0,0 +1,36 @@
+-- Rows inserted outside GORM picked up the old default and were hidden as
+-- soft deleted at the moment they were created.
+ALTER TABLE "users" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "users" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "hero_sections" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "hero_sections" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "client_sections" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "client_sections" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "faq_sections" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "faq_sections" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "about_companies" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "about_companies" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "about_company_keynotes" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "about_company_keynotes" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "service_sections" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "service_sections" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "appointments" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "appointments" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "portofolio_sections" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "portofolio_sections" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "portofolio_details" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "portofolio_details" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "portofolio_testimonials" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "portofolio_testimonials" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "our_teams" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "our_teams" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "contact_us" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "contact_us" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "service_details" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "service_details" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "statistics" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "statistics" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "posts" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "posts" SET deleted_at = NULL WHERE deleted_at = created_at;
+ALTER TABLE "profiles" ALTER COLUMN deleted_at DROP DEFAULT;
+UPDATE "profiles" SET deleted_at = NULL WHERE deleted_at = created_at;
\ No newline at end of file
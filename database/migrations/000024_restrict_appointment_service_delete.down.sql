@@ -0,0 +1,3 @@
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_service_id_fkey;
+ALTER TABLE appointments ADD CONSTRAINT appointments_service_id_fkey
+    FOREIGN KEY (service_id) REFERENCES service_sections(id) ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+-- Purging a service from the trash cascaded into every appointment booked
+-- for it, including the ones that were never deleted.
+ALTER TABLE appointments DROP CONSTRAINT IF EXISTS appointments_service_id_fkey;
+ALTER TABLE appointments ADD CONSTRAINT appointments_service_id_fkey
+    FOREIGN KEY (service_id) REFERENCES service_sections(id) ON DELETE RESTRICT;
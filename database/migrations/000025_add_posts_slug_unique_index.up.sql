@@ -0,0 +1,30 @@
+-- Live posts sharing a slug keep it on the oldest one, the others get their
+-- id appended so the index can be built. A slug taken by then, by a post or
+-- by a truncated slug, gets a counter after the id until it is free.
+DO $$
+DECLARE
+    duplicate RECORD;
+    suffix    TEXT;
+    candidate TEXT;
+    n         INT;
+BEGIN
+    FOR duplicate IN
+        SELECT p.id, p.slug FROM posts p
+        WHERE p.deleted_at IS NULL AND p.id <> (
+            SELECT MIN(o.id) FROM posts o WHERE o.deleted_at IS NULL AND o.slug = p.slug
+        )
+        ORDER BY p.id
+    LOOP
+        n := 0;
+        LOOP
+            suffix := '-' || duplicate.id || CASE WHEN n > 0 THEN '-' || n ELSE '' END;
+            candidate := LEFT(duplicate.slug, 100 - LENGTH(suffix)) || suffix;
+            EXIT WHEN NOT EXISTS (SELECT 1 FROM posts WHERE deleted_at IS NULL AND slug = candidate);
+            n := n + 1;
+        END LOOP;
+
+        UPDATE posts SET slug = candidate WHERE id = duplicate.id;
+    END LOOP;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_posts_slug ON posts(slug) WHERE deleted_at IS NULL;
@@ -0,0 +1,39 @@
+package seeds
+
+import (
+	"context"
+	"desadangdang/internal/core/domain/model"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SeedAppointments books sample appointments on the first demo service. It
+// needs the content seeder, without a service it does nothing.
+func SeedAppointments(ctx context.Context, db *gorm.DB, opts Options) error {
+	service := model.ServiceSection{}
+	err := db.Where("name = ?", "Population Administration").First(&service).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	appointments := []model.Appointment{
+		{Name: "Andi Wijaya", PhoneNumber: "081234567890", Email: "andi@example.com", Brief: "Renew family card", Budget: 0},
+		{Name: "Rina Putri", PhoneNumber: "081298765432", Email: "rina@example.com", Brief: "Letter of domicile for a new job", Budget: 0},
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, appointment := range appointments {
+			appointment.ServiceID = service.ID
+			appointment.MeetAt = time.Now().AddDate(0, 0, i+1).Truncate(time.Hour)
+			if err := tx.FirstOrCreate(&appointment, model.Appointment{ServiceID: service.ID, Email: appointment.Email}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,162 @@
+package seeds
+
+import (
+	"context"
+	"desadangdang/internal/core/domain/model"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// demoImage points at a placeholder so demo content renders without uploads.
+func demoImage(text string) string {
+	return "https://placehold.co/1200x800?text=" + url.QueryEscape(text)
+}
+
+func demoString(s string) *string {
+	return &s
+}
+
+// SeedContent fills every landing page section with demo content. Rows are
+// matched on their name or title so running it twice adds nothing.
+func SeedContent(ctx context.Context, db *gorm.DB, opts Options) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		hero := model.HeroSection{
+			SubHeading:    "A small village with a big heart",
+			PathBanner:    demoImage("Hero"),
+			BannerAlt:     demoString("Rice fields around the village at sunrise"),
+			BannerCaption: demoString("Morning in Dangdang"),
+		}
+		if err := tx.FirstOrCreate(&hero, model.HeroSection{Heading: "Welcome to Desa Dangdang"}).Error; err != nil {
+			return err
+		}
+
+		for _, name := range []string{"Village Cooperative", "Local Farmers Group", "Youth Organization"} {
+			client := model.ClientSection{
+				PathIcon: demoImage(name),
+				IconAlt:  demoString(name + " logo"),
+			}
+			if err := tx.FirstOrCreate(&client, model.ClientSection{Name: name}).Error; err != nil {
+				return err
+			}
+		}
+
+		faqs := map[string]string{
+			"How do I request a village letter?":   "Visit the village office on weekdays or book an appointment online.",
+			"Where can I find the village budget?": "The yearly budget is published on the profile page.",
+			"Who do I contact for an emergency?":   "Call the village office, the number is listed on the contact page.",
+		}
+		for title, description := range faqs {
+			faq := model.FaqSection{Description: description}
+			if err := tx.FirstOrCreate(&faq, model.FaqSection{Title: title}).Error; err != nil {
+				return err
+			}
+		}
+
+		about := model.AboutCompany{}
+		if err := tx.FirstOrCreate(&about, model.AboutCompany{Description: "Desa Dangdang is a farming village that works together to build a better future for its people."}).Error; err != nil {
+			return err
+		}
+		for _, keypoint := range []string{"Community first", "Transparent government", "Sustainable farming"} {
+			keynote := model.AboutCompanyKeynote{
+				PathImage: demoString(demoImage(keypoint)),
+				ImageAlt:  demoString(keypoint),
+			}
+			if err := tx.FirstOrCreate(&keynote, model.AboutCompanyKeynote{AboutCompanyID: about.ID, Keypoint: keypoint}).Error; err != nil {
+				return err
+			}
+		}
+
+		services := map[string]string{
+			"Population Administration": "Identity cards, family cards and letters of domicile.",
+			"Business Permits":          "Letters for small businesses and market stalls.",
+		}
+		for name, tagline := range services {
+			section := model.ServiceSection{
+				PathIcon: demoImage(name),
+				Tagline:  tagline,
+			}
+			if err := tx.FirstOrCreate(&section, model.ServiceSection{Name: name}).Error; err != nil {
+				return err
+			}
+
+			detail := model.ServiceDetail{
+				PathImage:   demoImage(name),
+				Description: "Bring your identity card and the supporting documents to the village office. " + tagline,
+			}
+			if err := tx.FirstOrCreate(&detail, model.ServiceDetail{ServiceID: section.ID, Title: name + " Requirements"}).Error; err != nil {
+				return err
+			}
+		}
+
+		portofolio := model.PortofolioSection{
+			Tagline:      "Projects built together with the community",
+			Thumbnail:    demoString(demoImage("Village Road")),
+			ThumbnailAlt: demoString("The new paved village road"),
+		}
+		if err := tx.FirstOrCreate(&portofolio, model.PortofolioSection{Name: "Village Road Renovation"}).Error; err != nil {
+			return err
+		}
+		detail := model.PortofolioDetail{
+			Category:    "Infrastructure",
+			ClientName:  "Desa Dangdang",
+			ProjectDate: time.Date(2024, time.August, 17, 0, 0, 0, 0, time.UTC),
+			Description: "Two kilometres of the main road were paved by village workers.",
+		}
+		if err := tx.FirstOrCreate(&detail, model.PortofolioDetail{PortofolioSectionID: portofolio.ID, Title: "Main Road Paving"}).Error; err != nil {
+			return err
+		}
+		testimonial := model.PortofolioTestimonial{
+			Thumbnail: demoImage("Testimonial"),
+			Message:   "The market is much easier to reach since the road was finished.",
+			Role:      "Market trader",
+		}
+		if err := tx.FirstOrCreate(&testimonial, model.PortofolioTestimonial{PortofolioSectionID: portofolio.ID, ClientName: "Ibu Sari"}).Error; err != nil {
+			return err
+		}
+
+		team := map[string]string{
+			"Budi Santoso": "Village Head",
+			"Dewi Lestari": "Village Secretary",
+		}
+		for name, role := range team {
+			member := model.OurTeam{
+				Role:      role,
+				PathPhoto: demoImage(name),
+				PhotoAlt:  demoString("Portrait of " + name),
+				Tagline:   "Serving the people of Desa Dangdang",
+			}
+			if err := tx.FirstOrCreate(&member, model.OurTeam{Name: name}).Error; err != nil {
+				return err
+			}
+		}
+
+		contact := model.ContactUs{
+			LocationName: "Village Office",
+			Address:      "Jl. Raya Dangdang No. 1",
+			PhoneNumber:  "0211234567",
+		}
+		if err := tx.FirstOrCreate(&contact, model.ContactUs{CompanyName: "Kantor Desa Dangdang"}).Error; err != nil {
+			return err
+		}
+
+		statistics := map[string]int64{
+			"Residents":  4200,
+			"Households": 1150,
+			"Hamlets":    6,
+		}
+		for name, total := range statistics {
+			statistic := model.Statistic{
+				Total: total,
+				Icon:  "fa-chart-bar",
+			}
+			if err := tx.FirstOrCreate(&statistic, model.Statistic{Name: name}).Error; err != nil {
+				return err
+			}
+		}
+
+		profile := model.Profile{Content: "<p>Desa Dangdang sits between rice fields and the river.</p>"}
+		return tx.FirstOrCreate(&profile, model.Profile{Title: "Village History"}).Error
+	})
+}
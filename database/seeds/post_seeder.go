@@ -0,0 +1,43 @@
+package seeds
+
+import (
+	"context"
+	"desadangdang/internal/core/domain/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SeedPosts adds sample blog posts, matched on their slug.
+func SeedPosts(ctx context.Context, db *gorm.DB, opts Options) error {
+	posts := []model.Post{
+		{
+			Title:   "Harvest Festival 2024",
+			Slug:    "harvest-festival-2024",
+			Content: "<p>The whole village came together to celebrate this year's harvest.</p>",
+		},
+		{
+			Title:   "New Village Library Opens",
+			Slug:    "new-village-library-opens",
+			Content: "<p>The library is open every afternoon for students and parents.</p>",
+		},
+		{
+			Title:   "Clean River Program",
+			Slug:    "clean-river-program",
+			Content: "<p>Volunteers clean the river banks every first Sunday of the month.</p>",
+		},
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, post := range posts {
+			post.Author = "admin"
+			post.FeaturedImage = demoImage(post.Title)
+			post.FeaturedImageAlt = demoString(post.Title)
+			post.PublishedAt = time.Now().AddDate(0, 0, -7*(len(posts)-i))
+			if err := tx.FirstOrCreate(&post, model.Post{Slug: post.Slug}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,86 @@
+package seeds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownSeeder    = errors.New("unknown seeder")
+	ErrDemoInProduction = errors.New("demo data is not allowed in production")
+)
+
+// Options carries the values seeders take from flags or the environment.
+type Options struct {
+	AdminName     string
+	AdminEmail    string
+	AdminPassword string
+}
+
+// Seeder inserts a named set of rows. Every seeder is idempotent, running it
+// again leaves existing rows alone.
+type Seeder struct {
+	Name        string
+	Description string
+	// Demo seeders insert sample content and never run in production.
+	Demo bool
+	Run  func(ctx context.Context, db *gorm.DB, opts Options) error
+}
+
+// Seeders lists every seeder in the order they run.
+func Seeders() []Seeder {
+	return []Seeder{
+		{Name: "admin", Description: "admin user from --admin-* flags or SEED_ADMIN_* env", Run: SeedAdmin},
+		{Name: "content", Description: "demo content for every landing page section", Demo: true, Run: SeedContent},
+		{Name: "posts", Description: "sample blog posts", Demo: true, Run: SeedPosts},
+		{Name: "appointments", Description: "sample appointments for the demo services", Demo: true, Run: SeedAppointments},
+	}
+}
+
+// IsProduction reports whether env names a production environment.
+func IsProduction(env string) bool {
+	env = strings.ToLower(strings.TrimSpace(env))
+	return env == "production" || env == "prod"
+}
+
+// Run runs the named seeders in their declared order. Without names every
+// seeder allowed in env runs, naming a demo seeder in production fails.
+func Run(ctx context.Context, db *gorm.DB, env string, names []string, opts Options) error {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	selected := []Seeder{}
+	for _, seeder := range Seeders() {
+		if len(names) > 0 && !wanted[seeder.Name] {
+			continue
+		}
+		delete(wanted, seeder.Name)
+
+		if seeder.Demo && IsProduction(env) {
+			if len(names) > 0 {
+				return fmt.Errorf("%s: %w", seeder.Name, ErrDemoInProduction)
+			}
+			log.Info().Msgf("Skipping demo seeder %s in production", seeder.Name)
+			continue
+		}
+		selected = append(selected, seeder)
+	}
+	for name := range wanted {
+		return fmt.Errorf("%s: %w", name, ErrUnknownSeeder)
+	}
+
+	for _, seeder := range selected {
+		if err := seeder.Run(ctx, db.WithContext(ctx), opts); err != nil {
+			return fmt.Errorf("seed %s: %w", seeder.Name, err)
+		}
+		log.Info().Msgf("Seeder %s has run", seeder.Name)
+	}
+	return nil
+}
@@ -1,28 +1,56 @@
 package seeds
 
 import (
+	"context"
 	"desadangdang/internal/core/domain/model"
 	"desadangdang/utils/conv"
+	"errors"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
-func SeedAdmin(db *gorm.DB) {
-	bytes, err := conv.HashPassword("admin123")
-	if err != nil {
-		log.Fatal().Err(err).Msg(err.Error())
+const minAdminPasswordLength = 8
+
+var ErrAdminCredentials = errors.New("admin email and a password of at least 8 characters are required")
+
+// SeedAdmin creates the admin user when no user has its email yet. An existing
+// admin keeps its password, so the seeder never resets a changed password.
+func SeedAdmin(ctx context.Context, db *gorm.DB, opts Options) error {
+	email := strings.ToLower(strings.TrimSpace(opts.AdminEmail))
+	if email == "" || len(opts.AdminPassword) < minAdminPasswordLength {
+		return ErrAdminCredentials
+	}
+
+	name := strings.TrimSpace(opts.AdminName)
+	if name == "" {
+		name = "admin"
 	}
 
-	admin := model.User{
-		Name:     "admin",
-		Email:    "admin@mail.com",
-		Password: bytes,
+	admin := model.User{}
+	err := db.Where("email = ?", email).First(&admin).Error
+	if err == nil {
+		log.Info().Msg("Admin user already exists")
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
 	}
 
-	if err = db.FirstOrCreate(&admin, model.User{Email: "admin@mail.com"}).Error; err != nil {
-		log.Fatal().Err(err).Msg(err.Error())
-	} else {
-		log.Info().Msg("Admin user has been seeded")
+	password, err := conv.HashPassword(opts.AdminPassword)
+	if err != nil {
+		return err
+	}
+
+	admin = model.User{
+		Name:     name,
+		Email:    email,
+		Password: password,
+	}
+	if err = db.Create(&admin).Error; err != nil {
+		return err
 	}
+	log.Info().Msg("Admin user has been seeded")
+	return nil
 }
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type AboutCompanyHandlerInterface interface {
@@ -32,16 +33,13 @@ func (cs *aboutCompanyHandler) FetchAllCompanyHome(c echo.Context) error {
 	var (
 		respCompany = response.AboutCompanyResponse{}
 		resp        = response.DefaultSuccessResponse{}
-		respError   = response.ErrorResponseDefault{}
 		ctx         = c.Request().Context()
 	)
 
 	result, err := cs.aboutCompanyService.FetchAllCompanyAndKeynote(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllCompanyHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllCompanyHome", 1, err)
+		return err
 	}
 
 	respCompany.ID = result.ID
@@ -52,6 +50,8 @@ func (cs *aboutCompanyHandler) FetchAllCompanyHome(c echo.Context) error {
 			AboutCompanyID: val.AboutCompanyID,
 			Keynote:        val.Keynote,
 			PathImage:      val.PathImage,
+			ImageAlt:       val.ImageAlt,
+			ImageCaption:   val.ImageCaption,
 		})
 	}
 	resp.Meta.Message = "Success fetch all company home"
@@ -65,32 +65,25 @@ func (cs *aboutCompanyHandler) FetchAllCompanyHome(c echo.Context) error {
 // CreateAboutCompany implements AboutCompanyHandlerInterface.
 func (cs *aboutCompanyHandler) CreateAboutCompany(c echo.Context) error {
 	var (
-		req       = request.AboutCompanyRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.AboutCompanyRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateAboutCompany - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateAboutCompany", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateAboutCompany - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateAboutCompany", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateAboutCompany - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateAboutCompany", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.AboutCompanyEntity{
@@ -99,10 +92,8 @@ func (cs *aboutCompanyHandler) CreateAboutCompany(c echo.Context) error {
 
 	err = cs.aboutCompanyService.CreateAboutCompany(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateAboutCompany - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateAboutCompany", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create about company"
@@ -115,34 +106,27 @@ func (cs *aboutCompanyHandler) CreateAboutCompany(c echo.Context) error {
 // DeleteByIDAboutCompany implements AboutCompanyHandlerInterface.
 func (cs *aboutCompanyHandler) DeleteByIDAboutCompany(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDAboutCompany - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDAboutCompany", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAboutCompany := c.Param("id")
 	id, err := conv.StringToInt64(idAboutCompany)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDAboutCompany - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDAboutCompany", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.aboutCompanyService.DeleteByIDAboutCompany(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDAboutCompany - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDAboutCompany", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete client section"
 	resp.Meta.Status = true
@@ -155,41 +139,32 @@ func (cs *aboutCompanyHandler) DeleteByIDAboutCompany(c echo.Context) error {
 // EditByIDAboutCompany implements AboutCompanyHandlerInterface.
 func (cs *aboutCompanyHandler) EditByIDAboutCompany(c echo.Context) error {
 	var (
-		req       = request.AboutCompanyRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.AboutCompanyRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDAboutCompany - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDAboutCompany", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAboutCompany := c.Param("id")
 	id, err := conv.StringToInt64(idAboutCompany)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompany - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompany", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompany - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompany", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompany - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompany", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.AboutCompanyEntity{
@@ -199,10 +174,8 @@ func (cs *aboutCompanyHandler) EditByIDAboutCompany(c echo.Context) error {
 
 	err = cs.aboutCompanyService.EditByIDAboutCompany(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompany - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompany", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit about company"
 	resp.Meta.Status = true
@@ -215,25 +188,20 @@ func (cs *aboutCompanyHandler) EditByIDAboutCompany(c echo.Context) error {
 func (cs *aboutCompanyHandler) FetchAllAboutCompany(c echo.Context) error {
 	var (
 		resp             = response.DefaultSuccessResponse{}
-		respError        = response.ErrorResponseDefault{}
 		ctx              = c.Request().Context()
 		respAboutCompany = []response.AboutCompanyResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllAboutCompany - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllAboutCompany", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.aboutCompanyService.FetchAllAboutCompany(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllAboutCompany - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllAboutCompany", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -254,34 +222,27 @@ func (cs *aboutCompanyHandler) FetchAllAboutCompany(c echo.Context) error {
 func (cs *aboutCompanyHandler) FetchByIDAboutCompany(c echo.Context) error {
 	var (
 		resp             = response.DefaultSuccessResponse{}
-		respError        = response.ErrorResponseDefault{}
 		ctx              = c.Request().Context()
 		respAboutCompany = response.AboutCompanyResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDAboutCompany - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDAboutCompany", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAboutCompany := c.Param("id")
 	id, err := conv.StringToInt64(idAboutCompany)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDAboutCompany - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDAboutCompany", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.aboutCompanyService.FetchByIDAboutCompany(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDAboutCompany - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDAboutCompany", 3, err)
+		return err
 	}
 
 	respAboutCompany.ID = result.ID
@@ -293,7 +254,7 @@ func (cs *aboutCompanyHandler) FetchByIDAboutCompany(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewAboutCompanyHandler(e *echo.Echo, aboutCompanyService service.AboutCompanyServiceInterface, cfg *config.Config) AboutCompanyHandlerInterface {
+func NewAboutCompanyHandler(e *echo.Group, aboutCompanyService service.AboutCompanyServiceInterface, cfg *config.Config) AboutCompanyHandlerInterface {
 	h := &aboutCompanyHandler{
 		aboutCompanyService: aboutCompanyService,
 	}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type AboutCompanyKeynoteHandlerInterface interface {
@@ -31,34 +32,27 @@ type aboutCompanyKeynoteHandler struct {
 func (cs *aboutCompanyKeynoteHandler) FetchByCompanyID(c echo.Context) error {
 	var (
 		resp                    = response.DefaultSuccessResponse{}
-		respError               = response.ErrorResponseDefault{}
 		ctx                     = c.Request().Context()
 		respAboutCompanyKeynote = []response.AboutCompanyKeynoteResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByCompanyID - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByCompanyID", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAboutCompany := c.Param("id")
 	id, err := conv.StringToInt64(idAboutCompany)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByCompanyID - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByCompanyID", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	results, err := cs.aboutCompanyKeynoteService.FetchByCompanyID(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByCompanyID - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByCompanyID", 3, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -67,6 +61,8 @@ func (cs *aboutCompanyKeynoteHandler) FetchByCompanyID(c echo.Context) error {
 			AboutCompanyID:          val.AboutCompanyID,
 			Keynote:                 val.Keynote,
 			PathImage:               val.PathImage,
+			ImageAlt:                val.ImageAlt,
+			ImageCaption:            val.ImageCaption,
 			AboutCompanyDescription: val.AboutCompanyDescription,
 		})
 	}
@@ -81,46 +77,39 @@ func (cs *aboutCompanyKeynoteHandler) FetchByCompanyID(c echo.Context) error {
 // CreateAboutCompanyKeynote implements AboutCompanyKeynoteHandlerInterface.
 func (cs *aboutCompanyKeynoteHandler) CreateAboutCompanyKeynote(c echo.Context) error {
 	var (
-		req       = request.AboutCompanyKeynoteRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.AboutCompanyKeynoteRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateAboutCompanyKeynote - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateAboutCompanyKeynote", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateAboutCompanyKeynote - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateAboutCompanyKeynote", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateAboutCompanyKeynote - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateAboutCompanyKeynote", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.AboutCompanyKeynoteEntity{
 		AboutCompanyID: req.AboutCompanyID,
 		Keynote:        req.Keynote,
 		PathImage:      req.PathImage,
+		ImageAlt:       req.ImageAlt,
+		ImageCaption:   req.ImageCaption,
 	}
 
 	err = cs.aboutCompanyKeynoteService.CreateAboutCompanyKeynote(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateAboutCompanyKeynote - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateAboutCompanyKeynote", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create about company keynote"
@@ -133,34 +122,27 @@ func (cs *aboutCompanyKeynoteHandler) CreateAboutCompanyKeynote(c echo.Context)
 // DeleteByIDAboutCompanyKeynote implements AboutCompanyKeynoteHandlerInterface.
 func (cs *aboutCompanyKeynoteHandler) DeleteByIDAboutCompanyKeynote(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDAboutCompanyKeynote - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDAboutCompanyKeynote", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAboutCompanyKeynote := c.Param("id")
 	id, err := conv.StringToInt64(idAboutCompanyKeynote)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDAboutCompanyKeynote - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDAboutCompanyKeynote", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.aboutCompanyKeynoteService.DeleteByIDAboutCompanyKeynote(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDAboutCompanyKeynote - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDAboutCompanyKeynote", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete about company keynote"
 	resp.Meta.Status = true
@@ -173,41 +155,32 @@ func (cs *aboutCompanyKeynoteHandler) DeleteByIDAboutCompanyKeynote(c echo.Conte
 // EditByIDAboutCompanyKeynote implements AboutCompanyKeynoteHandlerInterface.
 func (cs *aboutCompanyKeynoteHandler) EditByIDAboutCompanyKeynote(c echo.Context) error {
 	var (
-		req       = request.AboutCompanyKeynoteRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.AboutCompanyKeynoteRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDAboutCompanyKeynote - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDAboutCompanyKeynote", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAboutCompanyKeynote := c.Param("id")
 	id, err := conv.StringToInt64(idAboutCompanyKeynote)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompanyKeynote - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompanyKeynote", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompanyKeynote - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompanyKeynote", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompanyKeynote - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompanyKeynote", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.AboutCompanyKeynoteEntity{
@@ -215,14 +188,14 @@ func (cs *aboutCompanyKeynoteHandler) EditByIDAboutCompanyKeynote(c echo.Context
 		AboutCompanyID: req.AboutCompanyID,
 		Keynote:        req.Keynote,
 		PathImage:      req.PathImage,
+		ImageAlt:       req.ImageAlt,
+		ImageCaption:   req.ImageCaption,
 	}
 
 	err = cs.aboutCompanyKeynoteService.EditByIDAboutCompanyKeynote(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDAboutCompanyKeynote - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDAboutCompanyKeynote", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit about company keynote"
 	resp.Meta.Status = true
@@ -235,25 +208,20 @@ func (cs *aboutCompanyKeynoteHandler) EditByIDAboutCompanyKeynote(c echo.Context
 func (cs *aboutCompanyKeynoteHandler) FetchAllAboutCompanyKeynote(c echo.Context) error {
 	var (
 		resp                    = response.DefaultSuccessResponse{}
-		respError               = response.ErrorResponseDefault{}
 		ctx                     = c.Request().Context()
 		respAboutCompanyKeynote = []response.AboutCompanyKeynoteResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllAboutCompanyKeynote - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllAboutCompanyKeynote", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.aboutCompanyKeynoteService.FetchAllAboutCompanyKeynote(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllAboutCompanyKeynote - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllAboutCompanyKeynote", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -262,6 +230,8 @@ func (cs *aboutCompanyKeynoteHandler) FetchAllAboutCompanyKeynote(c echo.Context
 			AboutCompanyID:          val.AboutCompanyID,
 			Keynote:                 val.Keynote,
 			PathImage:               val.PathImage,
+			ImageAlt:                val.ImageAlt,
+			ImageCaption:            val.ImageCaption,
 			AboutCompanyDescription: val.AboutCompanyDescription,
 		})
 	}
@@ -277,40 +247,35 @@ func (cs *aboutCompanyKeynoteHandler) FetchAllAboutCompanyKeynote(c echo.Context
 func (cs *aboutCompanyKeynoteHandler) FetchByIDAboutCompanyKeynote(c echo.Context) error {
 	var (
 		resp                    = response.DefaultSuccessResponse{}
-		respError               = response.ErrorResponseDefault{}
 		ctx                     = c.Request().Context()
 		respAboutCompanyKeynote = response.AboutCompanyKeynoteResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDAboutCompanyKeynote - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDAboutCompanyKeynote", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAboutCompanyKeynote := c.Param("id")
 	id, err := conv.StringToInt64(idAboutCompanyKeynote)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDAboutCompanyKeynote - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDAboutCompanyKeynote", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.aboutCompanyKeynoteService.FetchByIDAboutCompanyKeynote(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDAboutCompanyKeynote - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDAboutCompanyKeynote", 3, err)
+		return err
 	}
 
 	respAboutCompanyKeynote.ID = result.ID
 	respAboutCompanyKeynote.AboutCompanyID = result.AboutCompanyID
 	respAboutCompanyKeynote.Keynote = result.Keynote
 	respAboutCompanyKeynote.PathImage = result.PathImage
+	respAboutCompanyKeynote.ImageAlt = result.ImageAlt
+	respAboutCompanyKeynote.ImageCaption = result.ImageCaption
 	respAboutCompanyKeynote.AboutCompanyDescription = result.AboutCompanyDescription
 	resp.Meta.Message = "Success fetch about company keynote by ID"
 	resp.Meta.Status = true
@@ -319,7 +284,7 @@ func (cs *aboutCompanyKeynoteHandler) FetchByIDAboutCompanyKeynote(c echo.Contex
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewAboutCompanyKeynoteHandler(e *echo.Echo, aboutCompanyKeynoteService service.AboutCompanyKeynoteServiceInterface, cfg *config.Config) AboutCompanyKeynoteHandlerInterface {
+func NewAboutCompanyKeynoteHandler(e *echo.Group, aboutCompanyKeynoteService service.AboutCompanyKeynoteServiceInterface, cfg *config.Config) AboutCompanyKeynoteHandlerInterface {
 	h := &aboutCompanyKeynoteHandler{
 		aboutCompanyKeynoteService: aboutCompanyKeynoteService,
 	}
@@ -4,15 +4,17 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/export"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type AppointmentHandlerInterface interface {
@@ -29,32 +31,25 @@ type appointmentHandler struct {
 // CreateAppointment implements AppointmentHandlerInterface.
 func (cs *appointmentHandler) CreateAppointment(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		req       = request.AppointmentRequest{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		req  = request.AppointmentRequest{}
+		ctx  = c.Request().Context()
 	)
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateAppointment - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateAppointment", 1, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateAppointment - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateAppointment", 2, err)
+		return apperr.Validation(err.Error())
 	}
 
 	stringProjectDate, err := time.Parse("2006-01-02", req.MeetAt)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateAppointment - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateAppointment", 3, err)
+		return apperr.Validation("date must be formatted as YYYY-MM-DD").Wrap(err)
 	}
 
 	reqEntity := entity.AppointmentEntity{
@@ -69,10 +64,8 @@ func (cs *appointmentHandler) CreateAppointment(c echo.Context) error {
 
 	err = cs.appointmentService.CreateAppointment(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateAppointment - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateAppointment", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create appointment"
@@ -85,34 +78,27 @@ func (cs *appointmentHandler) CreateAppointment(c echo.Context) error {
 // DeleteByIDAppointment implements AppointmentHandlerInterface.
 func (cs *appointmentHandler) DeleteByIDAppointment(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDAppointment - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDAppointment", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAppointment := c.Param("id")
 	id, err := conv.StringToInt64(idAppointment)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDAppointment - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDAppointment", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.appointmentService.DeleteByIDAppointment(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDAppointment - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDAppointment", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete appointment"
 	resp.Meta.Status = true
@@ -126,25 +112,41 @@ func (cs *appointmentHandler) DeleteByIDAppointment(c echo.Context) error {
 func (cs *appointmentHandler) FetchAllAppointment(c echo.Context) error {
 	var (
 		resp            = response.DefaultSuccessResponse{}
-		respError       = response.ErrorResponseDefault{}
+		req             = request.AppointmentFilterRequest{}
 		ctx             = c.Request().Context()
 		respAppointment = []response.AppointmentResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllAppointment - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllAppointment", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
-	results, err := cs.appointmentService.FetchAllAppointment(ctx)
+	if err = c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllAppointment", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err = c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllAppointment", 3, err)
+		return apperr.Validation(err.Error())
+	}
+
+	filter, err := appointmentFilter(req)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllAppointment", 4, err)
+		return apperr.Validation("date must be formatted as YYYY-MM-DD").Wrap(err)
+	}
+
+	if export.IsSupported(req.Format) {
+		return cs.exportAppointment(c, filter, req.Format)
+	}
+
+	results, err := cs.appointmentService.FetchAllAppointment(ctx, filter)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllAppointment - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllAppointment", 5, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -168,38 +170,77 @@ func (cs *appointmentHandler) FetchAllAppointment(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+func (cs *appointmentHandler) exportAppointment(c echo.Context, filter entity.AppointmentFilter, format string) error {
+	headers := []string{"ID", "Name", "Email", "Phone Number", "Service", "Meeting Date", "Budget", "Brief"}
+
+	return streamExport(c, "appointments", format, headers, func(w export.Writer) error {
+		return cs.appointmentService.StreamAppointment(c.Request().Context(), filter, func(val entity.AppointmentEntity) error {
+			return w.WriteRow([]interface{}{
+				val.ID,
+				val.Name,
+				val.Email,
+				val.PhoneNumber,
+				val.ServiceName,
+				val.MeetAt,
+				export.Decimal{Value: val.Budget, Scale: 1},
+				val.Brief,
+			})
+		})
+	})
+}
+
+func appointmentFilter(req request.AppointmentFilterRequest) (entity.AppointmentFilter, error) {
+	filter := entity.AppointmentFilter{
+		Search:    req.Search,
+		ServiceID: req.ServiceID,
+	}
+
+	if req.StartDate != "" {
+		startDate, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return filter, err
+		}
+		filter.StartDate = &startDate
+	}
+
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return filter, err
+		}
+		// end_date is inclusive
+		endDate = endDate.AddDate(0, 0, 1)
+		filter.EndDate = &endDate
+	}
+
+	return filter, nil
+}
+
 // FetchByIDAppointment implements AppointmentHandlerInterface.
 func (cs *appointmentHandler) FetchByIDAppointment(c echo.Context) error {
 	var (
 		resp            = response.DefaultSuccessResponse{}
-		respError       = response.ErrorResponseDefault{}
 		ctx             = c.Request().Context()
 		respAppointment = response.AppointmentResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDAppointment - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDAppointment", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idAppointment := c.Param("id")
 	id, err := conv.StringToInt64(idAppointment)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDAppointment - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDAppointment", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.appointmentService.FetchByIDAppointment(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDAppointment - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDAppointment", 3, err)
+		return err
 	}
 
 	respAppointment.ID = result.ID
@@ -218,7 +259,7 @@ func (cs *appointmentHandler) FetchByIDAppointment(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewAppointmentHandler(e *echo.Echo, appointmentService service.AppointmentServiceInterface, cfg *config.Config) AppointmentHandlerInterface {
+func NewAppointmentHandler(e *echo.Group, appointmentService service.AppointmentServiceInterface, cfg *config.Config) AppointmentHandlerInterface {
 	h := &appointmentHandler{
 		appointmentService: appointmentService,
 	}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type ClientSectionHandlerInterface interface {
@@ -33,23 +34,22 @@ func (cs *clientSectionHandler) FetchAllClientSectionHome(c echo.Context) error
 	var (
 		respClients = []response.ClientSectionResponse{}
 		resp        = response.DefaultSuccessResponse{}
-		respError   = response.ErrorResponseDefault{}
 		ctx         = c.Request().Context()
 	)
 
 	results, err := cs.clientSectionService.FetchAllClientSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllClientSectionHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllClientSectionHome", 1, err)
+		return err
 	}
 
 	for _, val := range results {
 		respClients = append(respClients, response.ClientSectionResponse{
-			ID:       val.ID,
-			Name:     val.Name,
-			PathIcon: val.PathIcon,
+			ID:          val.ID,
+			Name:        val.Name,
+			PathIcon:    val.PathIcon,
+			IconAlt:     val.IconAlt,
+			IconCaption: val.IconCaption,
 		})
 	}
 
@@ -63,45 +63,38 @@ func (cs *clientSectionHandler) FetchAllClientSectionHome(c echo.Context) error
 // CreateClientSection implements ClientSectionHandlerInterface.
 func (cs *clientSectionHandler) CreateClientSection(c echo.Context) error {
 	var (
-		req       = request.ClientSectionRquest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ClientSectionRquest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateClientSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateClientSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateClientSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateClientSection", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateClientSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateClientSection", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ClientSectionEntity{
-		Name:     req.Name,
-		PathIcon: req.PathIcon,
+		Name:        req.Name,
+		PathIcon:    req.PathIcon,
+		IconAlt:     req.IconAlt,
+		IconCaption: req.IconCaption,
 	}
 
 	err = cs.clientSectionService.CreateClientSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateClientSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateClientSection", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create client section"
@@ -114,34 +107,27 @@ func (cs *clientSectionHandler) CreateClientSection(c echo.Context) error {
 // DeleteByIDClientSection implements ClientSectionHandlerInterface.
 func (cs *clientSectionHandler) DeleteByIDClientSection(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDClientSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDClientSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idClient := c.Param("id")
 	id, err := conv.StringToInt64(idClient)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDClientSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDClientSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.clientSectionService.DeleteByIDClientSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDClientSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDClientSection", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete client section"
 	resp.Meta.Status = true
@@ -154,55 +140,46 @@ func (cs *clientSectionHandler) DeleteByIDClientSection(c echo.Context) error {
 // EditByIDClientSection implements ClientSectionHandlerInterface.
 func (cs *clientSectionHandler) EditByIDClientSection(c echo.Context) error {
 	var (
-		req       = request.ClientSectionRquest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ClientSectionRquest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDClientSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDClientSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idClient := c.Param("id")
 	id, err := conv.StringToInt64(idClient)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDClientSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDClientSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDClientSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDClientSection", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDClientSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDClientSection", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ClientSectionEntity{
-		ID:       id,
-		Name:     req.Name,
-		PathIcon: req.PathIcon,
+		ID:          id,
+		Name:        req.Name,
+		PathIcon:    req.PathIcon,
+		IconAlt:     req.IconAlt,
+		IconCaption: req.IconCaption,
 	}
 
 	err = cs.clientSectionService.EditByIDClientSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDClientSection - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDClientSection", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit client section"
 	resp.Meta.Status = true
@@ -215,32 +192,29 @@ func (cs *clientSectionHandler) EditByIDClientSection(c echo.Context) error {
 func (cs *clientSectionHandler) FetchAllClientSection(c echo.Context) error {
 	var (
 		resp       = response.DefaultSuccessResponse{}
-		respError  = response.ErrorResponseDefault{}
 		ctx        = c.Request().Context()
 		respClient = []response.ClientSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllClientSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllClientSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.clientSectionService.FetchAllClientSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllClientSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllClientSection", 2, err)
+		return err
 	}
 
 	for _, val := range results {
 		respClient = append(respClient, response.ClientSectionResponse{
-			ID:       val.ID,
-			Name:     val.Name,
-			PathIcon: val.PathIcon,
+			ID:          val.ID,
+			Name:        val.Name,
+			PathIcon:    val.PathIcon,
+			IconAlt:     val.IconAlt,
+			IconCaption: val.IconCaption,
 		})
 	}
 
@@ -255,39 +229,34 @@ func (cs *clientSectionHandler) FetchAllClientSection(c echo.Context) error {
 func (cs *clientSectionHandler) FetchByIDClientSection(c echo.Context) error {
 	var (
 		resp       = response.DefaultSuccessResponse{}
-		respError  = response.ErrorResponseDefault{}
 		ctx        = c.Request().Context()
 		respClient = response.ClientSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDClientSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDClientSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idClient := c.Param("id")
 	id, err := conv.StringToInt64(idClient)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDClientSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDClientSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.clientSectionService.FetchByIDClientSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDClientSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDClientSection", 3, err)
+		return err
 	}
 
 	respClient.ID = result.ID
 	respClient.Name = result.Name
 	respClient.PathIcon = result.PathIcon
+	respClient.IconAlt = result.IconAlt
+	respClient.IconCaption = result.IconCaption
 	resp.Meta.Message = "Success fetch hero section by ID"
 	resp.Meta.Status = true
 	resp.Data = respClient
@@ -295,7 +264,7 @@ func (cs *clientSectionHandler) FetchByIDClientSection(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewClientSectionHandler(e *echo.Echo, clientSectionService service.ClientSectionServiceInterface, cfg *config.Config) ClientSectionHandlerInterface {
+func NewClientSectionHandler(e *echo.Group, clientSectionService service.ClientSectionServiceInterface, cfg *config.Config) ClientSectionHandlerInterface {
 	h := &clientSectionHandler{
 		clientSectionService: clientSectionService,
 	}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type ContactUsHandlerInterface interface {
@@ -33,16 +34,13 @@ func (cs *contactUsHandler) FetchAllContactUsHome(c echo.Context) error {
 	var (
 		respContactUs = response.ContactUsResponse{}
 		resp          = response.DefaultSuccessResponse{}
-		respError     = response.ErrorResponseDefault{}
 		ctx           = c.Request().Context()
 	)
 
 	results, err := cs.contactUsService.FetchAllContactUs(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllContactUsHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllContactUsHome", 1, err)
+		return err
 	}
 
 	respContactUs = response.ContactUsResponse{
@@ -63,32 +61,25 @@ func (cs *contactUsHandler) FetchAllContactUsHome(c echo.Context) error {
 // CreateContactUs implements ContactUsHandlerInterface.
 func (cs *contactUsHandler) CreateContactUs(c echo.Context) error {
 	var (
-		req       = request.ContactUsRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ContactUsRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateContactUs - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateContactUs", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateContactUs - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateContactUs", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateContactUs - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateContactUs", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ContactUsEntity{
@@ -100,10 +91,8 @@ func (cs *contactUsHandler) CreateContactUs(c echo.Context) error {
 
 	err = cs.contactUsService.CreateContactUs(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateContactUs - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateContactUs", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create contact us"
@@ -116,34 +105,27 @@ func (cs *contactUsHandler) CreateContactUs(c echo.Context) error {
 // DeleteByIDContactUs implements ContactUsHandlerInterface.
 func (cs *contactUsHandler) DeleteByIDContactUs(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDContactUs - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDContactUs", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idContactUs := c.Param("id")
 	id, err := conv.StringToInt64(idContactUs)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDContactUs - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDContactUs", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.contactUsService.DeleteByIDContactUs(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDContactUs - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDContactUs", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete contact us"
 	resp.Meta.Status = true
@@ -156,41 +138,32 @@ func (cs *contactUsHandler) DeleteByIDContactUs(c echo.Context) error {
 // EditByIDContactUs implements ContactUsHandlerInterface.
 func (cs *contactUsHandler) EditByIDContactUs(c echo.Context) error {
 	var (
-		req       = request.ContactUsRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ContactUsRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDContactUs - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDContactUs", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idContactUs := c.Param("id")
 	id, err := conv.StringToInt64(idContactUs)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDContactUs - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDContactUs", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDContactUs - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDContactUs", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDContactUs - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDContactUs", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ContactUsEntity{
@@ -203,10 +176,8 @@ func (cs *contactUsHandler) EditByIDContactUs(c echo.Context) error {
 
 	err = cs.contactUsService.EditByIDContactUs(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDContactUs - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDContactUs", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit contact us"
 	resp.Meta.Status = true
@@ -219,25 +190,20 @@ func (cs *contactUsHandler) EditByIDContactUs(c echo.Context) error {
 func (cs *contactUsHandler) FetchAllContactUs(c echo.Context) error {
 	var (
 		resp          = response.DefaultSuccessResponse{}
-		respError     = response.ErrorResponseDefault{}
 		ctx           = c.Request().Context()
 		respContactUs = []response.ContactUsResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllContactUs - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllContactUs", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.contactUsService.FetchAllContactUs(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllContactUs - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllContactUs", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -261,34 +227,27 @@ func (cs *contactUsHandler) FetchAllContactUs(c echo.Context) error {
 func (cs *contactUsHandler) FetchByIDContactUs(c echo.Context) error {
 	var (
 		resp          = response.DefaultSuccessResponse{}
-		respError     = response.ErrorResponseDefault{}
 		ctx           = c.Request().Context()
 		respContactUs = response.ContactUsResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDContactUs - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDContactUs", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idContactUs := c.Param("id")
 	id, err := conv.StringToInt64(idContactUs)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDContactUs - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDContactUs", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.contactUsService.FetchByIDContactUs(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDContactUs - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDContactUs", 3, err)
+		return err
 	}
 
 	respContactUs.ID = result.ID
@@ -303,7 +262,7 @@ func (cs *contactUsHandler) FetchByIDContactUs(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewContactUsHandler(e *echo.Echo, contactUsService service.ContactUsServiceInterface, cfg *config.Config) ContactUsHandlerInterface {
+func NewContactUsHandler(e *echo.Group, contactUsService service.ContactUsServiceInterface, cfg *config.Config) ContactUsHandlerInterface {
 	h := &contactUsHandler{
 		contactUsService: contactUsService,
 	}
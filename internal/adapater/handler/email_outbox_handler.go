@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler/request"
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/middleware"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EmailOutboxHandlerInterface interface {
+	FetchAllEmailOutbox(c echo.Context) error
+	FetchByIDEmailOutbox(c echo.Context) error
+	RetryByIDEmailOutbox(c echo.Context) error
+}
+
+type emailOutboxHandler struct {
+	emailOutboxService service.EmailOutboxServiceInterface
+}
+
+// FetchAllEmailOutbox implements EmailOutboxHandlerInterface.
+func (h *emailOutboxHandler) FetchAllEmailOutbox(c echo.Context) error {
+	var (
+		resp       = response.DefaultSuccessResponse{}
+		req        = request.EmailOutboxFilterRequest{}
+		ctx        = c.Request().Context()
+		respOutbox = []response.EmailOutboxResponse{}
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "FetchAllEmailOutbox", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	if err := c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllEmailOutbox", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllEmailOutbox", 3, err)
+		return apperr.Validation(err.Error())
+	}
+
+	results, err := h.emailOutboxService.FetchAllEmailOutbox(ctx, req.Status)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllEmailOutbox", 4, err)
+		return err
+	}
+
+	for _, val := range results {
+		item := emailOutboxResponse(val)
+		item.Body = ""
+		item.TextBody = ""
+		respOutbox = append(respOutbox, item)
+	}
+
+	resp.Meta.Message = "Success fetch all email outbox"
+	resp.Meta.Status = true
+	resp.Data = respOutbox
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// FetchByIDEmailOutbox implements EmailOutboxHandlerInterface.
+func (h *emailOutboxHandler) FetchByIDEmailOutbox(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "FetchByIDEmailOutbox", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchByIDEmailOutbox", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	result, err := h.emailOutboxService.FetchByIDEmailOutbox(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchByIDEmailOutbox", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success fetch email outbox by ID"
+	resp.Meta.Status = true
+	resp.Data = emailOutboxResponse(*result)
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RetryByIDEmailOutbox implements EmailOutboxHandlerInterface.
+func (h *emailOutboxHandler) RetryByIDEmailOutbox(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "RetryByIDEmailOutbox", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "RetryByIDEmailOutbox", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	err = h.emailOutboxService.RetryByIDEmailOutbox(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "RetryByIDEmailOutbox", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success retry email outbox"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+func emailOutboxResponse(val entity.EmailOutboxEntity) response.EmailOutboxResponse {
+	result := response.EmailOutboxResponse{
+		ID:            val.ID,
+		FromAddress:   val.FromAddress,
+		ToAddress:     val.ToAddress,
+		Subject:       val.Subject,
+		Body:          val.Body,
+		TextBody:      val.TextBody,
+		Status:        val.Status,
+		Attempts:      val.Attempts,
+		MaxAttempts:   val.MaxAttempts,
+		LastError:     val.LastError,
+		NextAttemptAt: val.NextAttemptAt.Format("02 Jan 2006 15:04:05"),
+		CreatedAt:     val.CreatedAt.Format("02 Jan 2006 15:04:05"),
+	}
+	if val.SentAt != nil {
+		result.SentAt = val.SentAt.Format("02 Jan 2006 15:04:05")
+	}
+	return result
+}
+
+func NewEmailOutboxHandler(e *echo.Group, emailOutboxService service.EmailOutboxServiceInterface, cfg *config.Config) EmailOutboxHandlerInterface {
+	h := &emailOutboxHandler{
+		emailOutboxService: emailOutboxService,
+	}
+
+	mid := middleware.NewMiddleware(cfg)
+
+	adminApp := e.Group("/email-outbox/admin", mid.CheckToken())
+	adminApp.GET("", h.FetchAllEmailOutbox)
+	adminApp.GET("/:id", h.FetchByIDEmailOutbox)
+	adminApp.POST("/:id/retry", h.RetryByIDEmailOutbox)
+
+	return h
+}
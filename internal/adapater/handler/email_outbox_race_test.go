@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"desadangdang/config"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/validator"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+var errDatabaseDown = errors.New("database is down")
+
+// downConnector fails every connection, so each query returns an error the
+// repositories have to carry back to their caller.
+type downConnector struct{}
+
+func (downConnector) Connect(context.Context) (driver.Conn, error) { return nil, errDatabaseDown }
+func (downConnector) Driver() driver.Driver                        { return downDriver{} }
+
+type downDriver struct{}
+
+func (downDriver) Open(string) (driver.Conn, error) { return nil, errDatabaseDown }
+
+// TestEmailOutboxWorkerAlongsideHandler runs the outbox worker while
+// inquiries are posted. Run it with -race: the worker and the handler must
+// not share any error variable.
+func TestEmailOutboxWorkerAlongsideHandler(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(downConnector{})}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               gormLogger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	cfg := &config.Config{}
+	outboxService := service.NewEmailOutboxService(repository.NewEmailOutboxRepository(db), nil, cfg)
+	inquiryService := service.NewInquiryService(repository.NewInquiryRepository(db), nil, cfg)
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.Validator = validator.NewValidator()
+	NewInquiryHandler(e.Group(""), inquiryService, cfg, func(next echo.HandlerFunc) echo.HandlerFunc { return next })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			if err := outboxService.ProcessEmailOutbox(ctx); !errors.Is(err, errDatabaseDown) && ctx.Err() == nil {
+				t.Errorf("ProcessEmailOutbox = %v, want %v", err, errDatabaseDown)
+				return
+			}
+		}
+	}()
+
+	body := `{"name":"Budi","email":"budi@example.com","subject":"Website","message":"Hello"}`
+	for ctx.Err() == nil {
+		req := httptest.NewRequest(http.MethodPost, "/inquiries", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("POST /inquiries = %d, want %d: %s", rec.Code, http.StatusInternalServerError, rec.Body)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/adapater/messaging/mailtemplate"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/middleware"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EmailTemplateHandlerInterface interface {
+	FetchAllEmailTemplate(c echo.Context) error
+	PreviewEmailTemplate(c echo.Context) error
+}
+
+type emailTemplateHandler struct {
+	mailRenderer mailtemplate.RendererInterface
+}
+
+// FetchAllEmailTemplate implements EmailTemplateHandlerInterface.
+func (h *emailTemplateHandler) FetchAllEmailTemplate(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(c.Request().Context(), logger.Handler, "FetchAllEmailTemplate", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	resp.Meta.Message = "Success fetch all email template"
+	resp.Meta.Status = true
+	resp.Data = h.mailRenderer.Names()
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// PreviewEmailTemplate implements EmailTemplateHandlerInterface.
+// ?format=html or ?format=text returns the raw body so it can be opened in a browser.
+func (h *emailTemplateHandler) PreviewEmailTemplate(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		name = c.Param("name")
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(c.Request().Context(), logger.Handler, "PreviewEmailTemplate", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	result, err := h.mailRenderer.Preview(name)
+	if err != nil {
+		logger.Error(c.Request().Context(), logger.Handler, "PreviewEmailTemplate", 2, err)
+		if errors.Is(err, mailtemplate.ErrTemplateNotFound) {
+			return apperr.NotFound(err.Error())
+		}
+		return err
+	}
+
+	switch c.QueryParam("format") {
+	case "html":
+		return c.HTML(http.StatusOK, result.HTML)
+	case "text":
+		return c.String(http.StatusOK, result.Text)
+	}
+
+	resp.Meta.Message = "Success preview email template"
+	resp.Meta.Status = true
+	resp.Data = response.EmailTemplateResponse{
+		Name:    name,
+		Subject: result.Subject,
+		HTML:    result.HTML,
+		Text:    result.Text,
+	}
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+func NewEmailTemplateHandler(e *echo.Group, mailRenderer mailtemplate.RendererInterface, cfg *config.Config) EmailTemplateHandlerInterface {
+	h := &emailTemplateHandler{
+		mailRenderer: mailRenderer,
+	}
+
+	mid := middleware.NewMiddleware(cfg)
+
+	adminApp := e.Group("/email-templates/admin", mid.CheckToken())
+	adminApp.GET("", h.FetchAllEmailTemplate)
+	adminApp.GET("/:name", h.PreviewEmailTemplate)
+
+	return h
+}
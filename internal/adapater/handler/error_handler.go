@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/utils/logger"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HTTPErrorHandler writes every error returned by a handler or middleware.
+// Domain errors give the status and the code, anything else is answered as
+// an internal error so driver messages never reach the client.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var (
+		respError = response.ErrorResponseDefault{}
+		status    int
+		echoErr   *echo.HTTPError
+	)
+
+	if errors.As(err, &echoErr) {
+		// Errors of echo itself: unknown routes, body limits and the like
+		status = echoErr.Code
+		respError.Meta.Message = http.StatusText(status)
+		switch status {
+		case http.StatusNotFound:
+			respError.Code = apperr.CodeRouteNotFound
+		case http.StatusMethodNotAllowed:
+			respError.Code = apperr.CodeMethodNotAllowed
+		case http.StatusRequestEntityTooLarge:
+			respError.Code = apperr.CodeTooLarge
+		case http.StatusUnauthorized:
+			respError.Code = apperr.CodeUnauthorized
+		case http.StatusForbidden:
+			respError.Code = apperr.CodeForbidden
+		default:
+			if status >= http.StatusInternalServerError {
+				respError.Code = apperr.CodeInternal
+			} else {
+				respError.Code = apperr.CodeValidation
+			}
+		}
+	} else {
+		appErr := apperr.From(err)
+		status = appErr.Kind.Status()
+		respError.Meta.Message = appErr.Message
+		respError.Code = appErr.Code
+		if appErr.Kind == apperr.KindInternal {
+			logger.Error(c.Request().Context(), logger.Handler, "HTTPErrorHandler", 1, err)
+		}
+	}
+	respError.Meta.Status = false
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, respError)
+	}
+	if err != nil {
+		logger.Error(c.Request().Context(), logger.Handler, "HTTPErrorHandler", 2, err)
+	}
+}
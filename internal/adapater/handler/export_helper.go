@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"desadangdang/utils/export"
+	"desadangdang/utils/logger"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// exportBuffer is how much of an export is held back before the status is
+// sent, an export failing before then is answered with its error.
+const exportBuffer = 64 << 10
+
+// streamExport writes the response as a csv/xlsx attachment, rows are pushed by
+// fill while it reads them from the repository cursor. Small exports and every
+// xlsx, which is only written once complete, are sent after fill succeeded. A
+// csv failing past exportBuffer aborts the connection, so the client sees a
+// failed download rather than a truncated file.
+func streamExport(c echo.Context, name, format string, headers []string, fill func(w export.Writer) error) error {
+	ctx := c.Request().Context()
+	out := &exportWriter{res: c.Response(), name: name, format: format}
+
+	writer, err := export.NewWriter(format, out)
+	if err != nil {
+		return err
+	}
+
+	if err = writer.WriteHeader(headers); err == nil {
+		err = fill(writer)
+	}
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "streamExport", 1, err)
+		// Close still releases the temp files of an xlsx
+		out.discard = true
+		_ = writer.Close()
+		return out.fail(err)
+	}
+
+	if err = writer.Close(); err != nil {
+		logger.Error(ctx, logger.Handler, "streamExport", 2, err)
+		return out.fail(err)
+	}
+	if err = out.commit(); err != nil {
+		logger.Error(ctx, logger.Handler, "streamExport", 3, err)
+	}
+	return nil
+}
+
+// exportWriter holds the start of an export back, the status and the
+// attachment headers are sent with the first exportBuffer bytes or once the
+// export is complete.
+type exportWriter struct {
+	res       *echo.Response
+	name      string
+	format    string
+	buf       bytes.Buffer
+	committed bool
+	discard   bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if w.discard {
+		return len(p), nil
+	}
+	if w.committed {
+		return w.res.Write(p)
+	}
+
+	w.buf.Write(p)
+	if w.buf.Len() < exportBuffer {
+		return len(p), nil
+	}
+	if err := w.commit(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush lets the csv rows through once the status is sent.
+func (w *exportWriter) Flush() {
+	if w.committed {
+		w.res.Flush()
+	}
+}
+
+// commit sends the status, the headers and what was held back.
+func (w *exportWriter) commit() error {
+	if w.committed {
+		return nil
+	}
+	w.committed = true
+
+	w.res.Header().Set(echo.HeaderContentType, export.ContentType(w.format))
+	w.res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", export.FileName(w.name, w.format)))
+	w.res.WriteHeader(http.StatusOK)
+	_, err := w.buf.WriteTo(w.res)
+	return err
+}
+
+// fail returns err while nothing was sent, the error handler answers it.
+// Past that the status can't change and the connection is aborted.
+func (w *exportWriter) fail(err error) error {
+	if w.committed {
+		panic(http.ErrAbortHandler)
+	}
+	return err
+}
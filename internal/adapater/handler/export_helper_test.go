@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"desadangdang/utils/export"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func serveExport(t *testing.T, format string, fill func(w export.Writer) error) (*httptest.ResponseRecorder, error) {
+	t.Helper()
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/appointments/admin/export", nil), rec)
+	err := streamExport(c, "appointments", format, []string{"ID", "Name"}, fill)
+	return rec, err
+}
+
+func TestStreamExport(t *testing.T) {
+	for _, format := range []string{export.FormatCSV, export.FormatXLSX} {
+		rec, err := serveExport(t, format, func(w export.Writer) error {
+			return w.WriteRow([]interface{}{1, "Budi"})
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status = %d, want 200", format, rec.Code)
+		}
+		if got := rec.Header().Get(echo.HeaderContentDisposition); !strings.HasPrefix(got, "attachment;") {
+			t.Errorf("%s: Content-Disposition = %q", format, got)
+		}
+		if rec.Body.Len() == 0 {
+			t.Errorf("%s: empty body", format)
+		}
+	}
+}
+
+func TestStreamExportFillError(t *testing.T) {
+	dbErr := errors.New("connection reset")
+	for _, format := range []string{export.FormatCSV, export.FormatXLSX} {
+		rec, err := serveExport(t, format, func(w export.Writer) error {
+			if err := w.WriteRow([]interface{}{1, "Budi"}); err != nil {
+				return err
+			}
+			return dbErr
+		})
+		if !errors.Is(err, dbErr) {
+			t.Errorf("%s: err = %v, want the fill error", format, err)
+		}
+		if rec.Body.Len() != 0 {
+			t.Errorf("%s: %d bytes were sent before the error", format, rec.Body.Len())
+		}
+		if got := rec.Header().Get(echo.HeaderContentDisposition); got != "" {
+			t.Errorf("%s: Content-Disposition = %q on an error", format, got)
+		}
+	}
+}
+
+func TestStreamExportErrorAfterCommit(t *testing.T) {
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", r)
+		}
+	}()
+
+	serveExport(t, export.FormatCSV, func(w export.Writer) error {
+		row := []interface{}{1, strings.Repeat("x", 1000)}
+		for i := 0; i < 2*exportBuffer/1000; i++ {
+			if err := w.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		return errors.New("connection reset")
+	})
+	t.Error("a failure after the status was sent did not abort the response")
+}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type FaqSectionHandlerInterface interface {
@@ -31,18 +32,15 @@ type faqSectionHandler struct {
 // FetchAllFaqSectionHome implements FaqSectionHandlerInterface.
 func (cs *faqSectionHandler) FetchAllFaqSectionHome(c echo.Context) error {
 	var (
-		respFaqs  = []response.FaqSectionResponse{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		respFaqs = []response.FaqSectionResponse{}
+		resp     = response.DefaultSuccessResponse{}
+		ctx      = c.Request().Context()
 	)
 
 	results, err := cs.faqSectionService.FetchAllFaqSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllFaqSectionHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllFaqSectionHome", 1, err)
+		return err
 	}
 	for _, val := range results {
 		respFaqs = append(respFaqs, response.FaqSectionResponse{
@@ -61,32 +59,25 @@ func (cs *faqSectionHandler) FetchAllFaqSectionHome(c echo.Context) error {
 // CreateFaqSection implements FaqSectionHandlerInterface.
 func (cs *faqSectionHandler) CreateFaqSection(c echo.Context) error {
 	var (
-		req       = request.FaqSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.FaqSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateFaqSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateFaqSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateFaqSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateFaqSection", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateFaqSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateFaqSection", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.FaqSectionEntity{
@@ -96,10 +87,8 @@ func (cs *faqSectionHandler) CreateFaqSection(c echo.Context) error {
 
 	err = cs.faqSectionService.CreateFaqSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateFaqSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateFaqSection", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create faq section"
@@ -112,34 +101,27 @@ func (cs *faqSectionHandler) CreateFaqSection(c echo.Context) error {
 // DeleteByIDFaqSection implements FaqSectionHandlerInterface.
 func (cs *faqSectionHandler) DeleteByIDFaqSection(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDFaqSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDFaqSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idFaqSection := c.Param("id")
 	id, err := conv.StringToInt64(idFaqSection)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDFaqSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDFaqSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.faqSectionService.DeleteByIDFaqSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDFaqSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDFaqSection", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete faq section"
 	resp.Meta.Status = true
@@ -152,41 +134,32 @@ func (cs *faqSectionHandler) DeleteByIDFaqSection(c echo.Context) error {
 // EditByIDFaqSection implements FaqSectionHandlerInterface.
 func (cs *faqSectionHandler) EditByIDFaqSection(c echo.Context) error {
 	var (
-		req       = request.FaqSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.FaqSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDFaqSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDFaqSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idFaqSection := c.Param("id")
 	id, err := conv.StringToInt64(idFaqSection)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDFaqSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDFaqSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDFaqSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDFaqSection", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDFaqSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDFaqSection", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.FaqSectionEntity{
@@ -197,10 +170,8 @@ func (cs *faqSectionHandler) EditByIDFaqSection(c echo.Context) error {
 
 	err = cs.faqSectionService.EditByIDFaqSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDFaqSection - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDFaqSection", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit faq section"
 	resp.Meta.Status = true
@@ -213,25 +184,20 @@ func (cs *faqSectionHandler) EditByIDFaqSection(c echo.Context) error {
 func (cs *faqSectionHandler) FetchAllFaqSection(c echo.Context) error {
 	var (
 		resp           = response.DefaultSuccessResponse{}
-		respError      = response.ErrorResponseDefault{}
 		ctx            = c.Request().Context()
 		respFaqSection = []response.FaqSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllFaqSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllFaqSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.faqSectionService.FetchAllFaqSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllFaqSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllFaqSection", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -253,34 +219,27 @@ func (cs *faqSectionHandler) FetchAllFaqSection(c echo.Context) error {
 func (cs *faqSectionHandler) FetchByIDFaqSection(c echo.Context) error {
 	var (
 		resp           = response.DefaultSuccessResponse{}
-		respError      = response.ErrorResponseDefault{}
 		ctx            = c.Request().Context()
 		respFaqSection = response.FaqSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDFaqSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDFaqSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idFaqSection := c.Param("id")
 	id, err := conv.StringToInt64(idFaqSection)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDFaqSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDFaqSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.faqSectionService.FetchByIDFaqSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDFaqSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDFaqSection", 3, err)
+		return err
 	}
 
 	respFaqSection.ID = result.ID
@@ -293,7 +252,7 @@ func (cs *faqSectionHandler) FetchByIDFaqSection(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewFaqSectionHandler(e *echo.Echo, faqSectionService service.FaqSectionServiceInterface, cfg *config.Config) FaqSectionHandlerInterface {
+func NewFaqSectionHandler(e *echo.Group, faqSectionService service.FaqSectionServiceInterface, cfg *config.Config) FaqSectionHandlerInterface {
 	h := &faqSectionHandler{
 		faqSectionService: faqSectionService,
 	}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"desadangdang/utils/health"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthHandlerInterface interface {
+	Liveness(c echo.Context) error
+	Readiness(c echo.Context) error
+}
+
+type healthHandler struct {
+	checker *health.Checker
+}
+
+// Liveness implements HealthHandlerInterface.
+// It only tells the process answers, a restart doesn't bring a database back.
+// The dependencies are left to Readiness.
+func (h *healthHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, health.Report{Status: health.StatusOK})
+}
+
+// Readiness implements HealthHandlerInterface.
+// It fails when a dependency is down or the server is shutting down, so the
+// load balancer stops sending traffic.
+func (h *healthHandler) Readiness(c echo.Context) error {
+	if h.checker.ShuttingDown() {
+		return c.JSON(http.StatusServiceUnavailable, health.Report{Status: health.StatusShuttingDown})
+	}
+
+	report := h.checker.Run(c.Request().Context())
+	if report.Status != health.StatusOK {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+func NewHealthHandler(e *echo.Echo, checker *health.Checker) HealthHandlerInterface {
+	h := &healthHandler{
+		checker: checker,
+	}
+
+	e.GET("/healthz", h.Liveness)
+	e.GET("/readyz", h.Readiness)
+
+	return h
+}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type HeroSectionHandlerInterface interface {
@@ -31,27 +32,26 @@ type heroSectionHandler struct {
 // FetchHeroDataHome implements HeroSectionHandlerInterface.
 func (h *heroSectionHandler) FetchHeroDataHome(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
-		respHero  = []response.HeroSectionResponse{}
+		resp     = response.DefaultSuccessResponse{}
+		ctx      = c.Request().Context()
+		respHero = []response.HeroSectionResponse{}
 	)
 
 	results, err := h.heroSectionService.FetchAllHeroSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllHeroSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllHeroSection", 2, err)
+		return err
 	}
 
 	for _, val := range results {
 		respHero = append(respHero, response.HeroSectionResponse{
-			ID:         val.ID,
-			Heading:    val.Heading,
-			SubHeading: val.SubHeading,
-			PathVideo:  val.PathVideo,
-			Banner:     val.Banner,
+			ID:            val.ID,
+			Heading:       val.Heading,
+			SubHeading:    val.SubHeading,
+			PathVideo:     val.PathVideo,
+			Banner:        val.Banner,
+			BannerAlt:     val.BannerAlt,
+			BannerCaption: val.BannerCaption,
 		})
 	}
 
@@ -65,47 +65,40 @@ func (h *heroSectionHandler) FetchHeroDataHome(c echo.Context) error {
 // CreateHeroSection implements HeroSectionHandlerInterface.
 func (h *heroSectionHandler) CreateHeroSection(c echo.Context) error {
 	var (
-		req       = request.HeroSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.HeroSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateHeroSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateHeroSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateHeroSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateHeroSection", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateHeroSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateHeroSection", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.HeroSectionEntity{
-		Heading:    req.Heading,
-		SubHeading: req.SubHeading,
-		PathVideo:  req.PathVideo,
-		Banner:     req.Banner,
+		Heading:       req.Heading,
+		SubHeading:    req.SubHeading,
+		PathVideo:     req.PathVideo,
+		Banner:        req.Banner,
+		BannerAlt:     req.BannerAlt,
+		BannerCaption: req.BannerCaption,
 	}
 
 	err = h.heroSectionService.CreateHeroSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateHeroSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateHeroSection", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create hero section"
@@ -118,34 +111,27 @@ func (h *heroSectionHandler) CreateHeroSection(c echo.Context) error {
 // DeleteByIDHeroSection implements HeroSectionHandlerInterface.
 func (h *heroSectionHandler) DeleteByIDHeroSection(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDHeroSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDHeroSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idHero := c.Param("id")
 	id, err := conv.StringToInt64(idHero)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDHeroSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDHeroSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = h.heroSectionService.DeleteByIDHeroSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDHeroSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDHeroSection", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete hero section"
 	resp.Meta.Status = true
@@ -158,57 +144,48 @@ func (h *heroSectionHandler) DeleteByIDHeroSection(c echo.Context) error {
 // EditByIDHeroSection implements HeroSectionHandlerInterface.
 func (h *heroSectionHandler) EditByIDHeroSection(c echo.Context) error {
 	var (
-		req       = request.HeroSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.HeroSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDHeroSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDHeroSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idHero := c.Param("id")
 	id, err := conv.StringToInt64(idHero)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDHeroSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDHeroSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDHeroSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDHeroSection", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDHeroSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDHeroSection", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.HeroSectionEntity{
-		ID:         id,
-		Heading:    req.Heading,
-		SubHeading: req.SubHeading,
-		PathVideo:  req.PathVideo,
-		Banner:     req.Banner,
+		ID:            id,
+		Heading:       req.Heading,
+		SubHeading:    req.SubHeading,
+		PathVideo:     req.PathVideo,
+		Banner:        req.Banner,
+		BannerAlt:     req.BannerAlt,
+		BannerCaption: req.BannerCaption,
 	}
 
 	err = h.heroSectionService.EditByIDHeroSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDHeroSection - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDHeroSection", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit hero section"
 	resp.Meta.Status = true
@@ -220,37 +197,32 @@ func (h *heroSectionHandler) EditByIDHeroSection(c echo.Context) error {
 // FetchAllHeroSection implements HeroSectionHandlerInterface.
 func (h *heroSectionHandler) FetchAllHeroSection(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
-		respHero  = []response.HeroSectionResponse{}
+		resp     = response.DefaultSuccessResponse{}
+		ctx      = c.Request().Context()
+		respHero = []response.HeroSectionResponse{}
 	)
 
-	log.Errorf("user", c.Get("user"))
-
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllHeroSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllHeroSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := h.heroSectionService.FetchAllHeroSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllHeroSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllHeroSection", 2, err)
+		return err
 	}
 
 	for _, val := range results {
 		respHero = append(respHero, response.HeroSectionResponse{
-			ID:         val.ID,
-			Heading:    val.Heading,
-			SubHeading: val.SubHeading,
-			PathVideo:  val.PathVideo,
-			Banner:     val.Banner,
+			ID:            val.ID,
+			Heading:       val.Heading,
+			SubHeading:    val.SubHeading,
+			PathVideo:     val.PathVideo,
+			Banner:        val.Banner,
+			BannerAlt:     val.BannerAlt,
+			BannerCaption: val.BannerCaption,
 		})
 	}
 
@@ -264,35 +236,28 @@ func (h *heroSectionHandler) FetchAllHeroSection(c echo.Context) error {
 // FetchByIDHeroSection implements HeroSectionHandlerInterface.
 func (h *heroSectionHandler) FetchByIDHeroSection(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
-		respHero  = response.HeroSectionResponse{}
+		resp     = response.DefaultSuccessResponse{}
+		ctx      = c.Request().Context()
+		respHero = response.HeroSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDHeroSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDHeroSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idHero := c.Param("id")
 	id, err := conv.StringToInt64(idHero)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDHeroSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDHeroSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := h.heroSectionService.FetchByIDHeroSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDHeroSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDHeroSection", 3, err)
+		return err
 	}
 
 	respHero.ID = result.ID
@@ -300,6 +265,8 @@ func (h *heroSectionHandler) FetchByIDHeroSection(c echo.Context) error {
 	respHero.SubHeading = result.SubHeading
 	respHero.PathVideo = result.PathVideo
 	respHero.Banner = result.Banner
+	respHero.BannerAlt = result.BannerAlt
+	respHero.BannerCaption = result.BannerCaption
 	resp.Meta.Message = "Success fetch hero section by ID"
 	resp.Meta.Status = true
 	resp.Data = respHero
@@ -307,7 +274,7 @@ func (h *heroSectionHandler) FetchByIDHeroSection(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewHeroSectionHandler(c *echo.Echo, cfg *config.Config, heroSectionService service.HeroSectionServiceInterface) HeroSectionHandlerInterface {
+func NewHeroSectionHandler(c *echo.Group, cfg *config.Config, heroSectionService service.HeroSectionServiceInterface) HeroSectionHandlerInterface {
 	heroHandler := &heroSectionHandler{
 		heroSectionService: heroSectionService,
 	}
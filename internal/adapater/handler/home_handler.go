@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/logger"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HomeHandlerInterface interface {
+	FetchHome(c echo.Context) error
+}
+
+type homeHandler struct {
+	homeService service.HomeServiceInterface
+}
+
+// homeSections are the route groups the home page is assembled from. The
+// trash is in because restoring a record brings it back on the page.
+var homeSections = []string{
+	"/hero-sections",
+	"/client-sections",
+	"/about-companies",
+	"/about-company-keynotes",
+	"/service-sections",
+	"/portofolio-sections",
+	"/portofolio-testimonials",
+	"/faq-sections",
+	"/our-teams",
+	"/statistics",
+	"/contact-us",
+	"/trash",
+}
+
+// FetchHome implements HomeHandlerInterface.
+func (h *homeHandler) FetchHome(c echo.Context) error {
+	var (
+		resp     = response.DefaultSuccessResponse{}
+		ctx      = c.Request().Context()
+		respHome = response.HomeResponse{
+			HeroSections:           []response.HeroSectionResponse{},
+			ClientSections:         []response.ClientSectionResponse{},
+			ServiceSections:        []response.ServiceSectionResponse{},
+			PortofolioSections:     []response.PortofolioSectionResponse{},
+			PortofolioTestimonials: []response.PortofolioTestimonialResponse{},
+			FaqSections:            []response.FaqSectionResponse{},
+			OurTeams:               []response.OurTeamResponse{},
+			Statistics:             []response.StatisticResponse{},
+		}
+	)
+
+	result, err := h.homeService.FetchHome(ctx)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchHome", 1, err)
+		return err
+	}
+	// the http cache keeps the earlier of this and the tables' Last-Modified
+	c.Response().Header().Set(echo.HeaderLastModified, result.AssembledAt.UTC().Format(http.TimeFormat))
+
+	for _, val := range result.HeroSections {
+		respHome.HeroSections = append(respHome.HeroSections, response.HeroSectionResponse{
+			ID:            val.ID,
+			Heading:       val.Heading,
+			SubHeading:    val.SubHeading,
+			PathVideo:     val.PathVideo,
+			Banner:        val.Banner,
+			BannerAlt:     val.BannerAlt,
+			BannerCaption: val.BannerCaption,
+		})
+	}
+
+	for _, val := range result.ClientSections {
+		respHome.ClientSections = append(respHome.ClientSections, response.ClientSectionResponse{
+			ID:          val.ID,
+			Name:        val.Name,
+			PathIcon:    val.PathIcon,
+			IconAlt:     val.IconAlt,
+			IconCaption: val.IconCaption,
+		})
+	}
+
+	if result.AboutCompany != nil {
+		respCompany := response.AboutCompanyResponse{
+			ID:          result.AboutCompany.ID,
+			Description: result.AboutCompany.Description,
+		}
+		for _, val := range result.AboutCompany.Keynote {
+			respCompany.CompanyKeynotes = append(respCompany.CompanyKeynotes, response.AboutCompanyKeynoteResponse{
+				ID:             val.ID,
+				AboutCompanyID: val.AboutCompanyID,
+				Keynote:        val.Keynote,
+				PathImage:      val.PathImage,
+				ImageAlt:       val.ImageAlt,
+				ImageCaption:   val.ImageCaption,
+			})
+		}
+		respHome.AboutCompany = &respCompany
+	}
+
+	for _, val := range result.ServiceSections {
+		respHome.ServiceSections = append(respHome.ServiceSections, response.ServiceSectionResponse{
+			ID:       val.ID,
+			Name:     val.Name,
+			Tagline:  val.Tagline,
+			PathIcon: val.PathIcon,
+		})
+	}
+
+	for _, val := range result.PortofolioSections {
+		respHome.PortofolioSections = append(respHome.PortofolioSections, response.PortofolioSectionResponse{
+			ID:               val.ID,
+			Name:             val.Name,
+			Tagline:          val.Tagline,
+			Thumbnail:        val.Thumbnail,
+			ThumbnailAlt:     val.ThumbnailAlt,
+			ThumbnailCaption: val.ThumbnailCaption,
+
+			ThumbnailSet: imageSetResponse(val.Thumbnail),
+		})
+	}
+
+	for _, val := range result.PortofolioTestimonials {
+		respHome.PortofolioTestimonials = append(respHome.PortofolioTestimonials, response.PortofolioTestimonialResponse{
+			ID:         val.ID,
+			Thumbnail:  val.Thumbnail,
+			Message:    val.Message,
+			ClientName: val.ClientName,
+			Role:       val.Role,
+			PortofolioSection: response.PortofolioSectionResponse{
+				Name: val.PortofolioSection.Name,
+			},
+		})
+	}
+
+	for _, val := range result.FaqSections {
+		respHome.FaqSections = append(respHome.FaqSections, response.FaqSectionResponse{
+			ID:          val.ID,
+			Title:       val.Title,
+			Description: val.Description,
+		})
+	}
+
+	for _, val := range result.OurTeams {
+		respHome.OurTeams = append(respHome.OurTeams, response.OurTeamResponse{
+			ID:           val.ID,
+			Name:         val.Name,
+			Role:         val.Role,
+			PathPhoto:    val.PathPhoto,
+			PhotoAlt:     val.PhotoAlt,
+			PhotoCaption: val.PhotoCaption,
+			Tagline:      val.Tagline,
+
+			PathPhotoSet: imageSetResponse(val.PathPhoto),
+		})
+	}
+
+	for _, val := range result.Statistics {
+		respHome.Statistics = append(respHome.Statistics, response.StatisticResponse{
+			ID:    val.ID,
+			Name:  val.Name,
+			Total: val.Total,
+			Icon:  val.Icon,
+		})
+	}
+
+	if result.ContactUs != nil {
+		respHome.ContactUs = &response.ContactUsResponse{
+			ID:           result.ContactUs.ID,
+			CompanyName:  result.ContactUs.CompanyName,
+			LocationName: result.ContactUs.LocationName,
+			Address:      result.ContactUs.Address,
+			PhoneNumber:  result.ContactUs.PhoneNumber,
+		}
+	}
+
+	resp.Meta.Message = "Success fetch home"
+	resp.Meta.Status = true
+	resp.Data = respHome
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// InvalidateHome drops the cached home page once an admin request changing
+// one of its sections succeeded, in any api version.
+func InvalidateHome(homeService service.HomeServiceInterface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			if err != nil || c.Request().Method == http.MethodGet || c.Request().Method == http.MethodHead {
+				return err
+			}
+
+			path := strings.TrimPrefix(c.Path(), APIV1Prefix)
+			for _, section := range homeSections {
+				if strings.HasPrefix(path, section+"/admin") {
+					homeService.InvalidateHome()
+					break
+				}
+			}
+			return nil
+		}
+	}
+}
+
+func NewHomeHandler(e *echo.Group, homeService service.HomeServiceInterface) HomeHandlerInterface {
+	h := &homeHandler{
+		homeService: homeService,
+	}
+
+	e.GET("/home", h.FetchHome)
+
+	return h
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/utils/imageproc"
+)
+
+// imageSetResponse returns the srcset data of an uploaded image,
+// or nil when the url has no generated variants.
+func imageSetResponse(url string) *response.ImageSetResponse {
+	set, ok := imageproc.SetFromURL(url)
+	if !ok {
+		return nil
+	}
+
+	result := &response.ImageSetResponse{
+		Src:        set.Src,
+		SrcSet:     set.SrcSet(imageproc.FormatWebP),
+		SrcSetJPEG: set.SrcSet(imageproc.FormatJPEG),
+		Variants:   map[string]response.ImageVariantResponse{},
+	}
+	for _, v := range set.Variants {
+		result.Variants[v.Name] = response.ImageVariantResponse{
+			Width: v.Width,
+			WebP:  v.WebP,
+			JPEG:  v.JPEG,
+		}
+	}
+	return result
+}
@@ -0,0 +1,419 @@
+package handler
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler/request"
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/middleware"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+const inquiryMaxLinks = 3
+
+type InquiryHandlerInterface interface {
+	CreateInquiry(c echo.Context) error
+	FetchAllInquiry(c echo.Context) error
+	FetchByIDInquiry(c echo.Context) error
+	CountUnreadInquiry(c echo.Context) error
+	MarkReadByIDInquiry(c echo.Context) error
+	ArchiveByIDInquiry(c echo.Context) error
+	ReplyByIDInquiry(c echo.Context) error
+	DeleteByIDInquiry(c echo.Context) error
+}
+
+type inquiryHandler struct {
+	inquiryService service.InquiryServiceInterface
+}
+
+// CreateInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) CreateInquiry(c echo.Context) error {
+	var (
+		req  = request.InquiryRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	if err := c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "CreateInquiry", 1, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	resp.Meta.Message = "Success send inquiry"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+
+	// honeypot filled in: pretend it worked so the bot does not retry
+	if req.Website != "" {
+		logger.Ctx(ctx).Warn().Str("layer", logger.Handler).Str("op", "CreateInquiry").Str("remote_ip", c.RealIP()).Msg("honeypot triggered")
+		return c.JSON(http.StatusCreated, resp)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "CreateInquiry", 2, err)
+		return apperr.Validation(err.Error())
+	}
+
+	if strings.Count(strings.ToLower(req.Message), "http") > inquiryMaxLinks {
+		logger.Errorf(ctx, logger.Handler, "CreateInquiry", 3, "too many links from %s", c.RealIP())
+		return apperr.Validation("Message contains too many links")
+	}
+
+	reqEntity := entity.InquiryEntity{
+		Name:        strings.TrimSpace(req.Name),
+		Email:       strings.TrimSpace(req.Email),
+		PhoneNumber: strings.TrimSpace(req.PhoneNumber),
+		Subject:     strings.TrimSpace(req.Subject),
+		Message:     strings.TrimSpace(req.Message),
+		IpAddress:   c.RealIP(),
+	}
+
+	err := h.inquiryService.CreateInquiry(ctx, reqEntity)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "CreateInquiry", 4, err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// FetchAllInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) FetchAllInquiry(c echo.Context) error {
+	var (
+		req         = request.InquiryFilterRequest{}
+		resp        = response.DefaultSuccessResponse{}
+		ctx         = c.Request().Context()
+		respInquiry = []response.InquiryResponse{}
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "FetchAllInquiry", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	if err := c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllInquiry", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllInquiry", 3, err)
+		return apperr.Validation(err.Error())
+	}
+
+	results, err := h.inquiryService.FetchAllInquiry(ctx, entity.InquiryFilter{
+		Status:   req.Status,
+		Archived: req.Archived,
+		Search:   req.Search,
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllInquiry", 4, err)
+		return err
+	}
+
+	for _, val := range results {
+		respInquiry = append(respInquiry, inquiryResponse(val))
+	}
+
+	resp.Meta.Message = "Success fetch all inquiry"
+	resp.Meta.Status = true
+	resp.Data = respInquiry
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// FetchByIDInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) FetchByIDInquiry(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "FetchByIDInquiry", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchByIDInquiry", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	result, err := h.inquiryService.FetchByIDInquiry(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchByIDInquiry", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success fetch inquiry by ID"
+	resp.Meta.Status = true
+	resp.Data = inquiryResponse(*result)
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CountUnreadInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) CountUnreadInquiry(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "CountUnreadInquiry", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	count, err := h.inquiryService.CountUnreadInquiry(ctx)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "CountUnreadInquiry", 2, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success count unread inquiry"
+	resp.Meta.Status = true
+	resp.Data = map[string]int64{"unread": count}
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// MarkReadByIDInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) MarkReadByIDInquiry(c echo.Context) error {
+	var (
+		req  = request.InquiryReadRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "MarkReadByIDInquiry", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "MarkReadByIDInquiry", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	if err = c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "MarkReadByIDInquiry", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err = c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "MarkReadByIDInquiry", 4, err)
+		return apperr.Validation(err.Error())
+	}
+
+	err = h.inquiryService.MarkReadByIDInquiry(ctx, id, *req.Read)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "MarkReadByIDInquiry", 5, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success update inquiry read status"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ArchiveByIDInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) ArchiveByIDInquiry(c echo.Context) error {
+	var (
+		req  = request.InquiryArchiveRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "ArchiveByIDInquiry", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "ArchiveByIDInquiry", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	if err = c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "ArchiveByIDInquiry", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err = c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "ArchiveByIDInquiry", 4, err)
+		return apperr.Validation(err.Error())
+	}
+
+	err = h.inquiryService.ArchiveByIDInquiry(ctx, id, *req.Archived)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "ArchiveByIDInquiry", 5, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success update inquiry archive status"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ReplyByIDInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) ReplyByIDInquiry(c echo.Context) error {
+	var (
+		req  = request.InquiryReplyRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "ReplyByIDInquiry", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "ReplyByIDInquiry", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	if err = c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "ReplyByIDInquiry", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err = c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "ReplyByIDInquiry", 4, err)
+		return apperr.Validation(err.Error())
+	}
+
+	reqEntity := entity.InquiryReplyEntity{
+		InquiryID: id,
+		UserID:    user,
+		Message:   req.Message,
+	}
+
+	err = h.inquiryService.ReplyByIDInquiry(ctx, reqEntity)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "ReplyByIDInquiry", 5, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success reply inquiry"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// DeleteByIDInquiry implements InquiryHandlerInterface.
+func (h *inquiryHandler) DeleteByIDInquiry(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDInquiry", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "DeleteByIDInquiry", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	err = h.inquiryService.DeleteByIDInquiry(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "DeleteByIDInquiry", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success delete inquiry"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+func inquiryResponse(val entity.InquiryEntity) response.InquiryResponse {
+	result := response.InquiryResponse{
+		ID:          val.ID,
+		Name:        val.Name,
+		Email:       val.Email,
+		PhoneNumber: val.PhoneNumber,
+		Subject:     val.Subject,
+		Message:     val.Message,
+		IsRead:      val.ReadAt != nil,
+		IsArchived:  val.ArchivedAt != nil,
+		CreatedAt:   val.CreatedAt.Format("02 Jan 2006 15:04:05"),
+	}
+
+	for _, r := range val.Replies {
+		result.Replies = append(result.Replies, response.InquiryReplyResponse{
+			ID:        r.ID,
+			UserID:    r.UserID,
+			Message:   r.Message,
+			CreatedAt: r.CreatedAt.Format("02 Jan 2006 15:04:05"),
+		})
+	}
+	return result
+}
+
+// InquiryRateLimiter allows 5 inquiries per 10 minutes for each client IP.
+// The routes of every api version share one, so the paths of another version
+// don't give a client more.
+func InquiryRateLimiter() echo.MiddlewareFunc {
+	return echoMiddleware.RateLimiter(echoMiddleware.NewRateLimiterMemoryStoreWithConfig(
+		echoMiddleware.RateLimiterMemoryStoreConfig{
+			Rate:      rate.Every(2 * time.Minute),
+			Burst:     5,
+			ExpiresIn: 10 * time.Minute,
+		},
+	))
+}
+
+func NewInquiryHandler(e *echo.Group, inquiryService service.InquiryServiceInterface, cfg *config.Config, limiter echo.MiddlewareFunc) InquiryHandlerInterface {
+	h := &inquiryHandler{
+		inquiryService: inquiryService,
+	}
+
+	mid := middleware.NewMiddleware(cfg)
+
+	inquiryApp := e.Group("/inquiries")
+	inquiryApp.POST("", h.CreateInquiry, limiter)
+
+	adminApp := inquiryApp.Group("/admin", mid.CheckToken())
+	adminApp.GET("", h.FetchAllInquiry)
+	adminApp.GET("/unread-count", h.CountUnreadInquiry)
+	adminApp.GET("/:id", h.FetchByIDInquiry)
+	adminApp.PUT("/:id/read", h.MarkReadByIDInquiry)
+	adminApp.PUT("/:id/archive", h.ArchiveByIDInquiry)
+	adminApp.POST("/:id/replies", h.ReplyByIDInquiry)
+	adminApp.DELETE("/:id", h.DeleteByIDInquiry)
+
+	return h
+}
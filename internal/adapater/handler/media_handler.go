@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler/request"
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/middleware"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MediaHandlerInterface interface {
+	FetchAllMedia(c echo.Context) error
+	FetchByIDMedia(c echo.Context) error
+	EditAltTextByIDMedia(c echo.Context) error
+	DeleteByIDMedia(c echo.Context) error
+}
+
+type mediaHandler struct {
+	mediaService service.MediaServiceInterface
+}
+
+// FetchAllMedia implements MediaHandlerInterface.
+func (h *mediaHandler) FetchAllMedia(c echo.Context) error {
+	var (
+		req       = request.MediaFilterRequest{}
+		resp      = response.DefaultSuccessResponse{}
+		ctx       = c.Request().Context()
+		respMedia = []response.MediaResponse{}
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "FetchAllMedia", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	if err := c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllMedia", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err := c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllMedia", 3, err)
+		return apperr.Validation(err.Error())
+	}
+
+	results, err := h.mediaService.FetchAllMedia(ctx, entity.MediaFilter{
+		Search:  strings.TrimSpace(req.Search),
+		Purpose: req.Purpose,
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllMedia", 4, err)
+		return err
+	}
+
+	for _, val := range results {
+		respMedia = append(respMedia, mediaResponse(val))
+	}
+
+	resp.Meta.Message = "Success fetch all media"
+	resp.Meta.Status = true
+	resp.Data = respMedia
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// FetchByIDMedia implements MediaHandlerInterface.
+// The response lists every record that uses the file.
+func (h *mediaHandler) FetchByIDMedia(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "FetchByIDMedia", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchByIDMedia", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	result, err := h.mediaService.FetchByIDMedia(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchByIDMedia", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success fetch media by ID"
+	resp.Meta.Status = true
+	resp.Data = mediaResponse(*result)
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// EditAltTextByIDMedia implements MediaHandlerInterface.
+func (h *mediaHandler) EditAltTextByIDMedia(c echo.Context) error {
+	var (
+		req  = request.MediaAltTextRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "EditAltTextByIDMedia", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "EditAltTextByIDMedia", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	if err = c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "EditAltTextByIDMedia", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err = c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "EditAltTextByIDMedia", 4, err)
+		return apperr.Validation(err.Error())
+	}
+
+	err = h.mediaService.EditAltTextByIDMedia(ctx, id, strings.TrimSpace(req.AltText))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "EditAltTextByIDMedia", 5, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success edit media alt text"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteByIDMedia implements MediaHandlerInterface.
+func (h *mediaHandler) DeleteByIDMedia(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDMedia", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "DeleteByIDMedia", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	err = h.mediaService.DeleteByIDMedia(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "DeleteByIDMedia", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success delete media"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+func mediaResponse(val entity.MediaEntity) response.MediaResponse {
+	result := response.MediaResponse{
+		ID:           val.ID,
+		UserID:       val.UserID,
+		URL:          val.URL,
+		Purpose:      val.Purpose,
+		ContentType:  val.ContentType,
+		Size:         val.Size,
+		Width:        val.Width,
+		Height:       val.Height,
+		AltText:      val.AltText,
+		OriginalName: val.OriginalName,
+		CreatedAt:    val.CreatedAt.Format("02 Jan 2006 15:04:05"),
+		ImageSet:     imageSetResponse(val.URL),
+	}
+
+	for _, u := range val.Usages {
+		result.Usages = append(result.Usages, response.MediaUsageResponse{
+			Resource:   u.Resource,
+			ResourceID: u.ResourceID,
+			Field:      u.Field,
+		})
+	}
+	return result
+}
+
+func NewMediaHandler(e *echo.Group, mediaService service.MediaServiceInterface, cfg *config.Config) MediaHandlerInterface {
+	h := &mediaHandler{
+		mediaService: mediaService,
+	}
+
+	mid := middleware.NewMiddleware(cfg)
+
+	adminApp := e.Group("/media/admin", mid.CheckToken())
+	adminApp.GET("", h.FetchAllMedia)
+	adminApp.GET("/:id", h.FetchByIDMedia)
+	adminApp.PUT("/:id", h.EditAltTextByIDMedia)
+	adminApp.DELETE("/:id", h.DeleteByIDMedia)
+
+	return h
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bytes"
+	"desadangdang/utils/openapi"
+	"embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// swaggerUIVersion is the swagger-ui-dist release loaded by the docs page.
+const swaggerUIVersion = "5.17.14"
+
+//go:embed docs/swagger.html
+var docsFS embed.FS
+
+var docsPage = template.Must(template.ParseFS(docsFS, "docs/swagger.html"))
+
+type OpenAPIHandlerInterface interface {
+	Spec(c echo.Context) error
+	Docs(c echo.Context) error
+}
+
+type openAPIHandler struct {
+	spec []byte
+	page []byte
+}
+
+// Spec implements OpenAPIHandlerInterface.
+func (h *openAPIHandler) Spec(c echo.Context) error {
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, h.spec)
+}
+
+// Docs implements OpenAPIHandlerInterface.
+func (h *openAPIHandler) Docs(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, h.page)
+}
+
+// NewOpenAPIHandler serves the document and its Swagger UI. Both are rendered
+// once, the routes don't change while the server runs.
+func NewOpenAPIHandler(e *echo.Echo, doc *openapi.Document) (OpenAPIHandlerInterface, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var page bytes.Buffer
+	err = docsPage.Execute(&page, map[string]string{
+		"Title":   doc.Info.Title,
+		"Version": swaggerUIVersion,
+		"SpecURL": "/openapi.json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h := &openAPIHandler{spec: spec, page: page.Bytes()}
+
+	e.GET("/openapi.json", h.Spec)
+	e.GET("/docs", h.Docs)
+
+	return h, nil
+}
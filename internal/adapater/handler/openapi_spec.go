@@ -0,0 +1,346 @@
+package handler
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler/request"
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/internal/adapater/storage"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/utils/export"
+	"desadangdang/utils/health"
+	"desadangdang/utils/openapi"
+	"desadangdang/utils/upload"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// bearerAuth is the jwt returned by POST /login.
+	bearerAuth = "bearerAuth"
+	// metricsAuth is METRICS_TOKEN.
+	metricsAuth = "metricsToken"
+)
+
+var exportTypes = []string{export.ContentType(export.FormatCSV), export.ContentType(export.FormatXLSX)}
+
+// OpenAPIDocument describes every route registered by the New*Handler
+// functions. A route added without an entry here fails `openapi check`.
+func OpenAPIDocument(cfg *config.Config) *openapi.Document {
+	b := openapi.New(openapi.Info{
+		Title:       cfg.App.AppName + " API",
+		Description: "Content and admin api of the company profile, under " + APIV1Prefix + ". Admin routes take the token returned by POST " + APIV1Prefix + "/login. Every json response has the meta envelope, errors carry a stable code next to the message.",
+		Version:     "1.0.0",
+	}, response.DefaultSuccessResponse{}, response.ErrorResponseDefault{})
+
+	b.BearerAuth(bearerAuth, "JWT", "Token returned by POST "+APIV1Prefix+"/login, valid for 24 hours.")
+	b.BearerAuth(metricsAuth, "", "The METRICS_TOKEN of the server.")
+
+	prefixes := []string{APIV1Prefix}
+	routes := append(systemRoutes(), mounted(APIV1Prefix, conditional(apiRoutes()))...)
+	if cfg.API.LegacyRoutes {
+		prefixes = append(prefixes, "")
+		routes = append(routes, legacyRoutes(conditional(apiRoutes()), cfg.API.LegacySunset, "/home")...)
+	}
+	b.Add(routes...)
+
+	tags := map[string]bool{}
+	for _, r := range routes {
+		if r.Tag != "" && !tags[r.Tag] {
+			tags[r.Tag] = true
+			b.Tag(r.Tag, "")
+		}
+	}
+
+	// Codes are listed so clients can switch on them exhaustively.
+	codes := []interface{}{}
+	for _, code := range []string{
+		apperr.CodeInternal, apperr.CodeNotFound, apperr.CodeRouteNotFound, apperr.CodeMethodNotAllowed,
+		apperr.CodeConflict, apperr.CodeDuplicate, apperr.CodeReferenced, apperr.CodeValidation,
+		apperr.CodeInvalidBody, apperr.CodeInvalidID, apperr.CodeUnauthorized, apperr.CodeForbidden,
+		apperr.CodeTooLarge, apperr.CodeUnsupportedType, apperr.CodeCanceled, apperr.CodeTimeout,
+		apperr.CodeWrongCredentials, apperr.CodeUserExists, apperr.CodeSlugTaken, apperr.CodeMediaInUse,
+		apperr.CodeTrashParentDeleted,
+	} {
+		codes = append(codes, code)
+	}
+	if errSchema := b.Schema("ErrorResponseDefault"); errSchema != nil {
+		errSchema.Properties["code"].Enum = codes
+		errSchema.Properties["code"].Example = nil
+		errSchema.Properties["status"].Example = false
+	}
+	for status, example := range map[int]*apperr.Error{
+		http.StatusBadRequest:            apperr.Validation("Name is a required field"),
+		http.StatusUnauthorized:          apperr.ErrUnauthorized,
+		http.StatusForbidden:             apperr.ErrForbidden,
+		http.StatusNotFound:              apperr.ErrNotFound,
+		http.StatusConflict:              apperr.Conflict(apperr.CodeDuplicate, "data already exists"),
+		http.StatusRequestEntityTooLarge: apperr.New(apperr.KindTooLarge, apperr.CodeTooLarge, upload.ErrFileTooLarge.Error()),
+		http.StatusInternalServerError:   apperr.ErrInternal,
+	} {
+		b.ErrorExample(status, response.ErrorResponseDefault{
+			Meta: response.Meta{Status: false, Message: example.Message},
+			Code: example.Code,
+		})
+	}
+
+	b.ErrorResponse(http.MethodPut, storage.LocalUploadRoute+"/*", http.StatusForbidden, http.StatusRequestEntityTooLarge)
+	for _, prefix := range prefixes {
+		b.ErrorResponse(http.MethodPost, prefix+"/login", http.StatusUnauthorized)
+		b.ErrorResponse(http.MethodPost, prefix+"/upload-image", http.StatusRequestEntityTooLarge)
+		b.ErrorResponse(http.MethodPost, prefix+"/upload-file", http.StatusRequestEntityTooLarge)
+		b.ErrorResponse(http.MethodPost, prefix+"/upload-file/sign", http.StatusRequestEntityTooLarge)
+		b.ErrorResponse(http.MethodPost, prefix+"/upload-file/complete", http.StatusForbidden)
+		b.ErrorResponse(http.MethodDelete, prefix+"/media/admin/:id", http.StatusConflict)
+		b.ErrorResponse(http.MethodPost, prefix+"/email-outbox/admin/:id/retry", http.StatusConflict)
+		b.ErrorResponse(http.MethodPut, prefix+"/trash/admin/:resource/:id/restore", http.StatusConflict)
+		b.ErrorResponse(http.MethodDelete, prefix+"/trash/admin/:resource/:id", http.StatusConflict)
+		b.ErrorResponse(http.MethodDelete, prefix+"/trash/admin/:resource", http.StatusConflict)
+	}
+
+	return b.Document()
+}
+
+// conditional marks the public reads, they may be cached and revalidated.
+func conditional(routes []openapi.Route) []openapi.Route {
+	for i := range routes {
+		routes[i].Conditional = routes[i].Method == http.MethodGet && routes[i].Auth == ""
+	}
+	return routes
+}
+
+func mounted(prefix string, routes []openapi.Route) []openapi.Route {
+	for i := range routes {
+		routes[i].Path = prefix + routes[i].Path
+	}
+	return routes
+}
+
+// legacyRoutes documents the routes still served at the root, the way they
+// were before the versioning, until the sunset. The paths in v1Only came
+// with the versioning and are left out.
+func legacyRoutes(routes []openapi.Route, sunset string, v1Only ...string) []openapi.Route {
+	sunsetAt := sunset
+	if t, err := time.Parse(time.DateOnly, sunset); err == nil {
+		sunsetAt = t.Format(http.TimeFormat)
+	}
+	headers := map[string]*openapi.Header{
+		"Deprecation": {Description: "When the route was deprecated, as @ and a unix timestamp.", Schema: &openapi.Schema{Type: "string", Example: "@" + strconv.FormatInt(LegacyDeprecatedAt.Unix(), 10)}},
+		"Sunset":      {Description: "When the route stops being served.", Schema: &openapi.Schema{Type: "string", Example: sunsetAt}},
+		"Link":        {Description: "The same request under " + APIV1Prefix + ", as the successor-version.", Schema: &openapi.Schema{Type: "string"}},
+	}
+	for i := range routes {
+		description := "Deprecated, use " + routes[i].Method + " " + APIV1Prefix + routes[i].Path + ". Served until " + sunset + "."
+		if routes[i].Description != "" {
+			description += " " + routes[i].Description
+		}
+		routes[i].Description = description
+		routes[i].Deprecated = true
+		routes[i].Headers = headers
+	}
+	return slices.DeleteFunc(routes, func(r openapi.Route) bool {
+		return slices.Contains(v1Only, r.Path)
+	})
+}
+
+// crudRoutes documents the admin routes shared by the content sections.
+func crudRoutes(tag, name, base string, body, one, list interface{}) []openapi.Route {
+	admin := base + "/admin"
+	return []openapi.Route{
+		{Method: http.MethodPost, Path: admin, Tag: tag, Summary: "Create a " + name, Auth: bearerAuth, Body: body, Status: http.StatusCreated},
+		{Method: http.MethodGet, Path: admin, Tag: tag, Summary: "List every " + name, Auth: bearerAuth, Data: list},
+		{Method: http.MethodGet, Path: admin + "/:id", Tag: tag, Summary: "Get a " + name, Auth: bearerAuth, Data: one},
+		{Method: http.MethodPut, Path: admin + "/:id", Tag: tag, Summary: "Update a " + name, Auth: bearerAuth, Body: body},
+		{Method: http.MethodDelete, Path: admin + "/:id", Tag: tag, Summary: "Move a " + name + " to the trash", Auth: bearerAuth},
+	}
+}
+
+func formatParam(description string, formats ...string) openapi.Parameter {
+	enum := []interface{}{}
+	for _, f := range formats {
+		enum = append(enum, f)
+	}
+	return openapi.Parameter{Name: "format", In: "query", Description: description, Schema: &openapi.Schema{Type: "string", Enum: enum}}
+}
+
+// systemRoutes are served at the root, outside of the api versions.
+func systemRoutes() []openapi.Route {
+	return []openapi.Route{
+		{Method: http.MethodGet, Path: "/api/check", Tag: "System", Summary: "Plain liveness probe", NoContent: true, Produces: []string{echo.MIMETextPlain}},
+		{Method: http.MethodGet, Path: "/healthz", Tag: "System", Summary: "Liveness probe", Description: "Answers 200 while the process runs, the dependencies aren't checked.", Raw: health.Report{}},
+		{Method: http.MethodGet, Path: "/readyz", Tag: "System", Summary: "Readiness probe", Description: "Answers 503 with the report when a dependency is down or the server is shutting down. Checks are only up or down, the errors are logged.", Raw: health.Report{}},
+		{Method: http.MethodGet, Path: "/metrics", Tag: "System", Summary: "Prometheus metrics", Description: "Only registered when METRICS_ENABLED is true.", Auth: metricsAuth, NoContent: true, Produces: []string{echo.MIMETextPlain}},
+		{Method: http.MethodGet, Path: "/openapi.json", Tag: "System", Summary: "This document", Raw: map[string]interface{}{}},
+		{Method: http.MethodGet, Path: "/docs", Tag: "System", Summary: "Swagger UI of this document", NoContent: true, Produces: []string{echo.MIMETextHTML}},
+		{
+			Method: http.MethodPut, Path: storage.LocalUploadRoute + "/*", Tag: "Upload", Summary: "Receive a signed upload",
+			Description: "Only registered with the local storage driver, it stands in for the storage provider. The url is the upload_url returned by the sign route.",
+			Params: []openapi.Parameter{
+				{Name: "content_type", In: "query", Required: true, Schema: &openapi.Schema{Type: "string"}},
+				{Name: "size", In: "query", Required: true, Schema: &openapi.Schema{Type: "integer", Format: "int64"}},
+				{Name: "expires", In: "query", Required: true, Schema: &openapi.Schema{Type: "integer", Format: "int64"}},
+				{Name: "signature", In: "query", Required: true, Schema: &openapi.Schema{Type: "string"}},
+			},
+			Body: &openapi.Schema{Type: "string", Format: "binary"}, BodyType: echo.MIMEOctetStream,
+			Status: http.StatusCreated, NoContent: true,
+		},
+		{Method: http.MethodGet, Path: storage.DefaultLocalRoute + "*", Tag: "Upload", Summary: "Download a stored file", Description: "Only registered with the local storage driver.", NoContent: true, Produces: []string{echo.MIMEOctetStream}},
+	}
+}
+
+// apiRoutes are the routes of the New*Handler functions, relative to the
+// prefix of the api version.
+func apiRoutes() []openapi.Route {
+	var routes []openapi.Route
+	add := func(r ...openapi.Route) { routes = append(routes, r...) }
+
+	// Auth
+	add(openapi.Route{Method: http.MethodPost, Path: "/login", Tag: "Auth", Summary: "Log in as admin", Description: "A wrong email and a wrong password get the same wrong_credentials error.", Body: request.LoginRequest{}, Data: response.LoginResponse{}})
+
+	// Uploads
+	uploadForm := &openapi.Schema{
+		Type:     "object",
+		Required: []string{"file"},
+		Properties: map[string]*openapi.Schema{
+			"file":     {Type: "string", Format: "binary"},
+			"purpose":  {Type: "string", Enum: []interface{}{upload.PurposeImage, upload.PurposePDF, upload.PurposeDocx, upload.PurposeVideo}, Example: upload.PurposeImage},
+			"alt_text": {Type: "string", MaxLength: intPtr(255), Example: "Village hall at sunset"},
+		},
+	}
+	add(
+		openapi.Route{Method: http.MethodPost, Path: "/upload-image", Tag: "Upload", Summary: "Upload a file", Description: "Images get resized webp and jpeg variants. Same as POST /upload-file.", Auth: bearerAuth, Body: uploadForm, BodyType: echo.MIMEMultipartForm, Data: response.UploadResponse{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodPost, Path: "/upload-file", Tag: "Upload", Summary: "Upload a file", Description: "The purpose picks the allowed types and the size limit. Images get resized webp and jpeg variants.", Auth: bearerAuth, Body: uploadForm, BodyType: echo.MIMEMultipartForm, Data: response.UploadResponse{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodPost, Path: "/upload-file/sign", Tag: "Upload", Summary: "Sign a direct upload to storage", Description: "Send the file with the returned method, url and headers, then call POST /upload-file/complete with the token.", Auth: bearerAuth, Body: request.SignedUploadRequest{}, Data: response.SignedUploadResponse{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodPost, Path: "/upload-file/complete", Tag: "Upload", Summary: "Complete a direct upload", Auth: bearerAuth, Body: request.CompleteUploadRequest{}, Data: response.UploadResponse{}, Status: http.StatusCreated},
+	)
+
+	// Media
+	add(
+		openapi.Route{Method: http.MethodGet, Path: "/media/admin", Tag: "Media", Summary: "List the media library", Auth: bearerAuth, Query: request.MediaFilterRequest{}, Data: []response.MediaResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/media/admin/:id", Tag: "Media", Summary: "Get a media item with its usages", Auth: bearerAuth, Data: response.MediaResponse{}},
+		openapi.Route{Method: http.MethodPut, Path: "/media/admin/:id", Tag: "Media", Summary: "Update the alt text of a media item", Auth: bearerAuth, Body: request.MediaAltTextRequest{}},
+		openapi.Route{Method: http.MethodDelete, Path: "/media/admin/:id", Tag: "Media", Summary: "Delete a media item", Description: "Fails with media_in_use while a section still shows it.", Auth: bearerAuth},
+	)
+
+	// Home
+	add(openapi.Route{Method: http.MethodGet, Path: "/home", Tag: "Home", Summary: "Get every section of the home page", Description: "The sections are fetched at the same time and cached for API_HOME_CACHE_TTL seconds, an admin change to one of them drops the cache. about_company and contact_us are null until one is created.", Data: response.HomeResponse{}})
+
+	// Sections
+	add(openapi.Route{Method: http.MethodGet, Path: "/hero-sections", Tag: "Hero section", Summary: "List the hero sections of the home page", Data: []response.HeroSectionResponse{}})
+	add(crudRoutes("Hero section", "hero section", "/hero-sections", request.HeroSectionRequest{}, response.HeroSectionResponse{}, []response.HeroSectionResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/client-sections", Tag: "Client section", Summary: "List the clients of the home page", Data: []response.ClientSectionResponse{}})
+	add(crudRoutes("Client section", "client", "/client-sections", request.ClientSectionRquest{}, response.ClientSectionResponse{}, []response.ClientSectionResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/about-companies", Tag: "About company", Summary: "Get the about section of the home page", Data: response.AboutCompanyResponse{}})
+	add(crudRoutes("About company", "company description", "/about-companies", request.AboutCompanyRequest{}, response.AboutCompanyResponse{}, []response.AboutCompanyResponse{})...)
+
+	add(crudRoutes("About company keynote", "keynote", "/about-company-keynotes", request.AboutCompanyKeynoteRequest{}, response.AboutCompanyKeynoteResponse{}, []response.AboutCompanyKeynoteResponse{})...)
+	add(openapi.Route{Method: http.MethodGet, Path: "/about-company-keynotes/admin/keynotes/:id", Tag: "About company keynote", Summary: "List the keynotes of a company description", Auth: bearerAuth, Data: []response.AboutCompanyKeynoteResponse{}})
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/faq-sections", Tag: "FAQ", Summary: "List the questions of the home page", Data: []response.FaqSectionResponse{}})
+	add(crudRoutes("FAQ", "question", "/faq-sections", request.FaqSectionRequest{}, response.FaqSectionResponse{}, []response.FaqSectionResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/our-teams", Tag: "Our team", Summary: "List the team members of the home page", Data: []response.OurTeamResponse{}})
+	add(crudRoutes("Our team", "team member", "/our-teams", request.OurTeamRequest{}, response.OurTeamResponse{}, []response.OurTeamResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/service-sections", Tag: "Service section", Summary: "List the services of the home page", Data: []response.ServiceSectionResponse{}})
+	add(crudRoutes("Service section", "service", "/service-sections", request.ServiceSectionRequest{}, response.ServiceSectionResponse{}, []response.ServiceSectionResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/service-details/:id", Tag: "Service detail", Summary: "Get the detail page of a service", Description: "The id is the one of the service section.", Data: response.ServiceDetailResponse{}})
+	add(crudRoutes("Service detail", "service detail", "/service-details", request.ServiceDetailRequest{}, response.ServiceDetailResponse{}, []response.ServiceDetailResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/portofolio-sections", Tag: "Portfolio section", Summary: "List the portfolio of the home page", Data: []response.PortofolioSectionResponse{}})
+	add(crudRoutes("Portfolio section", "portfolio", "/portofolio-sections", request.PortofolioSectionRequest{}, response.PortofolioSectionResponse{}, []response.PortofolioSectionResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/portofolio-details/:id", Tag: "Portfolio detail", Summary: "Get the detail page of a portfolio", Description: "The id is the one of the portfolio section.", Data: response.PortofolioDetailResponse{}})
+	add(crudRoutes("Portfolio detail", "portfolio detail", "/portofolio-details", request.PortofolioDetailRequest{}, response.PortofolioDetailResponse{}, []response.PortofolioDetailResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/portofolio-testimonials", Tag: "Portfolio testimonial", Summary: "List the testimonials of the home page", Data: []response.PortofolioTestimonialResponse{}})
+	add(crudRoutes("Portfolio testimonial", "testimonial", "/portofolio-testimonials", request.PortofolioTestimonialRequest{}, response.PortofolioTestimonialResponse{}, []response.PortofolioTestimonialResponse{})...)
+
+	add(openapi.Route{Method: http.MethodGet, Path: "/contact-us", Tag: "Contact us", Summary: "Get the contact section of the home page", Data: response.ContactUsResponse{}})
+	add(crudRoutes("Contact us", "contact", "/contact-us", request.ContactUsRequest{}, response.ContactUsResponse{}, []response.ContactUsResponse{})...)
+
+	// Statistics, the admin list doubles as the export
+	add(
+		openapi.Route{Method: http.MethodGet, Path: "/statistics", Tag: "Statistic", Summary: "List the statistics of the home page", Data: []response.StatisticResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/statistics/admin", Tag: "Statistic", Summary: "Create a statistic", Auth: bearerAuth, Body: request.StatisticRequest{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodGet, Path: "/statistics/admin", Tag: "Statistic", Summary: "List or export every statistic", Description: "With format csv or xlsx the list is streamed as an attachment.", Auth: bearerAuth, Params: []openapi.Parameter{formatParam("Export format, json when empty.", "json", export.FormatCSV, export.FormatXLSX)}, Data: []response.StatisticResponse{}, Produces: exportTypes},
+		openapi.Route{Method: http.MethodGet, Path: "/statistics/admin/:id", Tag: "Statistic", Summary: "Get a statistic", Auth: bearerAuth, Data: response.StatisticResponse{}},
+		openapi.Route{Method: http.MethodPut, Path: "/statistics/admin/:id", Tag: "Statistic", Summary: "Update a statistic", Auth: bearerAuth, Body: request.StatisticRequest{}},
+		openapi.Route{Method: http.MethodDelete, Path: "/statistics/admin/:id", Tag: "Statistic", Summary: "Move a statistic to the trash", Auth: bearerAuth},
+	)
+
+	// Appointments are created by visitors
+	add(
+		openapi.Route{Method: http.MethodPost, Path: "/appointments", Tag: "Appointment", Summary: "Book an appointment", Description: "The visitor and the admin get a confirmation email.", Body: request.AppointmentRequest{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodGet, Path: "/appointments/admin", Tag: "Appointment", Summary: "List or export the appointments", Description: "With format csv or xlsx the list is streamed as an attachment.", Auth: bearerAuth, Query: request.AppointmentFilterRequest{}, Data: []response.AppointmentResponse{}, Produces: exportTypes},
+		openapi.Route{Method: http.MethodGet, Path: "/appointments/admin/:id", Tag: "Appointment", Summary: "Get an appointment", Auth: bearerAuth, Data: response.AppointmentResponse{}},
+		openapi.Route{Method: http.MethodDelete, Path: "/appointments/admin/:id", Tag: "Appointment", Summary: "Move an appointment to the trash", Auth: bearerAuth},
+	)
+
+	// Posts
+	add(
+		openapi.Route{Method: http.MethodGet, Path: "/posts", Tag: "Post", Summary: "List the published posts", Data: []response.PostResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/posts/:id", Tag: "Post", Summary: "Get a post", Data: response.PostResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/posts/slug/:slug", Tag: "Post", Summary: "Get a post by its slug", Data: response.PostResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/posts/admin", Tag: "Post", Summary: "Create a post", Description: "The slug is made from the title when empty, a taken slug fails with slug_taken.", Auth: bearerAuth, Body: request.PostRequest{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodPut, Path: "/posts/admin/:id", Tag: "Post", Summary: "Update a post", Auth: bearerAuth, Body: request.PostRequest{}},
+		openapi.Route{Method: http.MethodDelete, Path: "/posts/admin/:id", Tag: "Post", Summary: "Move a post to the trash", Auth: bearerAuth},
+	)
+
+	// Profile
+	add(
+		openapi.Route{Method: http.MethodGet, Path: "/profile/:id", Tag: "Profile", Summary: "Get a profile page", Data: response.ProfileResponse{}},
+		openapi.Route{Method: http.MethodPut, Path: "/profile/admin/:id", Tag: "Profile", Summary: "Update a profile page", Auth: bearerAuth, Body: request.ProfileRequest{}},
+	)
+
+	// Inquiries come from the contact form
+	add(
+		openapi.Route{Method: http.MethodPost, Path: "/inquiries", Tag: "Inquiry", Summary: "Send the contact form", Description: "Limited to 5 inquiries per 10 minutes for each client ip, over the limit the route answers 429.", Body: request.InquiryRequest{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodGet, Path: "/inquiries/admin", Tag: "Inquiry", Summary: "List the inquiries", Auth: bearerAuth, Query: request.InquiryFilterRequest{}, Data: []response.InquiryResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/inquiries/admin/unread-count", Tag: "Inquiry", Summary: "Count the unread inquiries", Auth: bearerAuth, Data: map[string]int64{}},
+		openapi.Route{Method: http.MethodGet, Path: "/inquiries/admin/:id", Tag: "Inquiry", Summary: "Get an inquiry with its replies", Auth: bearerAuth, Data: response.InquiryResponse{}},
+		openapi.Route{Method: http.MethodPut, Path: "/inquiries/admin/:id/read", Tag: "Inquiry", Summary: "Mark an inquiry read or unread", Auth: bearerAuth, Body: request.InquiryReadRequest{}},
+		openapi.Route{Method: http.MethodPut, Path: "/inquiries/admin/:id/archive", Tag: "Inquiry", Summary: "Archive or unarchive an inquiry", Auth: bearerAuth, Body: request.InquiryArchiveRequest{}},
+		openapi.Route{Method: http.MethodPost, Path: "/inquiries/admin/:id/replies", Tag: "Inquiry", Summary: "Reply to an inquiry by email", Auth: bearerAuth, Body: request.InquiryReplyRequest{}, Status: http.StatusCreated},
+		openapi.Route{Method: http.MethodDelete, Path: "/inquiries/admin/:id", Tag: "Inquiry", Summary: "Move an inquiry to the trash", Auth: bearerAuth},
+	)
+
+	// Email
+	add(
+		openapi.Route{Method: http.MethodGet, Path: "/email-outbox/admin", Tag: "Email outbox", Summary: "List the queued and sent emails", Auth: bearerAuth, Query: request.EmailOutboxFilterRequest{}, Data: []response.EmailOutboxResponse{}},
+		openapi.Route{Method: http.MethodGet, Path: "/email-outbox/admin/:id", Tag: "Email outbox", Summary: "Get an email", Auth: bearerAuth, Data: response.EmailOutboxResponse{}},
+		openapi.Route{Method: http.MethodPost, Path: "/email-outbox/admin/:id/retry", Tag: "Email outbox", Summary: "Queue a dead email again", Description: "Fails with conflict when the email isn't dead.", Auth: bearerAuth},
+		openapi.Route{Method: http.MethodGet, Path: "/email-templates/admin", Tag: "Email template", Summary: "List the email template names", Auth: bearerAuth, Data: []string{}},
+		openapi.Route{Method: http.MethodGet, Path: "/email-templates/admin/:name", Tag: "Email template", Summary: "Preview an email template with sample data", Description: "format html or text sends the rendered body alone.", Auth: bearerAuth, Params: []openapi.Parameter{formatParam("Body to send, the json envelope when empty.", "json", "html", "text")}, Data: response.EmailTemplateResponse{}, Produces: []string{echo.MIMETextHTML, echo.MIMETextPlain}},
+	)
+
+	// Trash
+	resources := repository.TrashResources()
+	sort.Strings(resources)
+	resourceEnum := []interface{}{}
+	for _, r := range resources {
+		resourceEnum = append(resourceEnum, r)
+	}
+	resourceParam := openapi.Parameter{Name: "resource", In: "path", Required: true, Schema: &openapi.Schema{Type: "string", Enum: resourceEnum}}
+	add(
+		openapi.Route{Method: http.MethodGet, Path: "/trash/admin", Tag: "Trash", Summary: "List the deleted records", Auth: bearerAuth, Query: request.TrashFilterRequest{}, Data: []response.TrashResponse{}},
+		openapi.Route{Method: http.MethodPut, Path: "/trash/admin/:resource/:id/restore", Tag: "Trash", Summary: "Restore a deleted record", Description: "Fails with trash_parent_deleted while the record it belongs to is in the trash.", Auth: bearerAuth},
+		openapi.Route{Method: http.MethodDelete, Path: "/trash/admin/:resource/:id", Tag: "Trash", Summary: "Delete a record for good", Description: "Fails with still_referenced while a record outside the trash refers to it, like an appointment to its service.", Auth: bearerAuth},
+		openapi.Route{Method: http.MethodDelete, Path: "/trash/admin/:resource", Tag: "Trash", Summary: "Empty the trash of a resource", Auth: bearerAuth},
+	)
+	for i := len(routes) - 3; i < len(routes); i++ {
+		routes[i].Params = []openapi.Parameter{resourceParam}
+	}
+
+	return routes
+}
+
+func intPtr(v int) *int { return &v }
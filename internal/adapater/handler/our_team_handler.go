@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type OurTeamHandlerInterface interface {
@@ -33,25 +34,26 @@ func (h *ourTeamHandler) FetchAllOurTeamHome(c echo.Context) error {
 	var (
 		respOurTeams = []response.OurTeamResponse{}
 		resp         = response.DefaultSuccessResponse{}
-		respError    = response.ErrorResponseDefault{}
 		ctx          = c.Request().Context()
 	)
 
 	results, err := h.ourTeamService.FetchAllOurTeam(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllOurTeamHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllOurTeamHome", 1, err)
+		return err
 	}
 
 	for _, val := range results {
 		respOurTeams = append(respOurTeams, response.OurTeamResponse{
-			ID:        val.ID,
-			Name:      val.Name,
-			Role:      val.Role,
-			PathPhoto: val.PathPhoto,
-			Tagline:   val.Tagline,
+			ID:           val.ID,
+			Name:         val.Name,
+			Role:         val.Role,
+			PathPhoto:    val.PathPhoto,
+			PhotoAlt:     val.PhotoAlt,
+			PhotoCaption: val.PhotoCaption,
+			Tagline:      val.Tagline,
+
+			PathPhotoSet: imageSetResponse(val.PathPhoto),
 		})
 	}
 
@@ -66,47 +68,40 @@ func (h *ourTeamHandler) FetchAllOurTeamHome(c echo.Context) error {
 // CreateOurTeam implements OurTeamHandlerInterface.
 func (h *ourTeamHandler) CreateOurTeam(c echo.Context) error {
 	var (
-		req       = request.OurTeamRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.OurTeamRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateOurTeam - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateOurTeam", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateOurTeam - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateOurTeam", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateOurTeam - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateOurTeam", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.OurTeamEntity{
-		Name:      req.Name,
-		Role:      req.Role,
-		PathPhoto: req.PathPhoto,
-		Tagline:   req.Tagline,
+		Name:         req.Name,
+		Role:         req.Role,
+		PathPhoto:    req.PathPhoto,
+		PhotoAlt:     req.PhotoAlt,
+		PhotoCaption: req.PhotoCaption,
+		Tagline:      req.Tagline,
 	}
 
 	err = h.ourTeamService.CreateOurTeam(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateOurTeam - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateOurTeam", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create our team"
@@ -119,34 +114,27 @@ func (h *ourTeamHandler) CreateOurTeam(c echo.Context) error {
 // DeleteByIDOurTeam implements OurTeamHandlerInterface.
 func (h *ourTeamHandler) DeleteByIDOurTeam(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDOurTeam - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDOurTeam", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idOurTeam := c.Param("id")
 	id, err := conv.StringToInt64(idOurTeam)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDOurTeam - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDOurTeam", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = h.ourTeamService.DeleteByIDOurTeam(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDOurTeam - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDOurTeam", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete our team"
 	resp.Meta.Status = true
@@ -159,57 +147,48 @@ func (h *ourTeamHandler) DeleteByIDOurTeam(c echo.Context) error {
 // EditByIDOurTeam implements OurTeamHandlerInterface.
 func (h *ourTeamHandler) EditByIDOurTeam(c echo.Context) error {
 	var (
-		req       = request.OurTeamRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.OurTeamRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDOurTeam - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDOurTeam", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idOurTeam := c.Param("id")
 	id, err := conv.StringToInt64(idOurTeam)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDOurTeam - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDOurTeam", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDOurTeam - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDOurTeam", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDOurTeam - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDOurTeam", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.OurTeamEntity{
-		ID:        id,
-		Name:      req.Name,
-		Role:      req.Role,
-		PathPhoto: req.PathPhoto,
-		Tagline:   req.Tagline,
+		ID:           id,
+		Name:         req.Name,
+		Role:         req.Role,
+		PathPhoto:    req.PathPhoto,
+		PhotoAlt:     req.PhotoAlt,
+		PhotoCaption: req.PhotoCaption,
+		Tagline:      req.Tagline,
 	}
 
 	err = h.ourTeamService.EditByIDOurTeam(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDOurTeam - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDOurTeam", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit our team"
 	resp.Meta.Status = true
@@ -222,34 +201,31 @@ func (h *ourTeamHandler) EditByIDOurTeam(c echo.Context) error {
 func (h *ourTeamHandler) FetchAllOurTeam(c echo.Context) error {
 	var (
 		resp        = response.DefaultSuccessResponse{}
-		respError   = response.ErrorResponseDefault{}
 		ctx         = c.Request().Context()
 		respOurTeam = []response.OurTeamResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllOurTeam - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllOurTeam", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := h.ourTeamService.FetchAllOurTeam(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllOurTeam - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllOurTeam", 2, err)
+		return err
 	}
 
 	for _, val := range results {
 		respOurTeam = append(respOurTeam, response.OurTeamResponse{
-			ID:        val.ID,
-			Name:      val.Name,
-			Role:      val.Role,
-			PathPhoto: val.PathPhoto,
-			Tagline:   val.Tagline,
+			ID:           val.ID,
+			Name:         val.Name,
+			Role:         val.Role,
+			PathPhoto:    val.PathPhoto,
+			PhotoAlt:     val.PhotoAlt,
+			PhotoCaption: val.PhotoCaption,
+			Tagline:      val.Tagline,
 		})
 	}
 
@@ -264,40 +240,35 @@ func (h *ourTeamHandler) FetchAllOurTeam(c echo.Context) error {
 func (h *ourTeamHandler) FetchByIDOurTeam(c echo.Context) error {
 	var (
 		resp        = response.DefaultSuccessResponse{}
-		respError   = response.ErrorResponseDefault{}
 		ctx         = c.Request().Context()
 		respOurTeam = response.OurTeamResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDOurTeam - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDOurTeam", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idOurTeam := c.Param("id")
 	id, err := conv.StringToInt64(idOurTeam)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDOurTeam - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDOurTeam", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := h.ourTeamService.FetchByIDOurTeam(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDOurTeam - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDOurTeam", 3, err)
+		return err
 	}
 
 	respOurTeam.ID = result.ID
 	respOurTeam.Name = result.Name
 	respOurTeam.Role = result.Role
 	respOurTeam.PathPhoto = result.PathPhoto
+	respOurTeam.PhotoAlt = result.PhotoAlt
+	respOurTeam.PhotoCaption = result.PhotoCaption
 	respOurTeam.Tagline = result.Tagline
 	resp.Meta.Message = "Success fetch our team by ID"
 	resp.Meta.Status = true
@@ -306,7 +277,7 @@ func (h *ourTeamHandler) FetchByIDOurTeam(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewOurTeamHandler(c *echo.Echo, cfg *config.Config, ourTeamService service.OurTeamServiceInterface) OurTeamHandlerInterface {
+func NewOurTeamHandler(c *echo.Group, cfg *config.Config, ourTeamService service.OurTeamServiceInterface) OurTeamHandlerInterface {
 	heroHandler := &ourTeamHandler{
 		ourTeamService: ourTeamService,
 	}
@@ -4,15 +4,16 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type PortofolioDetailHandlerInterface interface {
@@ -34,24 +35,19 @@ func (cs *portofolioDetailHandler) FetchDetailPotofolioByPortoID(c echo.Context)
 	var (
 		respDetail = response.PortofolioDetailResponse{}
 		resp       = response.DefaultSuccessResponse{}
-		respError  = response.ErrorResponseDefault{}
 		ctx        = c.Request().Context()
 	)
 	idPorto := c.Param("id")
 	id, err := conv.StringToInt64(idPorto)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchDetailPotofolioByPortoID - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchDetailPotofolioByPortoID", 1, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.portofolioDetailService.FetchDetailPotofolioByPortoID(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchDetailPotofolioByPortoID - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchDetailPotofolioByPortoID", 2, err)
+		return err
 	}
 	respDetail.ID = result.ID
 	respDetail.Category = result.Category
@@ -73,40 +69,31 @@ func (cs *portofolioDetailHandler) FetchDetailPotofolioByPortoID(c echo.Context)
 // CreatePortofolioDetail implements PortofolioDetailHandlerInterface.
 func (cs *portofolioDetailHandler) CreatePortofolioDetail(c echo.Context) error {
 	var (
-		req       = request.PortofolioDetailRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.PortofolioDetailRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreatePortofolioDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreatePortofolioDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioDetail", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioDetail", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	stringProjectDate, err := time.Parse("2006-01-02", req.ProjectDate)
 	if err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioDetail - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioDetail", 4, err)
+		return apperr.Validation("date must be formatted as YYYY-MM-DD").Wrap(err)
 	}
 	reqEntity := entity.PortofolioDetailEntity{
 		Category:    req.Category,
@@ -122,10 +109,8 @@ func (cs *portofolioDetailHandler) CreatePortofolioDetail(c echo.Context) error
 
 	err = cs.portofolioDetailService.CreatePortofolioDetail(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioDetail - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioDetail", 5, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create portofolio detail"
@@ -138,34 +123,27 @@ func (cs *portofolioDetailHandler) CreatePortofolioDetail(c echo.Context) error
 // DeleteByIDPortofolioDetail implements PortofolioDetailHandlerInterface.
 func (cs *portofolioDetailHandler) DeleteByIDPortofolioDetail(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDPortofolioDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioDetail := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioDetail)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPortofolioDetail", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.portofolioDetailService.DeleteByIDPortofolioDetail(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPortofolioDetail", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete portofolio detail"
 	resp.Meta.Status = true
@@ -178,49 +156,38 @@ func (cs *portofolioDetailHandler) DeleteByIDPortofolioDetail(c echo.Context) er
 // EditByIDPortofolioDetail implements PortofolioDetailHandlerInterface.
 func (cs *portofolioDetailHandler) EditByIDPortofolioDetail(c echo.Context) error {
 	var (
-		req       = request.PortofolioDetailRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.PortofolioDetailRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDPortofolioDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDPortofolioDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioDetail := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioDetail)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioDetail", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioDetail", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioDetail - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioDetail", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	stringProjectDate, err := time.Parse("2006-01-02", req.ProjectDate)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioDetail - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioDetail", 5, err)
+		return apperr.Validation("date must be formatted as YYYY-MM-DD").Wrap(err)
 	}
 
 	reqEntity := entity.PortofolioDetailEntity{
@@ -238,10 +205,8 @@ func (cs *portofolioDetailHandler) EditByIDPortofolioDetail(c echo.Context) erro
 
 	err = cs.portofolioDetailService.EditByIDPortofolioDetail(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioDetail - 6: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioDetail", 6, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit portofolio detail"
 	resp.Meta.Status = true
@@ -254,25 +219,20 @@ func (cs *portofolioDetailHandler) EditByIDPortofolioDetail(c echo.Context) erro
 func (cs *portofolioDetailHandler) FetchAllPortofolioDetail(c echo.Context) error {
 	var (
 		resp                 = response.DefaultSuccessResponse{}
-		respError            = response.ErrorResponseDefault{}
 		ctx                  = c.Request().Context()
 		respPortofolioDetail = []response.PortofolioDetailResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllPortofolioDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllPortofolioDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.portofolioDetailService.FetchAllPortofolioDetail(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllPortofolioDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllPortofolioDetail", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -303,34 +263,27 @@ func (cs *portofolioDetailHandler) FetchAllPortofolioDetail(c echo.Context) erro
 func (cs *portofolioDetailHandler) FetchByIDPortofolioDetail(c echo.Context) error {
 	var (
 		resp                 = response.DefaultSuccessResponse{}
-		respError            = response.ErrorResponseDefault{}
 		ctx                  = c.Request().Context()
 		respPortofolioDetail = response.PortofolioDetailResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDPortofolioDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDPortofolioDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioDetail := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioDetail)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPortofolioDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPortofolioDetail", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.portofolioDetailService.FetchByIDPortofolioDetail(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPortofolioDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPortofolioDetail", 3, err)
+		return err
 	}
 
 	respPortofolioDetail.ID = result.ID
@@ -351,7 +304,7 @@ func (cs *portofolioDetailHandler) FetchByIDPortofolioDetail(c echo.Context) err
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewPortofolioDetailHandler(e *echo.Echo, portofolioDetailService service.PortofolioDetailServiceInterface, cfg *config.Config) PortofolioDetailHandlerInterface {
+func NewPortofolioDetailHandler(e *echo.Group, portofolioDetailService service.PortofolioDetailServiceInterface, cfg *config.Config) PortofolioDetailHandlerInterface {
 	h := &portofolioDetailHandler{
 		portofolioDetailService: portofolioDetailService,
 	}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type PortofolioSectionHandlerInterface interface {
@@ -33,23 +34,24 @@ func (cs *portofolioSectionHandler) FetchAllPortofolioHome(c echo.Context) error
 	var (
 		respPortofolios = []response.PortofolioSectionResponse{}
 		resp            = response.DefaultSuccessResponse{}
-		respError       = response.ErrorResponseDefault{}
 		ctx             = c.Request().Context()
 	)
 
 	results, err := cs.portofolioSectionService.FetchAllPortofolioSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllPortofolioHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllPortofolioHome", 1, err)
+		return err
 	}
 	for _, val := range results {
 		respPortofolios = append(respPortofolios, response.PortofolioSectionResponse{
-			ID:        val.ID,
-			Name:      val.Name,
-			Tagline:   val.Tagline,
-			Thumbnail: val.Thumbnail,
+			ID:               val.ID,
+			Name:             val.Name,
+			Tagline:          val.Tagline,
+			Thumbnail:        val.Thumbnail,
+			ThumbnailAlt:     val.ThumbnailAlt,
+			ThumbnailCaption: val.ThumbnailCaption,
+
+			ThumbnailSet: imageSetResponse(val.Thumbnail),
 		})
 	}
 
@@ -63,46 +65,39 @@ func (cs *portofolioSectionHandler) FetchAllPortofolioHome(c echo.Context) error
 // CreatePortofolioSection implements PortofolioSectionHandlerInterface.
 func (cs *portofolioSectionHandler) CreatePortofolioSection(c echo.Context) error {
 	var (
-		req       = request.PortofolioSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.PortofolioSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreatePortofolioSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreatePortofolioSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioSection", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioSection", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.PortofolioSectionEntity{
-		Thumbnail: req.Thumbnail,
-		Name:      req.Name,
-		Tagline:   req.Tagline,
+		Thumbnail:        req.Thumbnail,
+		ThumbnailAlt:     req.ThumbnailAlt,
+		ThumbnailCaption: req.ThumbnailCaption,
+		Name:             req.Name,
+		Tagline:          req.Tagline,
 	}
 
 	err = cs.portofolioSectionService.CreatePortofolioSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioSection", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create portofolio section"
@@ -115,34 +110,27 @@ func (cs *portofolioSectionHandler) CreatePortofolioSection(c echo.Context) erro
 // DeleteByIDPortofolioSection implements PortofolioSectionHandlerInterface.
 func (cs *portofolioSectionHandler) DeleteByIDPortofolioSection(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDPortofolioSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioSection := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioSection)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPortofolioSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.portofolioSectionService.DeleteByIDPortofolioSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPortofolioSection", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete portofolio section"
 	resp.Meta.Status = true
@@ -155,56 +143,47 @@ func (cs *portofolioSectionHandler) DeleteByIDPortofolioSection(c echo.Context)
 // EditByIDPortofolioSection implements PortofolioSectionHandlerInterface.
 func (cs *portofolioSectionHandler) EditByIDPortofolioSection(c echo.Context) error {
 	var (
-		req       = request.PortofolioSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.PortofolioSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDPortofolioSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDPortofolioSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioSection := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioSection)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioSection", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioSection", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.PortofolioSectionEntity{
-		ID:        id,
-		Thumbnail: req.Thumbnail,
-		Name:      req.Name,
-		Tagline:   req.Tagline,
+		ID:               id,
+		Thumbnail:        req.Thumbnail,
+		ThumbnailAlt:     req.ThumbnailAlt,
+		ThumbnailCaption: req.ThumbnailCaption,
+		Name:             req.Name,
+		Tagline:          req.Tagline,
 	}
 
 	err = cs.portofolioSectionService.EditByIDPortofolioSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioSection - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioSection", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit portofolio section"
 	resp.Meta.Status = true
@@ -217,33 +196,30 @@ func (cs *portofolioSectionHandler) EditByIDPortofolioSection(c echo.Context) er
 func (cs *portofolioSectionHandler) FetchAllPortofolioSection(c echo.Context) error {
 	var (
 		resp                  = response.DefaultSuccessResponse{}
-		respError             = response.ErrorResponseDefault{}
 		ctx                   = c.Request().Context()
 		respPortofolioSection = []response.PortofolioSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllPortofolioSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllPortofolioSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.portofolioSectionService.FetchAllPortofolioSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllPortofolioSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllPortofolioSection", 2, err)
+		return err
 	}
 
 	for _, val := range results {
 		respPortofolioSection = append(respPortofolioSection, response.PortofolioSectionResponse{
-			ID:        val.ID,
-			Name:      val.Name,
-			Tagline:   val.Tagline,
-			Thumbnail: val.Thumbnail,
+			ID:               val.ID,
+			Name:             val.Name,
+			Tagline:          val.Tagline,
+			Thumbnail:        val.Thumbnail,
+			ThumbnailAlt:     val.ThumbnailAlt,
+			ThumbnailCaption: val.ThumbnailCaption,
 		})
 	}
 
@@ -258,40 +234,35 @@ func (cs *portofolioSectionHandler) FetchAllPortofolioSection(c echo.Context) er
 func (cs *portofolioSectionHandler) FetchByIDPortofolioSection(c echo.Context) error {
 	var (
 		resp                  = response.DefaultSuccessResponse{}
-		respError             = response.ErrorResponseDefault{}
 		ctx                   = c.Request().Context()
 		respPortofolioSection = response.PortofolioSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDPortofolioSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDPortofolioSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioSection := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioSection)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPortofolioSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPortofolioSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.portofolioSectionService.FetchByIDPortofolioSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPortofolioSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPortofolioSection", 3, err)
+		return err
 	}
 
 	respPortofolioSection.ID = result.ID
 	respPortofolioSection.Name = result.Name
 	respPortofolioSection.Tagline = result.Tagline
 	respPortofolioSection.Thumbnail = result.Thumbnail
+	respPortofolioSection.ThumbnailAlt = result.ThumbnailAlt
+	respPortofolioSection.ThumbnailCaption = result.ThumbnailCaption
 	resp.Meta.Message = "Success fetch portofolio section by ID"
 	resp.Meta.Status = true
 	resp.Data = respPortofolioSection
@@ -299,7 +270,7 @@ func (cs *portofolioSectionHandler) FetchByIDPortofolioSection(c echo.Context) e
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewPortofolioSectionHandler(e *echo.Echo, portofolioSectionService service.PortofolioSectionServiceInterface, cfg *config.Config) PortofolioSectionHandlerInterface {
+func NewPortofolioSectionHandler(e *echo.Group, portofolioSectionService service.PortofolioSectionServiceInterface, cfg *config.Config) PortofolioSectionHandlerInterface {
 	h := &portofolioSectionHandler{
 		portofolioSectionService: portofolioSectionService,
 	}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type PortofolioTestimonialHandlerInterface interface {
@@ -33,16 +34,13 @@ func (cs *portofolioTestimonialHandler) FetchAllPortofolioTestimonialHome(c echo
 	var (
 		respTestimonials = []response.PortofolioTestimonialResponse{}
 		resp             = response.DefaultSuccessResponse{}
-		respError        = response.ErrorResponseDefault{}
 		ctx              = c.Request().Context()
 	)
 
 	results, err := cs.portofolioTestimonialService.FetchAllPortofolioTestimonial(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllPortofolioTestimonialHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllPortofolioTestimonialHome", 1, err)
+		return err
 	}
 	for _, val := range results {
 		respTestimonials = append(respTestimonials, response.PortofolioTestimonialResponse{
@@ -67,32 +65,25 @@ func (cs *portofolioTestimonialHandler) FetchAllPortofolioTestimonialHome(c echo
 // CreatePortofolioTestimonial implements PortofolioTestimonialHandlerInterface.
 func (cs *portofolioTestimonialHandler) CreatePortofolioTestimonial(c echo.Context) error {
 	var (
-		req       = request.PortofolioTestimonialRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.PortofolioTestimonialRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreatePortofolioTestimonial - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreatePortofolioTestimonial", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioTestimonial - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioTestimonial", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioTestimonial - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioTestimonial", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.PortofolioTestimonialEntity{
@@ -105,10 +96,8 @@ func (cs *portofolioTestimonialHandler) CreatePortofolioTestimonial(c echo.Conte
 
 	err = cs.portofolioTestimonialService.CreatePortofolioTestimonial(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreatePortofolioTestimonial - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreatePortofolioTestimonial", 5, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create portofolio testimonial"
@@ -121,34 +110,27 @@ func (cs *portofolioTestimonialHandler) CreatePortofolioTestimonial(c echo.Conte
 // DeleteByIDPortofolioTestimonial implements PortofolioTestimonialHandlerInterface.
 func (cs *portofolioTestimonialHandler) DeleteByIDPortofolioTestimonial(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioTestimonial - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDPortofolioTestimonial", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioTestimonial := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioTestimonial)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioTestimonial - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPortofolioTestimonial", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.portofolioTestimonialService.DeleteByIDPortofolioTestimonial(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPortofolioTestimonial - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPortofolioTestimonial", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete portofolio testimonial"
 	resp.Meta.Status = true
@@ -161,41 +143,32 @@ func (cs *portofolioTestimonialHandler) DeleteByIDPortofolioTestimonial(c echo.C
 // EditByIDPortofolioTestimonial implements PortofolioTestimonialHandlerInterface.
 func (cs *portofolioTestimonialHandler) EditByIDPortofolioTestimonial(c echo.Context) error {
 	var (
-		req       = request.PortofolioTestimonialRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.PortofolioTestimonialRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDPortofolioTestimonial - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDPortofolioTestimonial", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioTestimonial := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioTestimonial)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioTestimonial - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioTestimonial", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioTestimonial - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioTestimonial", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioTestimonial - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioTestimonial", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.PortofolioTestimonialEntity{
@@ -209,10 +182,8 @@ func (cs *portofolioTestimonialHandler) EditByIDPortofolioTestimonial(c echo.Con
 
 	err = cs.portofolioTestimonialService.EditByIDPortofolioTestimonial(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPortofolioTestimonial - 6: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPortofolioTestimonial", 6, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit portofolio testimonial"
 	resp.Meta.Status = true
@@ -225,25 +196,20 @@ func (cs *portofolioTestimonialHandler) EditByIDPortofolioTestimonial(c echo.Con
 func (cs *portofolioTestimonialHandler) FetchAllPortofolioTestimonial(c echo.Context) error {
 	var (
 		resp                      = response.DefaultSuccessResponse{}
-		respError                 = response.ErrorResponseDefault{}
 		ctx                       = c.Request().Context()
 		respPortofolioTestimonial = []response.PortofolioTestimonialResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllPortofolioTestimonial - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllPortofolioTestimonial", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.portofolioTestimonialService.FetchAllPortofolioTestimonial(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllPortofolioTestimonial - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllPortofolioTestimonial", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -268,34 +234,27 @@ func (cs *portofolioTestimonialHandler) FetchAllPortofolioTestimonial(c echo.Con
 func (cs *portofolioTestimonialHandler) FetchByIDPortofolioTestimonial(c echo.Context) error {
 	var (
 		resp                      = response.DefaultSuccessResponse{}
-		respError                 = response.ErrorResponseDefault{}
 		ctx                       = c.Request().Context()
 		respPortofolioTestimonial = response.PortofolioTestimonialResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDPortofolioTestimonial - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDPortofolioTestimonial", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPortofolioTestimonial := c.Param("id")
 	id, err := conv.StringToInt64(idPortofolioTestimonial)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPortofolioTestimonial - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPortofolioTestimonial", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.portofolioTestimonialService.FetchByIDPortofolioTestimonial(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPortofolioTestimonial - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPortofolioTestimonial", 3, err)
+		return err
 	}
 
 	respPortofolioTestimonial.ID = result.ID
@@ -314,7 +273,7 @@ func (cs *portofolioTestimonialHandler) FetchByIDPortofolioTestimonial(c echo.Co
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewPortofolioTestimonialHandler(e *echo.Echo, portofolioTestimonialService service.PortofolioTestimonialServiceInterface, cfg *config.Config) PortofolioTestimonialHandlerInterface {
+func NewPortofolioTestimonialHandler(e *echo.Group, portofolioTestimonialService service.PortofolioTestimonialServiceInterface, cfg *config.Config) PortofolioTestimonialHandlerInterface {
 	h := &portofolioTestimonialHandler{
 		portofolioTestimonialService: portofolioTestimonialService,
 	}
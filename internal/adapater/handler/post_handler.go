@@ -4,15 +4,16 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type PostHandlerInterface interface {
@@ -33,38 +34,29 @@ func (p *postHandler) CreatePost(c echo.Context) error {
 	var (
 		req       = request.PostRequest{}
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreatePost - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreatePost", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err := c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreatePost - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreatePost", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err := c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreatePost - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreatePost", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	stringPublishedAt, err := time.Parse("2006-01-02", req.PublishedAt)
 	if err != nil {
-		log.Errorf("[HANDLER] CreatePost - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreatePost", 3, err)
+		return apperr.Validation("date must be formatted as YYYY-MM-DD").Wrap(err)
 	}
 
 	reqEntity := entity.PostEntity{
@@ -72,16 +64,16 @@ func (p *postHandler) CreatePost(c echo.Context) error {
 		Slug:         req.Slug,
 		Author:       req.Author,
 		FeaturedImage: req.FeaturedImage,
+		FeaturedImageAlt: req.FeaturedImageAlt,
+		FeaturedImageCaption: req.FeaturedImageCaption,
 		Content:      req.Content,
 		PublishedAt:  stringPublishedAt,
 	}
 
 	err = p.postService.CreatePost(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreatePost - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreatePost", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create post"
@@ -95,33 +87,26 @@ func (p *postHandler) CreatePost(c echo.Context) error {
 func (p *postHandler) DeleteByIDPost(c echo.Context) error {
 	var (
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDPost - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDPost", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPost := c.Param("id")
 	id, err := conv.StringToInt64(idPost)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPost - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPost", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = p.postService.DeleteByIDPost(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDPost - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDPost", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete post"
 	resp.Meta.Status = true
@@ -136,47 +121,36 @@ func (p *postHandler) EditByIDPost(c echo.Context) error {
 	var (
 		req       = request.PostRequest{}
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDPost - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDPost", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idPost := c.Param("id")
 	id, err := conv.StringToInt64(idPost)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPost - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPost", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err := c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPost - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPost", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err := c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDPost - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPost", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	stringPublishedAt, err := time.Parse("2006-01-02", req.PublishedAt)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPost - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPost", 4, err)
+		return apperr.Validation("date must be formatted as YYYY-MM-DD").Wrap(err)
 	}
 
 	reqEntity := entity.PostEntity{
@@ -185,16 +159,16 @@ func (p *postHandler) EditByIDPost(c echo.Context) error {
 		Slug:         req.Slug,
 		Author:       req.Author,
 		FeaturedImage: req.FeaturedImage,
+		FeaturedImageAlt: req.FeaturedImageAlt,
+		FeaturedImageCaption: req.FeaturedImageCaption,
 		Content:      req.Content,
 		PublishedAt:  stringPublishedAt,
 	}
 
 	err = p.postService.EditByIDPost(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDPost - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDPost", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit post"
 	resp.Meta.Status = true
@@ -207,17 +181,14 @@ func (p *postHandler) EditByIDPost(c echo.Context) error {
 func (p *postHandler) FetchAllPosts(c echo.Context) error {
 	var (
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 		respPosts = []response.PostResponse{}
 	)
 
 	results, err := p.postService.FetchAllPosts(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllPosts - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllPosts", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -227,8 +198,12 @@ func (p *postHandler) FetchAllPosts(c echo.Context) error {
 			Slug:         val.Slug,
 			Author:       val.Author,
 			FeaturedImage: val.FeaturedImage,
+			FeaturedImageAlt: val.FeaturedImageAlt,
+			FeaturedImageCaption: val.FeaturedImageCaption,
 			Content:      val.Content,
 			PublishedAt:  val.PublishedAt.Format("02 Jan 2006 15:04:05"),
+
+			FeaturedImageSet: imageSetResponse(val.FeaturedImage),
 		})
 	}
 
@@ -243,7 +218,6 @@ func (p *postHandler) FetchAllPosts(c echo.Context) error {
 func (p *postHandler) FetchByIDPost(c echo.Context) error {
 	var (
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 		respPost  = response.PostResponse{}
 	)
@@ -251,18 +225,14 @@ func (p *postHandler) FetchByIDPost(c echo.Context) error {
 	idPost := c.Param("id")
 	id, err := conv.StringToInt64(idPost)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPost - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPost", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := p.postService.FetchByIDPost(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDPost - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDPost", 3, err)
+		return err
 	}
 
 	respPost.ID = result.ID
@@ -270,6 +240,9 @@ func (p *postHandler) FetchByIDPost(c echo.Context) error {
 	respPost.Slug = result.Slug
 	respPost.Author = result.Author
 	respPost.FeaturedImage = result.FeaturedImage
+	respPost.FeaturedImageAlt = result.FeaturedImageAlt
+	respPost.FeaturedImageCaption = result.FeaturedImageCaption
+	respPost.FeaturedImageSet = imageSetResponse(result.FeaturedImage)
 	respPost.Content = result.Content
 	respPost.PublishedAt = result.PublishedAt.Format("02 Jan 2006 15:04:05")
 	resp.Meta.Message = "Success fetch post by ID"
@@ -282,7 +255,6 @@ func (p *postHandler) FetchByIDPost(c echo.Context) error {
 func (p *postHandler) FetchBySlugPost(c echo.Context) error {
 	var (
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 		respPost  = response.PostResponse{}
 	)
@@ -291,10 +263,8 @@ func (p *postHandler) FetchBySlugPost(c echo.Context) error {
 
 	result, err := p.postService.FetchBySlugPost(ctx, slug)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchBySlugPost - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchBySlugPost", 1, err)
+		return err
 	}
 
 	respPost.ID = result.ID
@@ -302,6 +272,9 @@ func (p *postHandler) FetchBySlugPost(c echo.Context) error {
 	respPost.Slug = result.Slug
 	respPost.Author = result.Author
 	respPost.FeaturedImage = result.FeaturedImage
+	respPost.FeaturedImageAlt = result.FeaturedImageAlt
+	respPost.FeaturedImageCaption = result.FeaturedImageCaption
+	respPost.FeaturedImageSet = imageSetResponse(result.FeaturedImage)
 	respPost.Content = result.Content
 	respPost.PublishedAt = result.PublishedAt.Format("02 Jan 2006 15:04:05")
 	resp.Meta.Message = "Success fetch post by slug"
@@ -311,7 +284,7 @@ func (p *postHandler) FetchBySlugPost(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewPostHandler(c *echo.Echo, cfg *config.Config, postService service.PostServiceInterface) PostHandlerInterface {
+func NewPostHandler(c *echo.Group, cfg *config.Config, postService service.PostServiceInterface) PostHandlerInterface {
 	postHandler := &postHandler{
 		postService: postService,
 	}
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type ProfileHandlerInterface interface {
@@ -27,7 +28,6 @@ type profileHandler struct {
 func (p *profileHandler) FetchByIDProfile(c echo.Context) error {
 	var (
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 		respProfile response.ProfileResponse
 	)
@@ -35,18 +35,14 @@ func (p *profileHandler) FetchByIDProfile(c echo.Context) error {
 	// Fetch the profile by ID
 	id, err := conv.StringToInt64(c.Param("id"))
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDProfile - 1: %v", err)
-		respError.Meta.Message = "Invalid profile ID"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDProfile", 1, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := p.profileService.FetchByIDProfile(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDProfile - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDProfile", 2, err)
+		return err
 	}
 
 	// Mapping the fetched data to response
@@ -68,40 +64,31 @@ func (p *profileHandler) EditByIDProfile(c echo.Context) error {
 	var (
 		req       = request.ProfileRequest{}
 		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDProfile - Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDProfile", 0, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	// Fetch the profile by ID
 	id, err := conv.StringToInt64(c.Param("id"))
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDProfile - 1: %v", err)
-		respError.Meta.Message = "Invalid profile ID"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDProfile", 1, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err := c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDProfile - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDProfile", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	// Validate the input
 	if err := c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDProfile - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDProfile", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	// Create the ProfileEntity
@@ -114,10 +101,8 @@ func (p *profileHandler) EditByIDProfile(c echo.Context) error {
 	// Call service to update the profile
 	err = p.profileService.EditByIDProfile(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDProfile - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDProfile", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Successfully updated profile"
@@ -127,7 +112,7 @@ func (p *profileHandler) EditByIDProfile(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewProfileHandler(c *echo.Echo, cfg *config.Config, profileService service.ProfileServiceInterface) ProfileHandlerInterface {
+func NewProfileHandler(c *echo.Group, cfg *config.Config, profileService service.ProfileServiceInterface) ProfileHandlerInterface {
 	profileHandler := &profileHandler{
 		profileService: profileService,
 	}
@@ -4,4 +4,6 @@ type AboutCompanyKeynoteRequest struct {
 	AboutCompanyID int64  `json:"about_company_id" validate:"required"`
 	Keynote        string `json:"keynote" validate:"required"`
 	PathImage      string `json:"path_image"`
+	ImageAlt       string `json:"image_alt" validate:"required_with=PathImage,max=255"`
+	ImageCaption   string `json:"image_caption" validate:"max=255"`
 }
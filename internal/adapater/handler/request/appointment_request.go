@@ -3,9 +3,17 @@ package request
 type AppointmentRequest struct {
 	ServiceID   int64   `json:"service_id" validate:"required"`
 	Name        string  `json:"name" validate:"required"`
-	PhoneNumber string  `json:"phone_number" validate:"required"`
+	PhoneNumber string  `json:"phone_number" validate:"required" example:"081234567890"`
 	Email       string  `json:"email" validate:"required,email"`
 	Brief       string  `json:"brief" validate:"required"`
 	Budget      float64 `json:"budget" validate:"required"`
-	MeetAt      string  `json:"meet_at" validate:"required"`
+	MeetAt      string  `json:"meet_at" validate:"required" example:"2025-03-01"`
+}
+
+type AppointmentFilterRequest struct {
+	Search    string `query:"search"`
+	ServiceID int64  `query:"service_id"`
+	StartDate string `query:"start_date" example:"2025-03-01"`
+	EndDate   string `query:"end_date" example:"2025-03-31"`
+	Format    string `query:"format" validate:"omitempty,oneof=json csv xlsx"`
 }
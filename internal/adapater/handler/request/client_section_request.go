@@ -1,6 +1,8 @@
 package request
 
 type ClientSectionRquest struct {
-	Name     string `json:"name" validate:"required"`
-	PathIcon string `json:"path_icon" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+	PathIcon    string `json:"path_icon" validate:"required"`
+	IconAlt     string `json:"icon_alt" validate:"required,max=255"`
+	IconCaption string `json:"icon_caption" validate:"max=255"`
 }
@@ -0,0 +1,5 @@
+package request
+
+type EmailOutboxFilterRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending sent dead"`
+}
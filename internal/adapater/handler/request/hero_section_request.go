@@ -1,8 +1,10 @@
 package request
 
 type HeroSectionRequest struct {
-	Heading    string `json:"heading" validate:"required"`
-	SubHeading string `json:"subheading" validate:"required"`
-	PathVideo  string `json:"path_video"`
-	Banner     string `json:"banner" validate:"required"`
+	Heading       string `json:"heading" validate:"required"`
+	SubHeading    string `json:"subheading" validate:"required"`
+	PathVideo     string `json:"path_video"`
+	Banner        string `json:"banner" validate:"required"`
+	BannerAlt     string `json:"banner_alt" validate:"required,max=255"`
+	BannerCaption string `json:"banner_caption" validate:"max=255"`
 }
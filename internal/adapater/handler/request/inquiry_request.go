@@ -0,0 +1,29 @@
+package request
+
+type InquiryRequest struct {
+	Name        string `json:"name" validate:"required,max=150"`
+	Email       string `json:"email" validate:"required,email,max=150"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,max=17" example:"081234567890"`
+	Subject     string `json:"subject" validate:"required,max=255"`
+	Message     string `json:"message" validate:"required,max=5000"`
+	// Website is a honeypot, it is hidden on the form so only bots fill it in
+	Website string `json:"website"`
+}
+
+type InquiryFilterRequest struct {
+	Status   string `query:"status" validate:"omitempty,oneof=read unread"`
+	Archived bool   `query:"archived"`
+	Search   string `query:"search"`
+}
+
+type InquiryReplyRequest struct {
+	Message string `json:"message" validate:"required"`
+}
+
+type InquiryReadRequest struct {
+	Read *bool `json:"read" validate:"required"`
+}
+
+type InquiryArchiveRequest struct {
+	Archived *bool `json:"archived" validate:"required"`
+}
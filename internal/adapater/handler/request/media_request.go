@@ -0,0 +1,10 @@
+package request
+
+type MediaFilterRequest struct {
+	Search  string `query:"search"`
+	Purpose string `query:"purpose" validate:"omitempty,oneof=image pdf docx video"`
+}
+
+type MediaAltTextRequest struct {
+	AltText string `json:"alt_text" validate:"max=255"`
+}
@@ -1,8 +1,10 @@
 package request
 
 type OurTeamRequest struct {
-	Name      string `json:"name" validate:"required"`
-	Role      string `json:"role" validate:"required"`
-	Tagline   string `json:"tagline"`
-	PathPhoto string `json:"path_photo" validate:"required"`
+	Name         string `json:"name" validate:"required"`
+	Role         string `json:"role" validate:"required"`
+	Tagline      string `json:"tagline"`
+	PathPhoto    string `json:"path_photo" validate:"required"`
+	PhotoAlt     string `json:"photo_alt" validate:"required,max=255"`
+	PhotoCaption string `json:"photo_caption" validate:"max=255"`
 }
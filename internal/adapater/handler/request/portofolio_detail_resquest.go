@@ -3,7 +3,7 @@ package request
 type PortofolioDetailRequest struct {
 	Category            string `json:"category" validate:"required"`
 	ClientName          string `json:"client_name" validate:"required"`
-	ProjectDate         string `json:"project_date" validate:"required"`
+	ProjectDate         string `json:"project_date" validate:"required" example:"2025-03-01"`
 	ProjectUrl          string `json:"project_url"`
 	Title               string `json:"title" validate:"required"`
 	Description         string `json:"description" validate:"required"`
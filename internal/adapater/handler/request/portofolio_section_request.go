@@ -1,7 +1,9 @@
 package request
 
 type PortofolioSectionRequest struct {
-	Thumbnail string `json:"thumbnail" validate:"required"`
-	Name      string `json:"name" validate:"required"`
-	Tagline   string `json:"tagline" validate:"required"`
+	Thumbnail        string `json:"thumbnail" validate:"required"`
+	ThumbnailAlt     string `json:"thumbnail_alt" validate:"required,max=255"`
+	ThumbnailCaption string `json:"thumbnail_caption" validate:"max=255"`
+	Name             string `json:"name" validate:"required"`
+	Tagline          string `json:"tagline" validate:"required"`
 }
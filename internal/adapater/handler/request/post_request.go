@@ -5,6 +5,8 @@ type PostRequest struct {
 	Slug          string `json:"slug"`
 	Author       string `json:"author" validate:"required"`
 	FeaturedImage string `json:"featured_image" validate:"required"`
+	FeaturedImageAlt string `json:"featured_image_alt" validate:"required,max=255"`
+	FeaturedImageCaption string `json:"featured_image_caption" validate:"max=255"`
 	Content      string `json:"content" validate:"required"`
-	PublishedAt  string `json:"published_at" validate:"required"`
+	PublishedAt  string `json:"published_at" validate:"required" example:"2025-03-01"`
 }
@@ -0,0 +1,5 @@
+package request
+
+type TrashFilterRequest struct {
+	Resource string `query:"resource"`
+}
@@ -0,0 +1,13 @@
+package request
+
+type SignedUploadRequest struct {
+	Purpose     string `json:"purpose" validate:"required,oneof=pdf docx video"`
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"required,gt=0"`
+	FileName    string `json:"file_name" validate:"max=255"`
+}
+
+type CompleteUploadRequest struct {
+	Token   string `json:"token" validate:"required"`
+	AltText string `json:"alt_text" validate:"max=255"`
+}
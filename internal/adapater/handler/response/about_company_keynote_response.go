@@ -5,5 +5,7 @@ type AboutCompanyKeynoteResponse struct {
 	AboutCompanyID          int64  `json:"about_company_id"`
 	Keynote                 string `json:"keynote"`
 	PathImage               string `json:"path_image"`
+	ImageAlt                string `json:"image_alt"`
+	ImageCaption            string `json:"image_caption"`
 	AboutCompanyDescription string `json:"about_company_description"`
 }
@@ -1,7 +1,9 @@
 package response
 
 type ClientSectionResponse struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	PathIcon string `json:"path_icon"`
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	PathIcon    string `json:"path_icon"`
+	IconAlt     string `json:"icon_alt"`
+	IconCaption string `json:"icon_caption"`
 }
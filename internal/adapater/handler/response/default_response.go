@@ -2,6 +2,8 @@ package response
 
 type ErrorResponseDefault struct {
 	Meta
+	// Code is stable, clients branch on it instead of the message.
+	Code string `json:"code,omitempty"`
 }
 
 type Meta struct {
@@ -0,0 +1,17 @@
+package response
+
+type EmailOutboxResponse struct {
+	ID            int64  `json:"id"`
+	FromAddress   string `json:"from_address"`
+	ToAddress     string `json:"to_address"`
+	Subject       string `json:"subject"`
+	Body          string `json:"body,omitempty"`
+	TextBody      string `json:"text_body,omitempty"`
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	MaxAttempts   int    `json:"max_attempts"`
+	LastError     string `json:"last_error"`
+	NextAttemptAt string `json:"next_attempt_at"`
+	SentAt        string `json:"sent_at"`
+	CreatedAt     string `json:"created_at"`
+}
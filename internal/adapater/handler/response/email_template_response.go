@@ -0,0 +1,8 @@
+package response
+
+type EmailTemplateResponse struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
@@ -0,0 +1,14 @@
+package response
+
+type HomeResponse struct {
+	HeroSections           []HeroSectionResponse           `json:"hero_sections"`
+	ClientSections         []ClientSectionResponse         `json:"client_sections"`
+	AboutCompany           *AboutCompanyResponse           `json:"about_company"`
+	ServiceSections        []ServiceSectionResponse        `json:"service_sections"`
+	PortofolioSections     []PortofolioSectionResponse     `json:"portofolio_sections"`
+	PortofolioTestimonials []PortofolioTestimonialResponse `json:"portofolio_testimonials"`
+	FaqSections            []FaqSectionResponse            `json:"faq_sections"`
+	OurTeams               []OurTeamResponse               `json:"our_teams"`
+	Statistics             []StatisticResponse             `json:"statistics"`
+	ContactUs              *ContactUsResponse              `json:"contact_us"`
+}
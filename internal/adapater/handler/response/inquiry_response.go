@@ -0,0 +1,21 @@
+package response
+
+type InquiryResponse struct {
+	ID          int64                  `json:"id"`
+	Name        string                 `json:"name"`
+	Email       string                 `json:"email"`
+	PhoneNumber string                 `json:"phone_number"`
+	Subject     string                 `json:"subject"`
+	Message     string                 `json:"message"`
+	IsRead      bool                   `json:"is_read"`
+	IsArchived  bool                   `json:"is_archived"`
+	CreatedAt   string                 `json:"created_at"`
+	Replies     []InquiryReplyResponse `json:"replies,omitempty"`
+}
+
+type InquiryReplyResponse struct {
+	ID        int64  `json:"id"`
+	UserID    int64  `json:"user_id"`
+	Message   string `json:"message"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package response
+
+type MediaResponse struct {
+	ID           int64                `json:"id"`
+	UserID       int64                `json:"user_id"`
+	URL          string               `json:"url"`
+	Purpose      string               `json:"purpose"`
+	ContentType  string               `json:"content_type"`
+	Size         int64                `json:"size"`
+	Width        int                  `json:"width,omitempty"`
+	Height       int                  `json:"height,omitempty"`
+	AltText      string               `json:"alt_text"`
+	OriginalName string               `json:"original_name"`
+	CreatedAt    string               `json:"created_at"`
+	ImageSet     *ImageSetResponse    `json:"image_set,omitempty"`
+	Usages       []MediaUsageResponse `json:"usages,omitempty"`
+}
+
+type MediaUsageResponse struct {
+	Resource   string `json:"resource"`
+	ResourceID int64  `json:"resource_id"`
+	Field      string `json:"field"`
+}
@@ -1,9 +1,13 @@
 package response
 
 type OurTeamResponse struct {
-	ID        int64  `json:"id"`
-	Name      string `json:"name"`
-	Role      string `json:"role"`
-	Tagline   string `json:"tagline"`
-	PathPhoto string `json:"path_photo"`
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	Tagline      string `json:"tagline"`
+	PathPhoto    string `json:"path_photo"`
+	PhotoAlt     string `json:"photo_alt"`
+	PhotoCaption string `json:"photo_caption"`
+
+	PathPhotoSet *ImageSetResponse `json:"path_photo_set,omitempty"`
 }
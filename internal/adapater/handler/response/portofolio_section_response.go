@@ -1,8 +1,12 @@
 package response
 
 type PortofolioSectionResponse struct {
-	ID        int64  `json:"id"`
-	Thumbnail string `json:"thumbnail"`
-	Name      string `json:"name"`
-	Tagline   string `json:"tagline"`
+	ID               int64  `json:"id"`
+	Thumbnail        string `json:"thumbnail"`
+	ThumbnailAlt     string `json:"thumbnail_alt"`
+	ThumbnailCaption string `json:"thumbnail_caption"`
+	Name             string `json:"name"`
+	Tagline          string `json:"tagline"`
+
+	ThumbnailSet *ImageSetResponse `json:"thumbnail_set,omitempty"`
 }
@@ -6,6 +6,10 @@ type PostResponse struct {
 	Slug          string `json:"slug"`
 	Author       string `json:"author"`
 	FeaturedImage string `json:"featured_image"`
+	FeaturedImageAlt string `json:"featured_image_alt"`
+	FeaturedImageCaption string `json:"featured_image_caption"`
 	Content      string `json:"content"`
 	PublishedAt  string `json:"published_at"`
+
+	FeaturedImageSet *ImageSetResponse `json:"featured_image_set,omitempty"`
 }
@@ -0,0 +1,8 @@
+package response
+
+type TrashResponse struct {
+	Resource  string `json:"resource"`
+	ID        int64  `json:"id"`
+	Label     string `json:"label"`
+	DeletedAt string `json:"deleted_at"`
+}
@@ -0,0 +1,32 @@
+package response
+
+type UploadResponse struct {
+	ID          int64                           `json:"id"`
+	URL         string                          `json:"url"`
+	ContentType string                          `json:"content_type"`
+	Variants    map[string]ImageVariantResponse `json:"variants,omitempty"`
+}
+
+type ImageVariantResponse struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height,omitempty"`
+	WebP   string `json:"webp"`
+	JPEG   string `json:"jpeg"`
+}
+
+// ImageSetResponse carries what a <picture> element needs.
+type ImageSetResponse struct {
+	Src        string                          `json:"src"`
+	SrcSet     string                          `json:"srcset"`
+	SrcSetJPEG string                          `json:"srcset_jpeg"`
+	Variants   map[string]ImageVariantResponse `json:"variants"`
+}
+
+type SignedUploadResponse struct {
+	Token     string            `json:"token"`
+	Path      string            `json:"path"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt string            `json:"expires_at"`
+}
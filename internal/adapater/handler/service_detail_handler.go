@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type ServiceDetailHandlerInterface interface {
@@ -28,38 +29,26 @@ type serviceDetailHandler struct {
 	serviceDetailService service.ServiceDetailServiceInterface
 }
 
-// FetchServiceDetail implements ServiceDetailHandlerInterface.
+// FetchServiceDetailByServiceID implements ServiceDetailHandlerInterface.
+// The id is the one of the service section.
 func (cs *serviceDetailHandler) FetchServiceDetailByServiceID(c echo.Context) error {
 	var (
 		resp              = response.DefaultSuccessResponse{}
-		respError         = response.ErrorResponseDefault{}
 		ctx               = c.Request().Context()
 		respServiceDetail = response.ServiceDetailResponse{}
 	)
 
-	user := conv.GetUserIDByContext(c)
-	if user == 0 {
-		log.Errorf("[HANDLER] FetchServiceDetailByServiceID - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
-	}
-
 	idServiceID := c.Param("id")
 	id, err := conv.StringToInt64(idServiceID)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchServiceDetailByServiceID - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchServiceDetailByServiceID", 1, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.serviceDetailService.GetByServiceIDDetail(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchServiceDetailByServiceID - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchServiceDetailByServiceID", 2, err)
+		return err
 	}
 
 	respServiceDetail.ID = result.ID
@@ -80,32 +69,25 @@ func (cs *serviceDetailHandler) FetchServiceDetailByServiceID(c echo.Context) er
 // CreateServiceDetail implements ServiceDetailHandlerInterface.
 func (cs *serviceDetailHandler) CreateServiceDetail(c echo.Context) error {
 	var (
-		req       = request.ServiceDetailRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ServiceDetailRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateServiceDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateServiceDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateServiceDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateServiceDetail", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateServiceDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateServiceDetail", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ServiceDetailEntity{
@@ -119,10 +101,8 @@ func (cs *serviceDetailHandler) CreateServiceDetail(c echo.Context) error {
 
 	err = cs.serviceDetailService.CreateServiceDetail(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateServiceDetail - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateServiceDetail", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create service section"
@@ -135,34 +115,27 @@ func (cs *serviceDetailHandler) CreateServiceDetail(c echo.Context) error {
 // DeleteByIDServiceDetail implements ServiceDetailHandlerInterface.
 func (cs *serviceDetailHandler) DeleteByIDServiceDetail(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDServiceDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDServiceDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idServiceDetail := c.Param("id")
 	id, err := conv.StringToInt64(idServiceDetail)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDServiceDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDServiceDetail", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.serviceDetailService.DeleteByIDServiceDetail(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDServiceDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDServiceDetail", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete service section"
 	resp.Meta.Status = true
@@ -175,41 +148,32 @@ func (cs *serviceDetailHandler) DeleteByIDServiceDetail(c echo.Context) error {
 // EditByIDServiceDetail implements ServiceDetailHandlerInterface.
 func (cs *serviceDetailHandler) EditByIDServiceDetail(c echo.Context) error {
 	var (
-		req       = request.ServiceDetailRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ServiceDetailRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDServiceDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDServiceDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idServiceDetail := c.Param("id")
 	id, err := conv.StringToInt64(idServiceDetail)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceDetail", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceDetail", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceDetail - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceDetail", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ServiceDetailEntity{
@@ -224,10 +188,8 @@ func (cs *serviceDetailHandler) EditByIDServiceDetail(c echo.Context) error {
 
 	err = cs.serviceDetailService.EditByIDServiceDetail(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceDetail - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceDetail", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit service section"
 	resp.Meta.Status = true
@@ -240,25 +202,20 @@ func (cs *serviceDetailHandler) EditByIDServiceDetail(c echo.Context) error {
 func (cs *serviceDetailHandler) FetchAllServiceDetail(c echo.Context) error {
 	var (
 		resp              = response.DefaultSuccessResponse{}
-		respError         = response.ErrorResponseDefault{}
 		ctx               = c.Request().Context()
 		respServiceDetail = []response.ServiceDetailResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllServiceDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllServiceDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.serviceDetailService.FetchAllServiceDetail(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllServiceDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllServiceDetail", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -285,34 +242,27 @@ func (cs *serviceDetailHandler) FetchAllServiceDetail(c echo.Context) error {
 func (cs *serviceDetailHandler) FetchByIDServiceDetail(c echo.Context) error {
 	var (
 		resp              = response.DefaultSuccessResponse{}
-		respError         = response.ErrorResponseDefault{}
 		ctx               = c.Request().Context()
 		respServiceDetail = response.ServiceDetailResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDServiceDetail - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDServiceDetail", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idServiceDetail := c.Param("id")
 	id, err := conv.StringToInt64(idServiceDetail)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDServiceDetail - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDServiceDetail", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.serviceDetailService.FetchByIDServiceDetail(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDServiceDetail - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDServiceDetail", 3, err)
+		return err
 	}
 
 	respServiceDetail.ID = result.ID
@@ -330,7 +280,7 @@ func (cs *serviceDetailHandler) FetchByIDServiceDetail(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewServiceDetailHandler(e *echo.Echo, serviceDetailService service.ServiceDetailServiceInterface, cfg *config.Config) ServiceDetailHandlerInterface {
+func NewServiceDetailHandler(e *echo.Group, serviceDetailService service.ServiceDetailServiceInterface, cfg *config.Config) ServiceDetailHandlerInterface {
 	h := &serviceDetailHandler{
 		serviceDetailService: serviceDetailService,
 	}
@@ -338,7 +288,7 @@ func NewServiceDetailHandler(e *echo.Echo, serviceDetailService service.ServiceD
 	mid := middleware.NewMiddleware(cfg)
 
 	serviceDetailApp := e.Group("/service-details")
-	serviceDetailApp.GET("", h.FetchServiceDetailByServiceID)
+	serviceDetailApp.GET("/:id", h.FetchServiceDetailByServiceID)
 
 	adminApp := serviceDetailApp.Group("/admin", mid.CheckToken())
 
@@ -4,14 +4,15 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type ServiceSectionHandlerInterface interface {
@@ -33,16 +34,13 @@ func (cs *serviceSectionHandler) FetchAllServiceHome(c echo.Context) error {
 	var (
 		respServices = []response.ServiceSectionResponse{}
 		resp         = response.DefaultSuccessResponse{}
-		respError    = response.ErrorResponseDefault{}
 		ctx          = c.Request().Context()
 	)
 
 	results, err := cs.serviceSectionService.FetchAllServiceSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllServiceHome - 1: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllServiceHome", 1, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -63,32 +61,25 @@ func (cs *serviceSectionHandler) FetchAllServiceHome(c echo.Context) error {
 // CreateServiceSection implements ServiceSectionHandlerInterface.
 func (cs *serviceSectionHandler) CreateServiceSection(c echo.Context) error {
 	var (
-		req       = request.ServiceSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ServiceSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateServiceSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateServiceSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateServiceSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateServiceSection", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateServiceSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateServiceSection", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ServiceSectionEntity{
@@ -99,10 +90,8 @@ func (cs *serviceSectionHandler) CreateServiceSection(c echo.Context) error {
 
 	err = cs.serviceSectionService.CreateServiceSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateServiceSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateServiceSection", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create service section"
@@ -115,34 +104,27 @@ func (cs *serviceSectionHandler) CreateServiceSection(c echo.Context) error {
 // DeleteByIDServiceSection implements ServiceSectionHandlerInterface.
 func (cs *serviceSectionHandler) DeleteByIDServiceSection(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDServiceSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDServiceSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idServiceSection := c.Param("id")
 	id, err := conv.StringToInt64(idServiceSection)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDServiceSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDServiceSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = cs.serviceSectionService.DeleteByIDServiceSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDServiceSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDServiceSection", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete service section"
 	resp.Meta.Status = true
@@ -155,41 +137,32 @@ func (cs *serviceSectionHandler) DeleteByIDServiceSection(c echo.Context) error
 // EditByIDServiceSection implements ServiceSectionHandlerInterface.
 func (cs *serviceSectionHandler) EditByIDServiceSection(c echo.Context) error {
 	var (
-		req       = request.ServiceSectionRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.ServiceSectionRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDServiceSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDServiceSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idServiceSection := c.Param("id")
 	id, err := conv.StringToInt64(idServiceSection)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err = c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceSection", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceSection - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceSection", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.ServiceSectionEntity{
@@ -201,10 +174,8 @@ func (cs *serviceSectionHandler) EditByIDServiceSection(c echo.Context) error {
 
 	err = cs.serviceSectionService.EditByIDServiceSection(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDServiceSection - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDServiceSection", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit service section"
 	resp.Meta.Status = true
@@ -217,25 +188,20 @@ func (cs *serviceSectionHandler) EditByIDServiceSection(c echo.Context) error {
 func (cs *serviceSectionHandler) FetchAllServiceSection(c echo.Context) error {
 	var (
 		resp               = response.DefaultSuccessResponse{}
-		respError          = response.ErrorResponseDefault{}
 		ctx                = c.Request().Context()
 		respServiceSection = []response.ServiceSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchAllServiceSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchAllServiceSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	results, err := cs.serviceSectionService.FetchAllServiceSection(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllServiceSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllServiceSection", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -258,34 +224,27 @@ func (cs *serviceSectionHandler) FetchAllServiceSection(c echo.Context) error {
 func (cs *serviceSectionHandler) FetchByIDServiceSection(c echo.Context) error {
 	var (
 		resp               = response.DefaultSuccessResponse{}
-		respError          = response.ErrorResponseDefault{}
 		ctx                = c.Request().Context()
 		respServiceSection = response.ServiceSectionResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDServiceSection - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDServiceSection", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idServiceSection := c.Param("id")
 	id, err := conv.StringToInt64(idServiceSection)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDServiceSection - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDServiceSection", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := cs.serviceSectionService.FetchByIDServiceSection(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDServiceSection - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDServiceSection", 3, err)
+		return err
 	}
 
 	respServiceSection.ID = result.ID
@@ -299,7 +258,7 @@ func (cs *serviceSectionHandler) FetchByIDServiceSection(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewServiceSectionHandler(e *echo.Echo, serviceSectionService service.ServiceSectionServiceInterface, cfg *config.Config) ServiceSectionHandlerInterface {
+func NewServiceSectionHandler(e *echo.Group, serviceSectionService service.ServiceSectionServiceInterface, cfg *config.Config) ServiceSectionHandlerInterface {
 	h := &serviceSectionHandler{
 		serviceSectionService: serviceSectionService,
 	}
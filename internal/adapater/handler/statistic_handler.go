@@ -4,19 +4,22 @@ import (
 	"desadangdang/config"
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/export"
+	"desadangdang/utils/logger"
 	"desadangdang/utils/middleware"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
 )
 
 type StatisticHandlerInterface interface {
 	CreateStatistic(c echo.Context) error
 	FetchAllStatistic(c echo.Context) error
+	ExportStatistic(c echo.Context) error
 	FetchByIDStatistic(c echo.Context) error
 	EditByIDStatistic(c echo.Context) error
 	DeleteByIDStatistic(c echo.Context) error
@@ -29,32 +32,25 @@ type statisticHandler struct {
 // CreateStatistic implements StatisticHandlerInterface.
 func (s *statisticHandler) CreateStatistic(c echo.Context) error {
 	var (
-		req       = request.StatisticRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.StatisticRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] CreateStatistic - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "CreateStatistic", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	if err := c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] CreateStatistic - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "CreateStatistic", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err := c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] CreateStatistic - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "CreateStatistic", 3, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.StatisticEntity{
@@ -65,10 +61,8 @@ func (s *statisticHandler) CreateStatistic(c echo.Context) error {
 
 	err := s.statisticService.CreateStatistic(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] CreateStatistic - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "CreateStatistic", 4, err)
+		return err
 	}
 
 	resp.Meta.Message = "Success create statistic"
@@ -81,34 +75,27 @@ func (s *statisticHandler) CreateStatistic(c echo.Context) error {
 // DeleteByIDStatistic implements StatisticHandlerInterface.
 func (s *statisticHandler) DeleteByIDStatistic(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] DeleteByIDStatistic - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "DeleteByIDStatistic", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idStat := c.Param("id")
 	id, err := conv.StringToInt64(idStat)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDStatistic - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDStatistic", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	err = s.statisticService.DeleteByIDStatistic(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] DeleteByIDStatistic - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "DeleteByIDStatistic", 3, err)
+		return err
 	}
 	resp.Meta.Message = "Success delete statistic"
 	resp.Meta.Status = true
@@ -121,41 +108,32 @@ func (s *statisticHandler) DeleteByIDStatistic(c echo.Context) error {
 // EditByIDStatistic implements StatisticHandlerInterface.
 func (s *statisticHandler) EditByIDStatistic(c echo.Context) error {
 	var (
-		req       = request.StatisticRequest{}
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
+		req  = request.StatisticRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] EditByIDStatistic - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "EditByIDStatistic", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idStat := c.Param("id")
 	id, err := conv.StringToInt64(idStat)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDStatistic - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDStatistic", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	if err := c.Bind(&req); err != nil {
-		log.Errorf("[HANDLER] EditByIDStatistic - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDStatistic", 3, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err := c.Validate(req); err != nil {
-		log.Errorf("[HANDLER] EditByIDStatistic - 4: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "EditByIDStatistic", 4, err)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.StatisticEntity{
@@ -167,10 +145,8 @@ func (s *statisticHandler) EditByIDStatistic(c echo.Context) error {
 
 	err = s.statisticService.EditByIDStatistic(ctx, reqEntity)
 	if err != nil {
-		log.Errorf("[HANDLER] EditByIDStatistic - 5: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "EditByIDStatistic", 5, err)
+		return err
 	}
 	resp.Meta.Message = "Success edit statistic"
 	resp.Meta.Status = true
@@ -182,18 +158,15 @@ func (s *statisticHandler) EditByIDStatistic(c echo.Context) error {
 // FetchAllStatistic implements StatisticHandlerInterface.
 func (s *statisticHandler) FetchAllStatistic(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
-		respStat  = []response.StatisticResponse{}
+		resp     = response.DefaultSuccessResponse{}
+		ctx      = c.Request().Context()
+		respStat = []response.StatisticResponse{}
 	)
 
 	results, err := s.statisticService.FetchAllStatistic(ctx)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchAllStatistic - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchAllStatistic", 2, err)
+		return err
 	}
 
 	for _, val := range results {
@@ -212,38 +185,60 @@ func (s *statisticHandler) FetchAllStatistic(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+// ExportStatistic implements StatisticHandlerInterface.
+func (s *statisticHandler) ExportStatistic(c echo.Context) error {
+	var (
+		format = c.QueryParam("format")
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(c.Request().Context(), logger.Handler, "ExportStatistic", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	if format == "" || format == "json" {
+		return s.FetchAllStatistic(c)
+	}
+
+	if !export.IsSupported(format) {
+		logger.Error(c.Request().Context(), logger.Handler, "ExportStatistic", 2, export.ErrUnsupportedFormat)
+		return apperr.Validation(export.ErrUnsupportedFormat.Error())
+	}
+
+	headers := []string{"ID", "Name", "Total", "Icon"}
+	return streamExport(c, "statistics", format, headers, func(w export.Writer) error {
+		return s.statisticService.StreamStatistic(c.Request().Context(), func(val entity.StatisticEntity) error {
+			return w.WriteRow([]interface{}{val.ID, val.Name, val.Total, val.Icon})
+		})
+	})
+}
+
 // FetchByIDStatistic implements StatisticHandlerInterface.
 func (s *statisticHandler) FetchByIDStatistic(c echo.Context) error {
 	var (
-		resp      = response.DefaultSuccessResponse{}
-		respError = response.ErrorResponseDefault{}
-		ctx       = c.Request().Context()
-		respStat  = response.StatisticResponse{}
+		resp     = response.DefaultSuccessResponse{}
+		ctx      = c.Request().Context()
+		respStat = response.StatisticResponse{}
 	)
 
 	user := conv.GetUserIDByContext(c)
 	if user == 0 {
-		log.Errorf("[HANDLER] FetchByIDStatistic - 1: Unauthorized")
-		respError.Meta.Message = "Unauthorized"
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnauthorized, respError)
+		logger.Errorf(ctx, logger.Handler, "FetchByIDStatistic", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
 	}
 
 	idStat := c.Param("id")
 	id, err := conv.StringToInt64(idStat)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDStatistic - 2: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDStatistic", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
 	}
 
 	result, err := s.statisticService.FetchByIDStatistic(ctx, id)
 	if err != nil {
-		log.Errorf("[HANDLER] FetchByIDStatistic - 3: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		logger.Error(ctx, logger.Handler, "FetchByIDStatistic", 3, err)
+		return err
 	}
 
 	respStat.ID = result.ID
@@ -257,7 +252,7 @@ func (s *statisticHandler) FetchByIDStatistic(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewStatisticHandler(c *echo.Echo, cfg *config.Config, statisticService service.StatisticServiceInterface) StatisticHandlerInterface {
+func NewStatisticHandler(c *echo.Group, cfg *config.Config, statisticService service.StatisticServiceInterface) StatisticHandlerInterface {
 	statHandler := &statisticHandler{
 		statisticService: statisticService,
 	}
@@ -269,6 +264,7 @@ func NewStatisticHandler(c *echo.Echo, cfg *config.Config, statisticService serv
 	statApp.GET("", statHandler.FetchAllStatistic)
 
 	adminApp := statApp.Group("/admin", mid.CheckToken())
+	adminApp.GET("", statHandler.ExportStatistic)
 	adminApp.GET("/:id", statHandler.FetchByIDStatistic)
 	adminApp.POST("", statHandler.CreateStatistic)
 	adminApp.PUT("/:id", statHandler.EditByIDStatistic)
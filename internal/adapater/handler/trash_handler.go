@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler/request"
+	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/middleware"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TrashHandlerInterface interface {
+	FetchAllTrash(c echo.Context) error
+	RestoreTrash(c echo.Context) error
+	PurgeTrash(c echo.Context) error
+	EmptyTrash(c echo.Context) error
+}
+
+type trashHandler struct {
+	trashService service.TrashServiceInterface
+}
+
+// FetchAllTrash implements TrashHandlerInterface.
+func (h *trashHandler) FetchAllTrash(c echo.Context) error {
+	var (
+		req       = request.TrashFilterRequest{}
+		resp      = response.DefaultSuccessResponse{}
+		ctx       = c.Request().Context()
+		respTrash = []response.TrashResponse{}
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "FetchAllTrash", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	if err := c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllTrash", 2, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	results, err := h.trashService.FetchAllTrash(ctx, req.Resource)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "FetchAllTrash", 3, err)
+		return err
+	}
+
+	for _, val := range results {
+		respTrash = append(respTrash, response.TrashResponse{
+			Resource:  val.Resource,
+			ID:        val.ID,
+			Label:     val.Label,
+			DeletedAt: val.DeletedAt.Format("02 Jan 2006 15:04:05"),
+		})
+	}
+
+	resp.Meta.Message = "Success fetch all trash"
+	resp.Meta.Status = true
+	resp.Data = respTrash
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RestoreTrash implements TrashHandlerInterface.
+// Restoring a parent also restores the children deleted together with it.
+func (h *trashHandler) RestoreTrash(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "RestoreTrash", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "RestoreTrash", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	err = h.trashService.RestoreTrash(ctx, c.Param("resource"), id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "RestoreTrash", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success restore trash"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// PurgeTrash implements TrashHandlerInterface.
+func (h *trashHandler) PurgeTrash(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "PurgeTrash", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	id, err := conv.StringToInt64(c.Param("id"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "PurgeTrash", 2, err)
+		return apperr.ErrInvalidID.Wrap(err)
+	}
+
+	err = h.trashService.PurgeTrash(ctx, c.Param("resource"), id)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "PurgeTrash", 3, err)
+		return err
+	}
+
+	resp.Meta.Message = "Success purge trash"
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+// EmptyTrash implements TrashHandlerInterface.
+func (h *trashHandler) EmptyTrash(c echo.Context) error {
+	var (
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	user := conv.GetUserIDByContext(c)
+	if user == 0 {
+		logger.Errorf(ctx, logger.Handler, "EmptyTrash", 1, "Unauthorized")
+		return apperr.ErrUnauthorized
+	}
+
+	count, err := h.trashService.EmptyTrash(ctx, c.Param("resource"))
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "EmptyTrash", 2, err)
+		return err
+	}
+
+	resp.Meta.Message = fmt.Sprintf("Success purge %d records from trash", count)
+	resp.Meta.Status = true
+	resp.Data = nil
+	resp.Pagination = nil
+	return c.JSON(http.StatusOK, resp)
+}
+
+func NewTrashHandler(e *echo.Group, trashService service.TrashServiceInterface, cfg *config.Config) TrashHandlerInterface {
+	h := &trashHandler{
+		trashService: trashService,
+	}
+
+	mid := middleware.NewMiddleware(cfg)
+
+	adminApp := e.Group("/trash/admin", mid.CheckToken())
+	adminApp.GET("", h.FetchAllTrash)
+	adminApp.PUT("/:resource/:id/restore", h.RestoreTrash)
+	adminApp.DELETE("/:resource/:id", h.PurgeTrash)
+	adminApp.DELETE("/:resource", h.EmptyTrash)
+
+	return h
+}
@@ -2,97 +2,387 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"desadangdang/config"
+	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
 	"desadangdang/internal/adapater/storage"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/imageproc"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/metrics"
 	"desadangdang/utils/middleware"
+	"desadangdang/utils/upload"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
 )
 
+const uploadDir = "public/uploads"
+
 type UploadImageInterface interface {
 	UploadImage(c echo.Context) error
+	CreateSignedUpload(c echo.Context) error
+	CompleteSignedUpload(c echo.Context) error
+	LocalSignedUpload(c echo.Context) error
 }
 
 type uploadImage struct {
-	storageService storage.SupabaseInterface
+	storageService storage.StorageInterface
+	mediaService   service.MediaServiceInterface
+	policy         upload.Policy
+	secret         string
 }
 
 // UploadImage implements UploadImageInterface.
+// The purpose form field picks the allowlist: image (default), pdf, docx or video.
 func (u *uploadImage) UploadImage(c echo.Context) error {
 	var (
-		respError = response.ErrorResponseDefault{}
-		resp      = response.DefaultSuccessResponse{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
 	)
+
+	// The form is parsed up front so a body over the limit fails as too
+	// large, FormValue would swallow the error.
+	if _, err := c.MultipartForm(); errors.Is(err, echo.ErrStatusRequestEntityTooLarge) {
+		logger.Error(ctx, logger.Handler, "UploadImage", 1, err)
+		return err
+	}
+
+	purpose := c.FormValue("purpose")
+	if purpose == "" {
+		purpose = upload.PurposeImage
+	}
+	if !upload.IsPurpose(purpose) {
+		logger.Errorf(ctx, logger.Handler, "UploadImage", 2, "unknown upload purpose %q", purpose)
+		return apperr.Validation(upload.ErrUnknownPurpose.Error())
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
-		log.Errorf("Error getting file: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(400, respError)
+		logger.Error(ctx, logger.Handler, "UploadImage", 3, err)
+		return apperr.Validation("file is required").Wrap(err)
 	}
 
 	src, err := file.Open()
 	if err != nil {
-		log.Errorf("Error opening file: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(400, respError)
+		logger.Error(ctx, logger.Handler, "UploadImage", 4, err)
+		return u.uploadError(purpose, err)
 	}
 
 	defer src.Close()
 
-	fileBuffer := new(bytes.Buffer)
-	_, err = io.Copy(fileBuffer, src)
+	validFile, err := u.policy.Validate(purpose, file.Size, src)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "UploadImage", 5, err)
+		return u.uploadError(purpose, err)
+	}
+
+	fileID := fmt.Sprintf("%s_%d", uuid.New().String(), time.Now().Unix())
+
+	media := entity.MediaEntity{
+		UserID:       conv.GetUserIDByContext(c),
+		FileKey:      fileID,
+		Purpose:      purpose,
+		ContentType:  validFile.ContentType,
+		AltText:      strings.TrimSpace(c.FormValue("alt_text")),
+		OriginalName: file.Filename,
+	}
+
+	var result *response.UploadResponse
+	if purpose != upload.PurposeImage {
+		// Large files are streamed from the multipart temp file, the storage
+		// backend switches to a multipart upload on its own when needed.
+		// The extension follows the detected type, never the client filename.
+		media.Path = fmt.Sprintf("%s/%s%s", uploadDir, fileID, validFile.Extension)
+		media.Size = file.Size
+		media.URL, err = u.storageService.Put(ctx, media.Path, validFile.Reader, storage.PutOptions{
+			ContentType: validFile.ContentType,
+			Size:        media.Size,
+		})
+		if err != nil {
+			logger.Error(ctx, logger.Handler, "UploadImage", 6, err)
+			return u.uploadError(purpose, err)
+		}
+
+		result = &response.UploadResponse{URL: media.URL, ContentType: validFile.ContentType}
+	} else {
+		// Images are small enough to hold in memory while the variants are generated.
+		data, err := io.ReadAll(validFile.Reader)
+		if err != nil {
+			logger.Error(ctx, logger.Handler, "UploadImage", 7, err)
+			return u.uploadError(purpose, err)
+		}
+
+		result, err = u.uploadImageVariants(ctx, &media, validFile, data)
+		if err != nil {
+			logger.Error(ctx, logger.Handler, "UploadImage", 8, err)
+			return u.uploadError(purpose, err)
+		}
+	}
+
+	result.ID, err = u.mediaService.CreateMedia(ctx, media)
+	if err != nil {
+		logger.Error(ctx, logger.Handler, "UploadImage", 9, err)
+		return err
+	}
+	metrics.UploadStored(purpose, file.Size)
+
+	resp.Data = result
+	resp.Meta.Status = true
+	resp.Meta.Message = "Success upload " + purpose
+	resp.Pagination = nil
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// CreateSignedUpload implements UploadImageInterface.
+// The client sends the file straight to storage with the returned request,
+// then calls CompleteSignedUpload with the token.
+func (u *uploadImage) CreateSignedUpload(c echo.Context) error {
+	var (
+		req  = request.SignedUploadRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	if err = c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "CreateSignedUpload", 1, err)
+		return apperr.ErrInvalidBody.Wrap(err)
+	}
+
+	if err = c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "CreateSignedUpload", 2, err)
+		return apperr.Validation(err.Error())
+	}
+
+	result, err := u.mediaService.CreateMediaUpload(ctx, entity.MediaUploadEntity{
+		Purpose:      req.Purpose,
+		ContentType:  req.ContentType,
+		Size:         req.Size,
+		OriginalName: req.FileName,
+	})
 	if err != nil {
-		log.Errorf("Error copying file: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(400, respError)
+		logger.Error(ctx, logger.Handler, "CreateSignedUpload", 3, err)
+		return u.uploadError(req.Purpose, err)
+	}
+
+	resp.Meta.Status = true
+	resp.Meta.Message = "Success create signed upload"
+	resp.Data = response.SignedUploadResponse{
+		Token:     result.Token,
+		Path:      result.Path,
+		UploadURL: result.URL,
+		Method:    result.Method,
+		Headers:   result.Headers,
+		ExpiresAt: result.ExpiresAt.Format(time.RFC3339),
+	}
+	resp.Pagination = nil
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// CompleteSignedUpload implements UploadImageInterface.
+func (u *uploadImage) CompleteSignedUpload(c echo.Context) error {
+	var (
+		req  = request.CompleteUploadRequest{}
+		resp = response.DefaultSuccessResponse{}
+		ctx  = c.Request().Context()
+	)
+
+	if err = c.Bind(&req); err != nil {
+		logger.Error(ctx, logger.Handler, "CompleteSignedUpload", 1, err)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
-	newFileName := fmt.Sprintf("%s_%d%s", uuid.New().String(), time.Now().Unix(), getExtension(file.Filename))
+	if err = c.Validate(req); err != nil {
+		logger.Error(ctx, logger.Handler, "CompleteSignedUpload", 2, err)
+		return apperr.Validation(err.Error())
+	}
 
-	uploadPath := fmt.Sprintf("public/uploads/%s", newFileName)
-	url, err := u.storageService.UploadFile(uploadPath, fileBuffer)
+	result, err := u.mediaService.CompleteMediaUpload(ctx, req.Token, conv.GetUserIDByContext(c), strings.TrimSpace(req.AltText))
 	if err != nil {
-		log.Errorf("Error uploading file: %v", err)
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(400, respError)
+		logger.Error(ctx, logger.Handler, "CompleteSignedUpload", 3, err)
+		return u.uploadError("", err)
 	}
 
 	resp.Meta.Status = true
-	resp.Meta.Message = "Success upload image"
-	resp.Data = map[string]string{
-		"url": url,
+	resp.Meta.Message = "Success complete upload"
+	resp.Data = response.UploadResponse{
+		ID:          result.ID,
+		URL:         result.URL,
+		ContentType: result.ContentType,
 	}
 	resp.Pagination = nil
 	return c.JSON(http.StatusCreated, resp)
 }
 
-func getExtension(fileName string) string {
-	ext := "." + fileName[len(fileName)-3:] // Ambil 3 karakter terakhir untuk ekstensi
-	if len(fileName) > 4 && fileName[len(fileName)-4] == '.' {
-		ext = "." + fileName[len(fileName)-4:]
+// LocalSignedUpload implements UploadImageInterface.
+// It stands in for the storage provider when STORAGE_DRIVER=local, the body
+// is streamed to disk and capped at the signed size.
+func (u *uploadImage) LocalSignedUpload(c echo.Context) error {
+	var (
+		path  = c.Param("*")
+		query = c.QueryParams()
+	)
+
+	maxSize, err := storage.VerifyLocalUpload(u.secret, path, query)
+	if err != nil {
+		logger.Error(c.Request().Context(), logger.Handler, "LocalSignedUpload", 1, err)
+		return apperr.Forbidden(err.Error()).Wrap(err)
+	}
+
+	contentType := query.Get("content_type")
+	if c.Request().Header.Get(echo.HeaderContentType) != contentType {
+		logger.Errorf(c.Request().Context(), logger.Handler, "LocalSignedUpload", 2, "content type does not match the signature")
+		return apperr.Forbidden(storage.ErrInvalidSignature.Error())
+	}
+	if c.Request().ContentLength > maxSize {
+		logger.Error(c.Request().Context(), logger.Handler, "LocalSignedUpload", 3, upload.ErrFileTooLarge)
+		return apperr.New(apperr.KindTooLarge, apperr.CodeTooLarge, upload.ErrFileTooLarge.Error())
+	}
+
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxSize)
+	_, err = u.storageService.Put(c.Request().Context(), path, body, storage.PutOptions{
+		ContentType: contentType,
+		Size:        c.Request().ContentLength,
+	})
+	if err != nil {
+		logger.Error(c.Request().Context(), logger.Handler, "LocalSignedUpload", 4, err)
+		return u.uploadError("", err)
+	}
+
+	return c.NoContent(http.StatusCreated)
+}
+
+// uploadError turns an upload or storage error into a domain error, the size
+// limit and the allowlist of the purpose are added to the message when known.
+func (u *uploadImage) uploadError(purpose string, err error) error {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, upload.ErrFileTooLarge):
+		message := err.Error()
+		if purpose != "" {
+			message = fmt.Sprintf("%s, max %d MB", message, u.policy.MaxSizeMB(purpose))
+		}
+		return apperr.New(apperr.KindTooLarge, apperr.CodeTooLarge, message).Wrap(err)
+	case errors.Is(err, imageproc.ErrTooManyPixels):
+		message := fmt.Sprintf("%s, max %d megapixels", err.Error(), imageproc.MaxPixels/1_000_000)
+		return apperr.New(apperr.KindTooLarge, apperr.CodeTooLarge, message).Wrap(err)
+	case errors.As(err, &maxBytesErr):
+		return apperr.New(apperr.KindTooLarge, apperr.CodeTooLarge, upload.ErrFileTooLarge.Error()).Wrap(err)
+	case errors.Is(err, upload.ErrUnsupportedType):
+		message := err.Error()
+		if purpose != "" {
+			message = fmt.Sprintf("%s, allowed: %s", message, strings.Join(upload.Allowed(purpose), ", "))
+		}
+		return apperr.New(apperr.KindUnsupportedType, apperr.CodeUnsupportedType, message).Wrap(err)
+	case errors.Is(err, upload.ErrUnknownPurpose), errors.Is(err, upload.ErrEmptyFile), errors.Is(err, upload.ErrImageNotDirect),
+		errors.Is(err, storage.ErrInvalidPath), errors.Is(err, storage.ErrObjectNotFound):
+		return apperr.Validation(err.Error()).Wrap(err)
+	case errors.Is(err, upload.ErrInvalidTicket), errors.Is(err, upload.ErrTicketExpired):
+		return apperr.Forbidden(err.Error()).Wrap(err)
+	}
+	return err
+}
+
+// uploadImageVariants stores the cleaned original next to its resized variants.
+// The media entity receives the stored path, size and dimensions.
+func (u *uploadImage) uploadImageVariants(ctx context.Context, media *entity.MediaEntity, file *upload.File, data []byte) (*response.UploadResponse, error) {
+	var err error
+	media.Width, media.Height, err = imageproc.Dimensions(data)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := imageproc.Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := imageproc.Original(data, file.ContentType)
+	if err != nil {
+		return nil, err
 	}
-	return ext
+
+	media.Path = imageproc.OriginalPath(uploadDir, media.FileKey, file.Extension, media.Width)
+	media.Size = int64(len(original))
+	media.URL, err = u.storageService.Put(ctx, media.Path, bytes.NewReader(original), storage.PutOptions{
+		ContentType: file.ContentType,
+		Size:        media.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.UploadResponse{
+		URL:         media.URL,
+		ContentType: file.ContentType,
+		Variants:    map[string]response.ImageVariantResponse{},
+	}
+	for _, v := range variants {
+		variantURL, err := u.storageService.Put(ctx, imageproc.VariantPath(uploadDir, media.FileKey, v), bytes.NewReader(v.Data), storage.PutOptions{
+			ContentType: v.ContentType,
+			Size:        int64(len(v.Data)),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		item := result.Variants[v.Name]
+		item.Width = v.Width
+		item.Height = v.Height
+		if v.Format == imageproc.FormatWebP {
+			item.WebP = variantURL
+		} else {
+			item.JPEG = variantURL
+		}
+		result.Variants[v.Name] = item
+	}
+
+	return result, nil
 }
 
-func NewUploadImage(e *echo.Echo, storageService storage.SupabaseInterface, cfg *config.Config) UploadImageInterface {
+func NewUploadImage(e *echo.Group, storageService storage.StorageInterface, mediaService service.MediaServiceInterface, cfg *config.Config) UploadImageInterface {
 	res := &uploadImage{
 		storageService: storageService,
+		mediaService:   mediaService,
+		policy:         upload.PolicyFromConfig(cfg),
+		secret:         cfg.UploadSigningKey(),
 	}
 
 	mid := middleware.NewMiddleware(cfg)
+	// The form is parsed before the purpose and its limit are known, so the
+	// body is capped at the highest limit while it is read.
+	bodyLimit := echoMiddleware.BodyLimit(fmt.Sprintf("%dB", res.policy.BodyLimit()))
+
+	e.POST("/upload-image", res.UploadImage, mid.CheckToken(), bodyLimit)
+	e.POST("/upload-file", res.UploadImage, mid.CheckToken(), bodyLimit)
+	e.POST("/upload-file/sign", res.CreateSignedUpload, mid.CheckToken())
+	e.POST("/upload-file/complete", res.CompleteSignedUpload, mid.CheckToken())
+
+	return res
+}
+
+// NewLocalUpload registers the route receiving the signed uploads of the local
+// driver. The driver has no provider to upload to, so the route stands in for
+// one: it isn't versioned and the signature replaces the token.
+func NewLocalUpload(e *echo.Echo, storageService storage.StorageInterface, cfg *config.Config) UploadImageInterface {
+	res := &uploadImage{
+		storageService: storageService,
+		policy:         upload.PolicyFromConfig(cfg),
+		secret:         cfg.UploadSigningKey(),
+	}
 
-	e.POST("/upload-image", res.UploadImage, mid.CheckToken())
+	e.PUT(storage.LocalUploadRoute+"/*", res.LocalSignedUpload)
 
 	return res
 }
@@ -3,13 +3,12 @@ package handler
 import (
 	"desadangdang/internal/adapater/handler/request"
 	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/service"
-	"desadangdang/utils/conv"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 )
 
 type UserHandler interface {
@@ -31,22 +30,17 @@ func (u *userHandler) LoginAdmin(c echo.Context) error {
 		req       = request.LoginRequest{}
 		resp      = response.DefaultSuccessResponse{}
 		respLogin = response.LoginResponse{}
-		respError = response.ErrorResponseDefault{}
 		ctx       = c.Request().Context()
 	)
 
 	if err = c.Bind(&req); err != nil {
 		code = "[HANDLER] LoginAdmin - 1"
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusUnprocessableEntity, respError)
+		return apperr.ErrInvalidBody.Wrap(err)
 	}
 
 	if err = c.Validate(req); err != nil {
 		code = "[HANDLER] LoginAdmin - 2"
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(http.StatusBadRequest, respError)
+		return apperr.Validation(err.Error())
 	}
 
 	reqEntity := entity.UserEntity{
@@ -56,9 +50,7 @@ func (u *userHandler) LoginAdmin(c echo.Context) error {
 	token, err := u.userService.LoginAdmin(ctx, reqEntity)
 	if err != nil {
 		code = "[HANDLER] LoginAdmin - 3"
-		respError.Meta.Message = err.Error()
-		respError.Meta.Status = false
-		return c.JSON(conv.SetHTTPStatusCode(err), respError)
+		return err
 	}
 
 	respLogin.Token = token
@@ -69,12 +61,11 @@ func (u *userHandler) LoginAdmin(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-func NewUserHandler(e *echo.Echo, userService service.UserServiceInterface) UserHandler {
+func NewUserHandler(e *echo.Group, userService service.UserServiceInterface) UserHandler {
 	userHandler := &userHandler{
 		userService: userService,
 	}
 
-	e.Use(middleware.Recover())
 	e.POST("/login", userHandler.LoginAdmin)
 
 	return userHandler
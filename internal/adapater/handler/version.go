@@ -0,0 +1,11 @@
+package handler
+
+import "time"
+
+// APIV1Prefix is where the app mounts the routes of the New*Handler
+// functions, they register their paths relative to it.
+const APIV1Prefix = "/api/v1"
+
+// LegacyDeprecatedAt is when the paths from before APIV1Prefix, served at
+// the root, were deprecated.
+var LegacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
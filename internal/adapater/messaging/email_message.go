@@ -1,58 +1,70 @@
 package messaging
 
 import (
-	"crypto/tls"
+	"context"
 	"desadangdang/config"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/metrics"
+	"desadangdang/utils/tracing"
 
 	"github.com/go-mail/mail"
-	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type EmailMessagingInterface interface {
-	SendEmailAppointment(attach *string, from, subject, body string) error
+	// SendEmail sends a multipart/alternative message with a plain text and an html part.
+	SendEmail(ctx context.Context, from, to, subject, textBody, htmlBody string) error
+	// Ping checks that the transport can deliver, see Transport.
+	Ping(ctx context.Context) error
 }
 
 type emailAttributes struct {
-	username string
-	password string
-	host     string
-	port     int
-	isTLS    bool
-	receiver string
+	transport     Transport
+	transportName string
 }
 
-// SendEmailAppointment implements EmailMessagingInterface.
-func (e *emailAttributes) SendEmailAppointment(attach *string, from, subject string, body string) error {
+// SendEmail implements EmailMessagingInterface.
+func (e *emailAttributes) SendEmail(ctx context.Context, from, to, subject, textBody, htmlBody string) (err error) {
+	_, span := tracing.Start(ctx, "email.send", trace.SpanKindClient, attribute.String("email.transport", e.transportName))
+	defer func() { tracing.End(span, err) }()
+
 	m := mail.NewMessage()
 	m.SetHeader("From", from)
-	m.SetHeader("To", e.receiver)
+	m.SetHeader("To", to)
 
 	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", body)
-
-	if attach != nil {
-		m.Attach(*attach)
+	if textBody != "" {
+		m.SetBody("text/plain", textBody)
+		if htmlBody != "" {
+			m.AddAlternative("text/html", htmlBody)
+		}
+	} else {
+		m.SetBody("text/html", htmlBody)
 	}
 
-	d := mail.NewDialer(e.host, e.port, e.username, e.password)
-	d.TLSConfig = &tls.Config{
-		InsecureSkipVerify: true,
-	}
-
-	if err := d.DialAndSend(m); err != nil {
-		log.Errorf("error sending mail: %v", err)
+	err = e.transport.Send(m)
+	metrics.EmailSent(err)
+	if err != nil {
+		logger.Error(ctx, logger.Messaging, "SendEmail", 1, err)
 		return err
 	}
 	return nil
 }
 
-func NewEmailMessaging(cfg *config.Config) EmailMessagingInterface {
-	return &emailAttributes{
-		username: cfg.Email.Username,
-		password: cfg.Email.Password,
-		host:     cfg.Email.Host,
-		port:     cfg.Email.Port,
-		isTLS:    cfg.Email.IsTLS,
-		receiver: cfg.Email.Reciever,
+// Ping implements EmailMessagingInterface.
+func (e *emailAttributes) Ping(ctx context.Context) error {
+	return e.transport.Ping(ctx)
+}
+
+func NewEmailMessaging(cfg *config.Config) (EmailMessagingInterface, error) {
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
 	}
+
+	return &emailAttributes{
+		transport:     transport,
+		transportName: cfg.Email.Transport,
+	}, nil
 }
@@ -0,0 +1,110 @@
+package mailtemplate
+
+import "time"
+
+const (
+	AppointmentReceived     = "appointment_received"
+	AppointmentConfirmation = "appointment_confirmation"
+	AppointmentReminder     = "appointment_reminder"
+	PasswordReset           = "password_reset"
+	ContactReply            = "contact_reply"
+)
+
+// AppointmentReceivedData notifies the village office about a new appointment.
+type AppointmentReceivedData struct {
+	Name        string
+	Email       string
+	PhoneNumber string
+	ServiceName string
+	Brief       string
+	Budget      float64
+	MeetAt      time.Time
+}
+
+func (AppointmentReceivedData) TemplateName() string { return AppointmentReceived }
+func (d AppointmentReceivedData) Subject() string {
+	return "Permintaan janji temu baru dari " + d.Name
+}
+
+// AppointmentConfirmationData is sent to the resident after booking.
+type AppointmentConfirmationData struct {
+	Name        string
+	PhoneNumber string
+	ServiceName string
+	MeetAt      time.Time
+}
+
+func (AppointmentConfirmationData) TemplateName() string { return AppointmentConfirmation }
+func (AppointmentConfirmationData) Subject() string {
+	return "Permintaan janji temu Anda telah diterima"
+}
+
+type AppointmentReminderData struct {
+	Name        string
+	ServiceName string
+	MeetAt      time.Time
+}
+
+func (AppointmentReminderData) TemplateName() string { return AppointmentReminder }
+func (AppointmentReminderData) Subject() string {
+	return "Pengingat janji temu"
+}
+
+type PasswordResetData struct {
+	Name      string
+	ResetURL  string
+	ExpiresAt time.Time
+}
+
+func (PasswordResetData) TemplateName() string { return PasswordReset }
+func (PasswordResetData) Subject() string {
+	return "Atur ulang kata sandi"
+}
+
+// ContactReplyData is an admin reply to a message sent by a resident.
+type ContactReplyData struct {
+	Name            string
+	Topic           string
+	OriginalMessage string
+	Reply           string
+}
+
+func (ContactReplyData) TemplateName() string { return ContactReply }
+func (d ContactReplyData) Subject() string {
+	return "Re: " + d.Topic
+}
+
+// samples are used for admin previews, every template must have one.
+var samples = map[string]Data{
+	AppointmentReceived: AppointmentReceivedData{
+		Name:        "Budi Santoso",
+		Email:       "budi@example.com",
+		PhoneNumber: "081234567890",
+		ServiceName: "Pembuatan KTP",
+		Brief:       "Ingin membuat KTP baru karena pindah domisili.",
+		Budget:      150000,
+		MeetAt:      time.Date(2025, time.January, 15, 9, 0, 0, 0, time.Local),
+	},
+	AppointmentConfirmation: AppointmentConfirmationData{
+		Name:        "Budi Santoso",
+		PhoneNumber: "081234567890",
+		ServiceName: "Pembuatan KTP",
+		MeetAt:      time.Date(2025, time.January, 15, 9, 0, 0, 0, time.Local),
+	},
+	AppointmentReminder: AppointmentReminderData{
+		Name:        "Budi Santoso",
+		ServiceName: "Pembuatan KTP",
+		MeetAt:      time.Date(2025, time.January, 15, 9, 0, 0, 0, time.Local),
+	},
+	PasswordReset: PasswordResetData{
+		Name:      "Admin",
+		ResetURL:  "https://example.com/reset-password?token=sample",
+		ExpiresAt: time.Date(2025, time.January, 15, 10, 0, 0, 0, time.Local),
+	},
+	ContactReply: ContactReplyData{
+		Name:            "Siti Aminah",
+		Topic:           "Jadwal posyandu",
+		OriginalMessage: "Kapan jadwal posyandu bulan depan?",
+		Reply:           "Posyandu bulan depan dilaksanakan setiap Sabtu minggu kedua di balai desa.",
+	},
+}
@@ -0,0 +1,157 @@
+package mailtemplate
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+var ErrTemplateNotFound = errors.New("email template not found")
+
+// Data is implemented by every typed template payload.
+type Data interface {
+	TemplateName() string
+	Subject() string
+}
+
+type Brand struct {
+	Name    string
+	Address string
+}
+
+// Message is a rendered email ready to be sent as multipart/alternative.
+type Message struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type RendererInterface interface {
+	Render(data Data) (*Message, error)
+	Preview(name string) (*Message, error)
+	Names() []string
+}
+
+type renderer struct {
+	brand Brand
+	html  map[string]*htmltemplate.Template
+	text  map[string]*texttemplate.Template
+}
+
+type layoutData struct {
+	Subject string
+	Brand   Brand
+	Data    Data
+}
+
+var funcs = map[string]interface{}{
+	"formatDate":   formatDate,
+	"formatBudget": formatBudget,
+}
+
+// Render implements RendererInterface.
+func (r *renderer) Render(data Data) (*Message, error) {
+	name := data.TemplateName()
+	htmlTpl, ok := r.html[name]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	textTpl, ok := r.text[name]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+
+	payload := layoutData{Subject: data.Subject(), Brand: r.brand, Data: data}
+
+	var htmlBuf, textBuf bytes.Buffer
+	if err := htmlTpl.ExecuteTemplate(&htmlBuf, "layout", payload); err != nil {
+		return nil, err
+	}
+	if err := textTpl.ExecuteTemplate(&textBuf, "layout", payload); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Subject: data.Subject(),
+		HTML:    htmlBuf.String(),
+		Text:    strings.TrimSpace(textBuf.String()) + "\n",
+	}, nil
+}
+
+// Preview implements RendererInterface.
+// It renders the named template with sample data.
+func (r *renderer) Preview(name string) (*Message, error) {
+	sample, ok := samples[name]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	return r.Render(sample)
+}
+
+// Names implements RendererInterface.
+func (r *renderer) Names() []string {
+	names := make([]string, 0, len(r.html))
+	for name := range r.html {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("02 Jan 2006 15:04")
+}
+
+// formatBudget formats a rupiah amount, ex: 1500000 -> Rp 1.500.000
+func formatBudget(amount float64) string {
+	digits := strconv.FormatInt(int64(amount), 10)
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	return "Rp " + b.String()
+}
+
+func NewRenderer(brand Brand) (RendererInterface, error) {
+	if brand.Name == "" {
+		brand.Name = "Desa Dangdang"
+	}
+
+	r := &renderer{
+		brand: brand,
+		html:  map[string]*htmltemplate.Template{},
+		text:  map[string]*texttemplate.Template{},
+	}
+
+	for name := range samples {
+		htmlTpl, err := htmltemplate.New(name).Funcs(funcs).ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html")
+		if err != nil {
+			return nil, err
+		}
+		textTpl, err := texttemplate.New(name).Funcs(funcs).ParseFS(templateFS, "templates/layout.txt", "templates/"+name+".txt")
+		if err != nil {
+			return nil, err
+		}
+
+		r.html[name] = htmlTpl
+		r.text[name] = textTpl
+	}
+
+	return r, nil
+}
@@ -0,0 +1,155 @@
+package messaging
+
+import (
+	"context"
+	"crypto/tls"
+	"desadangdang/config"
+	"desadangdang/utils/logger"
+	"fmt"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-mail/mail"
+	"github.com/google/uuid"
+)
+
+const (
+	TransportSMTP = "smtp"
+	TransportFile = "file"
+	TransportLog  = "log"
+)
+
+// Transport delivers an already built message.
+type Transport interface {
+	Send(m *mail.Message) error
+	// Ping checks that messages can be delivered without sending one
+	Ping(ctx context.Context) error
+}
+
+type smtpTransport struct {
+	dialer *mail.Dialer
+}
+
+// Send implements Transport.
+func (s *smtpTransport) Send(m *mail.Message) error {
+	return s.dialer.DialAndSend(m)
+}
+
+// Ping implements Transport.
+// It dials the server and waits for its greeting, nothing is sent.
+func (s *smtpTransport) Ping(ctx context.Context) error {
+	addr := net.JoinHostPort(s.dialer.Host, strconv.Itoa(s.dialer.Port))
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.dialer.SSL {
+		conn, err = (&tls.Dialer{Config: s.dialer.TLSConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	text := textproto.NewConn(conn)
+	defer text.Close()
+	if _, _, err = text.ReadResponse(220); err != nil {
+		return err
+	}
+	return text.PrintfLine("QUIT")
+}
+
+// newSMTPTransport verifies the server certificate. With EMAIL_IS_TLS the
+// connection must be encrypted: implicit TLS on port 465, STARTTLS otherwise.
+// Without it STARTTLS is still used when the server offers it.
+func newSMTPTransport(cfg *config.Config) *smtpTransport {
+	d := mail.NewDialer(cfg.Email.Host, cfg.Email.Port, cfg.Email.Username, cfg.Email.Password)
+	d.TLSConfig = &tls.Config{
+		ServerName: cfg.Email.Host,
+		MinVersion: tls.VersionTLS12,
+	}
+	d.SSL = cfg.Email.IsTLS && cfg.Email.Port == 465
+	d.StartTLSPolicy = mail.OpportunisticStartTLS
+	if cfg.Email.IsTLS {
+		d.StartTLSPolicy = mail.MandatoryStartTLS
+	}
+
+	return &smtpTransport{dialer: d}
+}
+
+type fileTransport struct {
+	dir string
+}
+
+// Send implements Transport.
+// Every message is written as an .eml file that can be opened by any mail client.
+func (f *fileTransport) Send(m *mail.Message) error {
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405"), uuid.New().String())
+	file, err := os.Create(filepath.Join(f.dir, name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = m.WriteTo(file)
+	return err
+}
+
+// Ping implements Transport.
+func (f *fileTransport) Ping(ctx context.Context) error {
+	return os.MkdirAll(f.dir, 0o755)
+}
+
+type logTransport struct{}
+
+// Send implements Transport.
+func (l *logTransport) Send(m *mail.Message) error {
+	var body strings.Builder
+	if _, err := m.WriteTo(&body); err != nil {
+		return err
+	}
+
+	logger.Ctx(context.Background()).Info().
+		Str("layer", logger.Messaging).
+		Strs("from", m.GetHeader("From")).
+		Strs("to", m.GetHeader("To")).
+		Strs("subject", m.GetHeader("Subject")).
+		Msg(body.String())
+	return nil
+}
+
+// Ping implements Transport.
+func (l *logTransport) Ping(ctx context.Context) error {
+	return nil
+}
+
+func NewTransport(cfg *config.Config) (Transport, error) {
+	switch cfg.Email.Transport {
+	case "", TransportSMTP:
+		return newSMTPTransport(cfg), nil
+	case TransportFile:
+		dir := cfg.Email.FileDir
+		if dir == "" {
+			dir = "storage/mail"
+		}
+		return &fileTransport{dir: dir}, nil
+	case TransportLog:
+		return &logTransport{}, nil
+	default:
+		return nil, fmt.Errorf("unknown email transport %q", cfg.Email.Transport)
+	}
+}
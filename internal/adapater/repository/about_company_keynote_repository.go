@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -24,27 +24,33 @@ type aboutCompanyKeynoteRepository struct {
 
 // FetchByCompanyID implements AboutCompanyKeynoteInterface.
 func (h *aboutCompanyKeynoteRepository) FetchByCompanyID(ctx context.Context, companyId int64) ([]entity.AboutCompanyKeynoteEntity, error) {
-	rows, err := h.DB.Table("about_company_keynotes as ack").
-		Select("ack.id", "ack.keypoint", "ack.about_company_id", "ack.path_image", "ac.description").
+	rows, err := h.DB.WithContext(ctx).Table("about_company_keynotes as ack").
+		Select("ack.id", "ack.keypoint", "ack.about_company_id", "ack.path_image", "COALESCE(ack.image_alt, '')", "COALESCE(ack.image_caption, '')", "ac.description").
 		Joins("inner join about_companies as ac on ac.id = ack.about_company_id").
 		Where("ack.about_company_id = ? AND ack.deleted_at IS NULL", companyId).
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByCompanyID - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByCompanyID", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	var aboutCompanyKeynoteRepositoryEntities []entity.AboutCompanyKeynoteEntity
 	for rows.Next() {
 		aboutCompanyKeynote := entity.AboutCompanyKeynoteEntity{}
-		err = rows.Scan(&aboutCompanyKeynote.ID, &aboutCompanyKeynote.Keynote, &aboutCompanyKeynote.AboutCompanyID, &aboutCompanyKeynote.PathImage, &aboutCompanyKeynote.AboutCompanyDescription)
+		err = rows.Scan(&aboutCompanyKeynote.ID, &aboutCompanyKeynote.Keynote, &aboutCompanyKeynote.AboutCompanyID, &aboutCompanyKeynote.PathImage, &aboutCompanyKeynote.ImageAlt, &aboutCompanyKeynote.ImageCaption, &aboutCompanyKeynote.AboutCompanyDescription)
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchByCompanyID - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchByCompanyID", 2, err)
+			return nil, dbError(err)
 		}
 		aboutCompanyKeynoteRepositoryEntities = append(aboutCompanyKeynoteRepositoryEntities, aboutCompanyKeynote)
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByCompanyID", 3, err)
+		return nil, dbError(err)
+	}
+
 	return aboutCompanyKeynoteRepositoryEntities, nil
 }
 
@@ -54,11 +60,13 @@ func (h *aboutCompanyKeynoteRepository) CreateAboutCompanyKeynote(ctx context.Co
 		AboutCompanyID: req.AboutCompanyID,
 		Keypoint:       req.Keynote,
 		PathImage:      &req.PathImage,
+		ImageAlt:       nullString(req.ImageAlt),
+		ImageCaption:   nullString(req.ImageCaption),
 	}
 
-	if err = h.DB.Create(&modelAboutCompanyKeynote).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateAboutCompanyKeynote - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelAboutCompanyKeynote).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateAboutCompanyKeynote", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -67,14 +75,14 @@ func (h *aboutCompanyKeynoteRepository) CreateAboutCompanyKeynote(ctx context.Co
 func (h *aboutCompanyKeynoteRepository) DeleteByIDAboutCompanyKeynote(ctx context.Context, id int64) error {
 	modelAboutCompanyKeynote := model.AboutCompanyKeynote{}
 
-	if err = h.DB.Where("id = ?", id).First(&modelAboutCompanyKeynote).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDAboutCompanyKeynote - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelAboutCompanyKeynote).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDAboutCompanyKeynote", 1, err)
+		return dbError(err)
 	}
 
-	if err = h.DB.Delete(&modelAboutCompanyKeynote).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDAboutCompanyKeynote - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Delete(&modelAboutCompanyKeynote).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDAboutCompanyKeynote", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -83,67 +91,85 @@ func (h *aboutCompanyKeynoteRepository) DeleteByIDAboutCompanyKeynote(ctx contex
 func (h *aboutCompanyKeynoteRepository) EditByIDAboutCompanyKeynote(ctx context.Context, req entity.AboutCompanyKeynoteEntity) error {
 	modelAboutCompanyKeynote := model.AboutCompanyKeynote{}
 
-	if err = h.DB.Where("id =?", req.ID).First(&modelAboutCompanyKeynote).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDAboutCompanyKeynote - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelAboutCompanyKeynote).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDAboutCompanyKeynote", 1, err)
+		return dbError(err)
 	}
 	modelAboutCompanyKeynote.AboutCompanyID = req.AboutCompanyID
 	modelAboutCompanyKeynote.Keypoint = req.Keynote
 	modelAboutCompanyKeynote.PathImage = &req.PathImage
+	modelAboutCompanyKeynote.ImageAlt = nullString(req.ImageAlt)
+	modelAboutCompanyKeynote.ImageCaption = nullString(req.ImageCaption)
 
-	if err = h.DB.Save(&modelAboutCompanyKeynote).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDAboutCompanyKeynote - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Save(&modelAboutCompanyKeynote).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDAboutCompanyKeynote", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
 
 // FetchAllAboutCompanyKeynote implements AboutCompanyKeynoteInterface.
 func (h *aboutCompanyKeynoteRepository) FetchAllAboutCompanyKeynote(ctx context.Context) ([]entity.AboutCompanyKeynoteEntity, error) {
-	rows, err := h.DB.Table("about_company_keynotes as ack").
-		Select("ack.id", "ack.keypoint", "ack.about_company_id", "ack.path_image", "ac.description").
+	rows, err := h.DB.WithContext(ctx).Table("about_company_keynotes as ack").
+		Select("ack.id", "ack.keypoint", "ack.about_company_id", "ack.path_image", "COALESCE(ack.image_alt, '')", "COALESCE(ack.image_caption, '')", "ac.description").
 		Joins("inner join about_companies as ac on ac.id = ack.about_company_id").
 		Where("ack.deleted_at IS NULL").
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllAboutCompanyKeynote - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllAboutCompanyKeynote", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	var aboutCompanyKeynoteRepositoryEntities []entity.AboutCompanyKeynoteEntity
 	for rows.Next() {
 		aboutCompanyKeynote := entity.AboutCompanyKeynoteEntity{}
-		err = rows.Scan(&aboutCompanyKeynote.ID, &aboutCompanyKeynote.Keynote, &aboutCompanyKeynote.AboutCompanyID, &aboutCompanyKeynote.PathImage, &aboutCompanyKeynote.AboutCompanyDescription)
+		err = rows.Scan(&aboutCompanyKeynote.ID, &aboutCompanyKeynote.Keynote, &aboutCompanyKeynote.AboutCompanyID, &aboutCompanyKeynote.PathImage, &aboutCompanyKeynote.ImageAlt, &aboutCompanyKeynote.ImageCaption, &aboutCompanyKeynote.AboutCompanyDescription)
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchAllAboutCompanyKeynote - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchAllAboutCompanyKeynote", 2, err)
+			return nil, dbError(err)
 		}
 		aboutCompanyKeynoteRepositoryEntities = append(aboutCompanyKeynoteRepositoryEntities, aboutCompanyKeynote)
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllAboutCompanyKeynote", 3, err)
+		return nil, dbError(err)
+	}
+
 	return aboutCompanyKeynoteRepositoryEntities, nil
 }
 
 // FetchByIDAboutCompanyKeynote implements AboutCompanyKeynoteInterface.
 func (h *aboutCompanyKeynoteRepository) FetchByIDAboutCompanyKeynote(ctx context.Context, id int64) (*entity.AboutCompanyKeynoteEntity, error) {
-	rows, err := h.DB.Table("about_company_keynotes as ack").
-		Select("ack.id", "ack.keypoint", "ack.about_company_id", "ack.path_image", "ac.description").
+	rows, err := h.DB.WithContext(ctx).Table("about_company_keynotes as ack").
+		Select("ack.id", "ack.keypoint", "ack.about_company_id", "ack.path_image", "COALESCE(ack.image_alt, '')", "COALESCE(ack.image_caption, '')", "ac.description").
 		Joins("inner join about_companies as ac on ac.id = ack.about_company_id").
 		Where("ack.id = ? AND ack.deleted_at IS NULL", id).
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDAboutCompanyKeynote - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDAboutCompanyKeynote", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	respEntity := entity.AboutCompanyKeynoteEntity{}
 	for rows.Next() {
-		err = rows.Scan(&respEntity.ID, &respEntity.Keynote, &respEntity.AboutCompanyID, &respEntity.PathImage, &respEntity.AboutCompanyDescription)
+		err = rows.Scan(&respEntity.ID, &respEntity.Keynote, &respEntity.AboutCompanyID, &respEntity.PathImage, &respEntity.ImageAlt, &respEntity.ImageCaption, &respEntity.AboutCompanyDescription)
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchByIDAboutCompanyKeynote - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchByIDAboutCompanyKeynote", 2, err)
+			return nil, dbError(err)
 		}
 	}
+
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDAboutCompanyKeynote", 3, err)
+		return nil, dbError(err)
+	}
+	if respEntity.ID == 0 {
+		return nil, dbError(gorm.ErrRecordNotFound)
+	}
+
 	return &respEntity, nil
 }
 
@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -25,18 +25,18 @@ type aboutCompanyRepository struct {
 // FetchAllCompanyAndKeynote implements AboutCompanyInterface.
 func (h *aboutCompanyRepository) FetchAllCompanyAndKeynote(ctx context.Context) (*entity.AboutCompanyEntity, error) {
 	modelAboutCompany := model.AboutCompany{}
-	err = h.DB.Select("id", "description").Find(&modelAboutCompany).Limit(1).Order("created_at DESC").Error
+	err := h.DB.WithContext(ctx).Select("id", "description").Find(&modelAboutCompany).Limit(1).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllCompanyAndKeynote - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllCompanyAndKeynote", 1, err)
+		return nil, dbError(err)
 	}
 
 	var aboutCompanyRepositoryEntities entity.AboutCompanyEntity
 	var aboutCompanyKeynoteModel []model.AboutCompanyKeynote
-	err = h.DB.Select("id", "keypoint", "path_image", "about_company_id").Where("about_company_id = ?", modelAboutCompany.ID).Find(&aboutCompanyKeynoteModel).Error
+	err = h.DB.WithContext(ctx).Select("id", "keypoint", "path_image", "image_alt", "image_caption", "about_company_id").Where("about_company_id = ?", modelAboutCompany.ID).Find(&aboutCompanyKeynoteModel).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllCompanyAndKeynote - 2: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllCompanyAndKeynote", 2, err)
+		return nil, dbError(err)
 	}
 
 	var aboutCompanyKeynoteEntity []entity.AboutCompanyKeynoteEntity
@@ -46,6 +46,8 @@ func (h *aboutCompanyRepository) FetchAllCompanyAndKeynote(ctx context.Context)
 			AboutCompanyID: modelAboutCompany.ID,
 			Keynote:        val.Keypoint,
 			PathImage:      *val.PathImage,
+			ImageAlt:       stringValue(val.ImageAlt),
+			ImageCaption:   stringValue(val.ImageCaption),
 		})
 	}
 
@@ -62,9 +64,9 @@ func (h *aboutCompanyRepository) CreateAboutCompany(ctx context.Context, req ent
 		Description: req.Description,
 	}
 
-	if err = h.DB.Create(&modelAboutCompany).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateAboutCompany - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelAboutCompany).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateAboutCompany", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -73,16 +75,21 @@ func (h *aboutCompanyRepository) CreateAboutCompany(ctx context.Context, req ent
 func (h *aboutCompanyRepository) DeleteByIDAboutCompany(ctx context.Context, id int64) error {
 	modelAboutCompany := model.AboutCompany{}
 
-	err = h.DB.Where("id = ?", id).First(&modelAboutCompany).Error
+	err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelAboutCompany).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDAboutCompany - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDAboutCompany", 1, err)
+		return dbError(err)
 	}
 
-	err = h.DB.Delete(&modelAboutCompany).Error
+	err = h.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&modelAboutCompany).Error; err != nil {
+			return dbError(err)
+		}
+		return softDeleteChildren(tx, "about-companies", id)
+	})
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDAboutCompany - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDAboutCompany", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -91,17 +98,17 @@ func (h *aboutCompanyRepository) DeleteByIDAboutCompany(ctx context.Context, id
 func (h *aboutCompanyRepository) EditByIDAboutCompany(ctx context.Context, req entity.AboutCompanyEntity) error {
 	modelAboutCompany := model.AboutCompany{}
 
-	err = h.DB.Where("id =?", req.ID).First(&modelAboutCompany).Error
+	err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelAboutCompany).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDAboutCompany - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDAboutCompany", 1, err)
+		return dbError(err)
 	}
 	modelAboutCompany.Description = req.Description
 
-	err = h.DB.Save(&modelAboutCompany).Error
+	err = h.DB.WithContext(ctx).Save(&modelAboutCompany).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDAboutCompany - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDAboutCompany", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -109,10 +116,10 @@ func (h *aboutCompanyRepository) EditByIDAboutCompany(ctx context.Context, req e
 // FetchAllAboutCompany implements AboutCompanyInterface.
 func (h *aboutCompanyRepository) FetchAllAboutCompany(ctx context.Context) ([]entity.AboutCompanyEntity, error) {
 	modelAboutCompany := []model.AboutCompany{}
-	err = h.DB.Select("id", "description").Find(&modelAboutCompany).Order("created_at DESC").Error
+	err = h.DB.WithContext(ctx).Select("id", "description").Find(&modelAboutCompany).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllAboutCompany - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllAboutCompany", 1, err)
+		return nil, dbError(err)
 	}
 
 	var aboutCompanyRepositoryEntities []entity.AboutCompanyEntity
@@ -129,10 +136,10 @@ func (h *aboutCompanyRepository) FetchAllAboutCompany(ctx context.Context) ([]en
 // FetchByIDAboutCompany implements AboutCompanyInterface.
 func (h *aboutCompanyRepository) FetchByIDAboutCompany(ctx context.Context, id int64) (*entity.AboutCompanyEntity, error) {
 	modelAboutCompany := model.AboutCompany{}
-	err = h.DB.Select("id", "description").Where("id = ?", id).First(&modelAboutCompany).Error
+	err = h.DB.WithContext(ctx).Select("id", "description").Where("id = ?", id).First(&modelAboutCompany).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDAboutCompany - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDAboutCompany", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.AboutCompanyEntity{
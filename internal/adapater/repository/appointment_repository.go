@@ -4,16 +4,17 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
 type AppointmentRepositoryInterface interface {
-	FetchAllAppointment(ctx context.Context) ([]entity.AppointmentEntity, error)
+	FetchAllAppointment(ctx context.Context, filter entity.AppointmentFilter) ([]entity.AppointmentEntity, error)
+	StreamAppointment(ctx context.Context, filter entity.AppointmentFilter, fn func(entity.AppointmentEntity) error) error
 	FetchByIDAppointment(ctx context.Context, id int64) (*entity.AppointmentEntity, error)
 	DeleteByIDAppointment(ctx context.Context, id int64) error
-	CreateAppointment(ctx context.Context, req entity.AppointmentEntity) (string, error)
+	CreateAppointment(ctx context.Context, req entity.AppointmentEntity, outbox []entity.EmailOutboxEntity) error
 }
 
 type appointmentRepository struct {
@@ -21,7 +22,8 @@ type appointmentRepository struct {
 }
 
 // CreateAppointment implements AppointmentRepositoryInterface.
-func (h *appointmentRepository) CreateAppointment(ctx context.Context, req entity.AppointmentEntity) (string, error) {
+// The notification emails are queued in the same transaction as the appointment.
+func (h *appointmentRepository) CreateAppointment(ctx context.Context, req entity.AppointmentEntity, outbox []entity.EmailOutboxEntity) error {
 	modelAppointment := model.Appointment{
 		ServiceID:   req.ServiceID,
 		Name:        req.Name,
@@ -32,80 +34,130 @@ func (h *appointmentRepository) CreateAppointment(ctx context.Context, req entit
 		MeetAt:      req.MeetAt,
 	}
 
-	if err = h.DB.Create(&modelAppointment).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateAppointment - 1: %v", err)
-		return "", err
-	}
-
-	return modelAppointment.Email, nil
+	err := h.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&modelAppointment).Error; err != nil {
+			logger.Error(ctx, logger.Repository, "CreateAppointment", 1, err)
+			return dbError(err)
+		}
 
+		for _, msg := range outbox {
+			if err := createEmailOutbox(tx, msg); err != nil {
+				logger.Error(ctx, logger.Repository, "CreateAppointment", 2, err)
+				return dbError(err)
+			}
+		}
+		return nil
+	})
+	return dbError(err)
 }
 
 // DeleteByIDAppointment implements AppointmentInterface.
 func (h *appointmentRepository) DeleteByIDAppointment(ctx context.Context, id int64) error {
 	modelAppointment := model.Appointment{}
 
-	if err = h.DB.Where("id = ?", id).First(&modelAppointment).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDAppointment - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelAppointment).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDAppointment", 1, err)
+		return dbError(err)
 	}
 
-	if err = h.DB.Delete(&modelAppointment).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDAppointment - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Delete(&modelAppointment).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDAppointment", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
 
 // FetchAllAppointment implements AppointmentInterface.
-func (h *appointmentRepository) FetchAllAppointment(ctx context.Context) ([]entity.AppointmentEntity, error) {
-	rows, err := h.DB.
+func (h *appointmentRepository) FetchAllAppointment(ctx context.Context, filter entity.AppointmentFilter) ([]entity.AppointmentEntity, error) {
+	var appointmentRepositoryEntities []entity.AppointmentEntity
+	err := h.StreamAppointment(ctx, filter, func(appointment entity.AppointmentEntity) error {
+		appointmentRepositoryEntities = append(appointmentRepositoryEntities, appointment)
+		return nil
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllAppointment", 1, err)
+		return nil, dbError(err)
+	}
+
+	return appointmentRepositoryEntities, nil
+}
+
+// StreamAppointment implements AppointmentInterface.
+func (h *appointmentRepository) StreamAppointment(ctx context.Context, filter entity.AppointmentFilter, fn func(entity.AppointmentEntity) error) error {
+	query := h.DB.WithContext(ctx).
 		Table("appointments as a").
-		Select("a.id", "a.name", "a.email", "a.phone_number", "a.brief", "a.budget", "ss.name").
+		Select("a.id", "a.name", "a.email", "a.phone_number", "a.brief", "a.budget", "a.meet_at", "ss.id", "ss.name").
 		Joins("inner join service_sections as ss on ss.id = a.service_id").
-		Where("a.deleted_at IS NULL").
-		Rows()
+		Where("a.deleted_at IS NULL")
+
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("(a.name ILIKE ? OR a.email ILIKE ? OR a.phone_number ILIKE ?)", search, search, search)
+	}
+	if filter.ServiceID > 0 {
+		query = query.Where("a.service_id = ?", filter.ServiceID)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("a.meet_at >= ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("a.meet_at < ?", *filter.EndDate)
+	}
+
+	rows, err := query.Order("a.meet_at DESC").Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllAppointment - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "StreamAppointment", 1, err)
+		return dbError(err)
 	}
+	defer rows.Close()
 
-	var appointmentRepositoryEntities []entity.AppointmentEntity
 	for rows.Next() {
 		var appointment entity.AppointmentEntity
-		err = rows.Scan(&appointment.ID, &appointment.Name, &appointment.Email, &appointment.PhoneNumber, &appointment.Brief, &appointment.Budget, &appointment.ServiceName)
+		err = rows.Scan(&appointment.ID, &appointment.Name, &appointment.Email, &appointment.PhoneNumber, &appointment.Brief, &appointment.Budget, &appointment.MeetAt, &appointment.ServiceID, &appointment.ServiceName)
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchAllAppointment - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "StreamAppointment", 2, err)
+			return dbError(err)
+		}
+
+		if err = fn(appointment); err != nil {
+			return dbError(err)
 		}
-		appointmentRepositoryEntities = append(appointmentRepositoryEntities, appointment)
 	}
 
-	return appointmentRepositoryEntities, nil
+	return dbError(rows.Err())
 }
 
 // FetchByIDAppointment implements AppointmentInterface.
 func (h *appointmentRepository) FetchByIDAppointment(ctx context.Context, id int64) (*entity.AppointmentEntity, error) {
-	rows, err := h.DB.
+	rows, err := h.DB.WithContext(ctx).
 		Table("appointments as a").
 		Select("a.id", "a.phone_number", "brief", "meet_at", "a.name", "a.email", "a.budget", "ss.id", "ss.name").
 		Joins("inner join service_sections as ss on ss.id = a.service_id").
 		Where("a.id =? AND a.deleted_at IS NULL", id).
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDAppointment - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDAppointment", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	appointment := &entity.AppointmentEntity{}
 	for rows.Next() {
 		err = rows.Scan(&appointment.ID, &appointment.PhoneNumber, &appointment.Brief, &appointment.MeetAt, &appointment.Name, &appointment.Email, &appointment.Budget, &appointment.ServiceID, &appointment.ServiceName)
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchByIDAppointment - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchByIDAppointment", 2, err)
+			return nil, dbError(err)
 		}
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDAppointment", 3, err)
+		return nil, dbError(err)
+	}
+	if appointment.ID == 0 {
+		return nil, dbError(gorm.ErrRecordNotFound)
+	}
+
 	return appointment, nil
 }
 
@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -24,13 +24,15 @@ type clientSectionRepository struct {
 // CreateClientSection implements ClientSectionInterface.
 func (h *clientSectionRepository) CreateClientSection(ctx context.Context, req entity.ClientSectionEntity) error {
 	modelClientSection := model.ClientSection{
-		Name:     req.Name,
-		PathIcon: req.PathIcon,
+		Name:        req.Name,
+		PathIcon:    req.PathIcon,
+		IconAlt:     nullString(req.IconAlt),
+		IconCaption: nullString(req.IconCaption),
 	}
 
-	if err = h.DB.Create(&modelClientSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateClientSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelClientSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateClientSection", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -39,16 +41,16 @@ func (h *clientSectionRepository) CreateClientSection(ctx context.Context, req e
 func (h *clientSectionRepository) DeleteByIDClientSection(ctx context.Context, id int64) error {
 	modelClientSection := model.ClientSection{}
 
-	err = h.DB.Where("id = ?", id).First(&modelClientSection).Error
+	err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelClientSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDClientSection - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDClientSection", 1, err)
+		return dbError(err)
 	}
 
-	err = h.DB.Delete(&modelClientSection).Error
+	err = h.DB.WithContext(ctx).Delete(&modelClientSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDClientSection - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDClientSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -57,17 +59,19 @@ func (h *clientSectionRepository) DeleteByIDClientSection(ctx context.Context, i
 func (h *clientSectionRepository) EditByIDClientSection(ctx context.Context, req entity.ClientSectionEntity) error {
 	modelClientSection := model.ClientSection{}
 
-	err = h.DB.Where("id =?", req.ID).First(&modelClientSection).Error
+	err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelClientSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDClientSection - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDClientSection", 1, err)
+		return dbError(err)
 	}
 	modelClientSection.Name = req.Name
 	modelClientSection.PathIcon = req.PathIcon
-	err = h.DB.Save(&modelClientSection).Error
+	modelClientSection.IconAlt = nullString(req.IconAlt)
+	modelClientSection.IconCaption = nullString(req.IconCaption)
+	err = h.DB.WithContext(ctx).Save(&modelClientSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDClientSection - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDClientSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -75,18 +79,20 @@ func (h *clientSectionRepository) EditByIDClientSection(ctx context.Context, req
 // FetchAllClientSection implements ClientSectionInterface.
 func (h *clientSectionRepository) FetchAllClientSection(ctx context.Context) ([]entity.ClientSectionEntity, error) {
 	modelClientSection := []model.ClientSection{}
-	err = h.DB.Select("id", "name", "path_icon").Find(&modelClientSection).Order("created_at DESC").Error
+	err := h.DB.WithContext(ctx).Select("id", "name", "path_icon", "icon_alt", "icon_caption").Find(&modelClientSection).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllClientSection - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllClientSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	var clientSectionRepositoryEntities []entity.ClientSectionEntity
 	for _, v := range modelClientSection {
 		clientSectionRepositoryEntities = append(clientSectionRepositoryEntities, entity.ClientSectionEntity{
-			ID:       v.ID,
-			Name:     v.Name,
-			PathIcon: v.PathIcon,
+			ID:          v.ID,
+			Name:        v.Name,
+			PathIcon:    v.PathIcon,
+			IconAlt:     stringValue(v.IconAlt),
+			IconCaption: stringValue(v.IconCaption),
 		})
 	}
 
@@ -96,16 +102,18 @@ func (h *clientSectionRepository) FetchAllClientSection(ctx context.Context) ([]
 // FetchByIDClientSection implements ClientSectionInterface.
 func (h *clientSectionRepository) FetchByIDClientSection(ctx context.Context, id int64) (*entity.ClientSectionEntity, error) {
 	modelClientSection := model.ClientSection{}
-	err = h.DB.Select("id", "name", "path_icon").Where("id = ?", id).First(&modelClientSection).Error
+	err = h.DB.WithContext(ctx).Select("id", "name", "path_icon", "icon_alt", "icon_caption").Where("id = ?", id).First(&modelClientSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDClientSection - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDClientSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.ClientSectionEntity{
-		ID:       modelClientSection.ID,
-		Name:     modelClientSection.Name,
-		PathIcon: modelClientSection.PathIcon,
+		ID:          modelClientSection.ID,
+		Name:        modelClientSection.Name,
+		PathIcon:    modelClientSection.PathIcon,
+		IconAlt:     stringValue(modelClientSection.IconAlt),
+		IconCaption: stringValue(modelClientSection.IconCaption),
 	}, nil
 }
 
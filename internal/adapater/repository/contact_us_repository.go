@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -30,9 +30,9 @@ func (h *contactUsRepository) CreateContactUs(ctx context.Context, req entity.Co
 		PhoneNumber:  req.PhoneNumber,
 	}
 
-	if err = h.DB.Create(&modelContactUs).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateContactUs - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelContactUs).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateContactUs", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -41,16 +41,16 @@ func (h *contactUsRepository) CreateContactUs(ctx context.Context, req entity.Co
 func (h *contactUsRepository) DeleteByIDContactUs(ctx context.Context, id int64) error {
 	modelContactUs := model.ContactUs{}
 
-	err = h.DB.Where("id = ?", id).First(&modelContactUs).Error
+	err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelContactUs).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDContactUs - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDContactUs", 1, err)
+		return dbError(err)
 	}
 
-	err = h.DB.Delete(&modelContactUs).Error
+	err = h.DB.WithContext(ctx).Delete(&modelContactUs).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDContactUs - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDContactUs", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -59,19 +59,19 @@ func (h *contactUsRepository) DeleteByIDContactUs(ctx context.Context, id int64)
 func (h *contactUsRepository) EditByIDContactUs(ctx context.Context, req entity.ContactUsEntity) error {
 	modelContactUs := model.ContactUs{}
 
-	err = h.DB.Where("id =?", req.ID).First(&modelContactUs).Error
+	err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelContactUs).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDContactUs - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDContactUs", 1, err)
+		return dbError(err)
 	}
 	modelContactUs.Address = req.Address
 	modelContactUs.CompanyName = req.CompanyName
 	modelContactUs.PhoneNumber = req.PhoneNumber
 	modelContactUs.LocationName = req.LocationName
-	err = h.DB.Save(&modelContactUs).Error
+	err = h.DB.WithContext(ctx).Save(&modelContactUs).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDContactUs - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDContactUs", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -79,10 +79,10 @@ func (h *contactUsRepository) EditByIDContactUs(ctx context.Context, req entity.
 // FetchAllContactUs implements ContactUsInterface.
 func (h *contactUsRepository) FetchAllContactUs(ctx context.Context) ([]entity.ContactUsEntity, error) {
 	modelContactUs := []model.ContactUs{}
-	err = h.DB.Select("id", "location_name", "address", "phone_number", "company_name").Find(&modelContactUs).Order("created_at DESC").Error
+	err := h.DB.WithContext(ctx).Select("id", "location_name", "address", "phone_number", "company_name").Find(&modelContactUs).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllContactUs - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllContactUs", 1, err)
+		return nil, dbError(err)
 	}
 
 	var contactUsRepositoryEntities []entity.ContactUsEntity
@@ -102,10 +102,10 @@ func (h *contactUsRepository) FetchAllContactUs(ctx context.Context) ([]entity.C
 // FetchByIDContactUs implements ContactUsInterface.
 func (h *contactUsRepository) FetchByIDContactUs(ctx context.Context, id int64) (*entity.ContactUsEntity, error) {
 	modelContactUs := model.ContactUs{}
-	err = h.DB.Select("id", "location_name", "address", "phone_number", "company_name").Where("id = ?", id).First(&modelContactUs).Error
+	err = h.DB.WithContext(ctx).Select("id", "location_name", "address", "phone_number", "company_name").Where("id = ?", id).First(&modelContactUs).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDContactUs - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDContactUs", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.ContactUsEntity{
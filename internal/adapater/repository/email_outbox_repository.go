@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"desadangdang/internal/core/domain/apperr"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/tracing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailOutboxInterface interface {
+	CreateEmailOutbox(ctx context.Context, req entity.EmailOutboxEntity) error
+	ClaimDueEmailOutbox(ctx context.Context, limit int, lease time.Duration) ([]entity.EmailOutboxEntity, error)
+	MarkSentEmailOutbox(ctx context.Context, id int64) error
+	MarkFailedEmailOutbox(ctx context.Context, id int64, attempts int, lastError string, nextAttemptAt time.Time, dead bool) error
+	FetchAllEmailOutbox(ctx context.Context, status string) ([]entity.EmailOutboxEntity, error)
+	FetchByIDEmailOutbox(ctx context.Context, id int64) (*entity.EmailOutboxEntity, error)
+	RetryByIDEmailOutbox(ctx context.Context, id int64) error
+}
+
+var errEmailNotDead = apperr.Conflict(apperr.CodeConflict, "only dead emails can be retried")
+
+type emailOutbox struct {
+	DB *gorm.DB
+}
+
+// createEmailOutbox inserts the message with the given tx so it can be
+// committed together with the domain change that produced it. The trace of
+// the tx context is kept so the send can be linked back to it.
+func createEmailOutbox(tx *gorm.DB, req entity.EmailOutboxEntity) error {
+	maxAttempts := req.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+
+	modelOutbox := model.EmailOutbox{
+		FromAddress:   req.FromAddress,
+		ToAddress:     req.ToAddress,
+		Subject:       req.Subject,
+		Body:          req.Body,
+		TextBody:      &req.TextBody,
+		Status:        entity.EmailStatusPending,
+		MaxAttempts:   maxAttempts,
+		NextAttemptAt: time.Now(),
+	}
+	if traceparent := tracing.Traceparent(tx.Statement.Context); traceparent != "" {
+		modelOutbox.Traceparent = &traceparent
+	}
+
+	return tx.Create(&modelOutbox).Error
+}
+
+// CreateEmailOutbox implements EmailOutboxInterface.
+func (e *emailOutbox) CreateEmailOutbox(ctx context.Context, req entity.EmailOutboxEntity) error {
+	if err := createEmailOutbox(e.DB.WithContext(ctx), req); err != nil {
+		logger.Error(ctx, logger.Repository, "CreateEmailOutbox", 1, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+// ClaimDueEmailOutbox implements EmailOutboxInterface.
+// Claimed rows get their next_attempt_at pushed by lease so another worker
+// does not pick them up while they are being sent.
+func (e *emailOutbox) ClaimDueEmailOutbox(ctx context.Context, limit int, lease time.Duration) ([]entity.EmailOutboxEntity, error) {
+	modelOutbox := []model.EmailOutbox{}
+
+	err := e.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.EmailStatusPending, time.Now()).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&modelOutbox).Error
+		if err != nil || len(modelOutbox) == 0 {
+			return dbError(err)
+		}
+
+		ids := make([]int64, 0, len(modelOutbox))
+		for _, v := range modelOutbox {
+			ids = append(ids, v.ID)
+		}
+
+		return tx.Model(&model.EmailOutbox{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", time.Now().Add(lease)).Error
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "ClaimDueEmailOutbox", 1, err)
+		return nil, dbError(err)
+	}
+
+	var outboxEntities []entity.EmailOutboxEntity
+	for _, v := range modelOutbox {
+		outboxEntities = append(outboxEntities, emailOutboxEntity(v))
+	}
+
+	return outboxEntities, nil
+}
+
+// MarkSentEmailOutbox implements EmailOutboxInterface.
+func (e *emailOutbox) MarkSentEmailOutbox(ctx context.Context, id int64) error {
+	err := e.DB.WithContext(ctx).Model(&model.EmailOutbox{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     entity.EmailStatusSent,
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": nil,
+		"sent_at":    time.Now(),
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "MarkSentEmailOutbox", 1, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+// MarkFailedEmailOutbox implements EmailOutboxInterface.
+func (e *emailOutbox) MarkFailedEmailOutbox(ctx context.Context, id int64, attempts int, lastError string, nextAttemptAt time.Time, dead bool) error {
+	status := entity.EmailStatusPending
+	if dead {
+		status = entity.EmailStatusDead
+	}
+
+	err := e.DB.WithContext(ctx).Model(&model.EmailOutbox{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+		"updated_at":      time.Now(),
+	}).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "MarkFailedEmailOutbox", 1, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+// FetchAllEmailOutbox implements EmailOutboxInterface.
+func (e *emailOutbox) FetchAllEmailOutbox(ctx context.Context, status string) ([]entity.EmailOutboxEntity, error) {
+	modelOutbox := []model.EmailOutbox{}
+
+	query := e.DB.WithContext(ctx).Order("created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Find(&modelOutbox).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllEmailOutbox", 1, err)
+		return nil, dbError(err)
+	}
+
+	var outboxEntities []entity.EmailOutboxEntity
+	for _, v := range modelOutbox {
+		outboxEntities = append(outboxEntities, emailOutboxEntity(v))
+	}
+
+	return outboxEntities, nil
+}
+
+// FetchByIDEmailOutbox implements EmailOutboxInterface.
+func (e *emailOutbox) FetchByIDEmailOutbox(ctx context.Context, id int64) (*entity.EmailOutboxEntity, error) {
+	modelOutbox := model.EmailOutbox{}
+
+	if err := e.DB.WithContext(ctx).Where("id = ?", id).First(&modelOutbox).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDEmailOutbox", 1, err)
+		return nil, dbError(err)
+	}
+
+	result := emailOutboxEntity(modelOutbox)
+	return &result, nil
+}
+
+// RetryByIDEmailOutbox implements EmailOutboxInterface.
+// Only dead emails are queued again. A pending one is still retried by the
+// worker, maybe right now, and a sent one was delivered.
+func (e *emailOutbox) RetryByIDEmailOutbox(ctx context.Context, id int64) error {
+	result := e.DB.WithContext(ctx).Model(&model.EmailOutbox{}).
+		Where("id = ? AND status = ?", id, entity.EmailStatusDead).
+		Updates(map[string]interface{}{
+			"status":          entity.EmailStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+			"updated_at":      time.Now(),
+		})
+	if result.Error != nil {
+		logger.Error(ctx, logger.Repository, "RetryByIDEmailOutbox", 1, result.Error)
+		return dbError(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	if err := e.DB.WithContext(ctx).Where("id = ?", id).First(&model.EmailOutbox{}).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "RetryByIDEmailOutbox", 2, err)
+		return dbError(err)
+	}
+	logger.Errorf(ctx, logger.Repository, "RetryByIDEmailOutbox", 3, "email %d is not dead", id)
+	return errEmailNotDead
+}
+
+func emailOutboxEntity(v model.EmailOutbox) entity.EmailOutboxEntity {
+	result := entity.EmailOutboxEntity{
+		ID:            v.ID,
+		FromAddress:   v.FromAddress,
+		ToAddress:     v.ToAddress,
+		Subject:       v.Subject,
+		Body:          v.Body,
+		Status:        v.Status,
+		Attempts:      v.Attempts,
+		MaxAttempts:   v.MaxAttempts,
+		NextAttemptAt: v.NextAttemptAt,
+		SentAt:        v.SentAt,
+		CreatedAt:     v.CreatedAt,
+	}
+	if v.TextBody != nil {
+		result.TextBody = *v.TextBody
+	}
+	if v.LastError != nil {
+		result.LastError = *v.LastError
+	}
+	if v.Traceparent != nil {
+		result.Traceparent = *v.Traceparent
+	}
+	return result
+}
+
+func NewEmailOutboxRepository(DB *gorm.DB) EmailOutboxInterface {
+	return &emailOutbox{
+		DB: DB,
+	}
+}
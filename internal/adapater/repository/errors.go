@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"desadangdang/internal/core/domain/apperr"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+	pgDataException       = "22"
+)
+
+var (
+	errDuplicate  = apperr.Conflict(apperr.CodeDuplicate, "data already exists")
+	errReferenced = apperr.Conflict(apperr.CodeReferenced, "data is still referenced by or refers to another record")
+	errInvalid    = apperr.Validation("data is not valid")
+)
+
+// dbError turns a gorm or postgres error into a domain error. The driver
+// message stays in the cause, it is logged but never sent to a client.
+func dbError(err error) error {
+	var (
+		appErr *apperr.Error
+		pgErr  *pgconn.PgError
+	)
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &appErr):
+		return err
+	case errors.Is(err, context.Canceled):
+		return apperr.ErrCanceled.Wrap(err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return apperr.ErrTimeout.Wrap(err)
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, sql.ErrNoRows):
+		return apperr.ErrNotFound.Wrap(err)
+	case errors.As(err, &pgErr):
+		switch {
+		case pgErr.Code == pgUniqueViolation:
+			return errDuplicate.Wrap(err)
+		case pgErr.Code == pgForeignKeyViolation:
+			return errReferenced.Wrap(err)
+		case pgErr.Code == pgNotNullViolation, pgErr.Code == pgCheckViolation, pgErr.Code[:2] == pgDataException:
+			return errInvalid.Wrap(err)
+		}
+	}
+	return apperr.ErrInternal.Wrap(err)
+}
+
+// isUniqueViolation reports whether err is a postgres unique violation of the
+// constraint or unique index named constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
+}
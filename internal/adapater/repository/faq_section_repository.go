@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -28,9 +28,9 @@ func (h *faqSectionRepository) CreateFaqSection(ctx context.Context, req entity.
 		Title:       req.Title,
 	}
 
-	if err = h.DB.Create(&modelFaqSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateFaqSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelFaqSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateFaqSection", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -39,16 +39,16 @@ func (h *faqSectionRepository) CreateFaqSection(ctx context.Context, req entity.
 func (h *faqSectionRepository) DeleteByIDFaqSection(ctx context.Context, id int64) error {
 	modelFaqSection := model.FaqSection{}
 
-	err = h.DB.Where("id = ?", id).First(&modelFaqSection).Error
+	err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelFaqSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDFaqSection - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDFaqSection", 1, err)
+		return dbError(err)
 	}
 
-	err = h.DB.Delete(&modelFaqSection).Error
+	err = h.DB.WithContext(ctx).Delete(&modelFaqSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDFaqSection - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDFaqSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -57,18 +57,18 @@ func (h *faqSectionRepository) DeleteByIDFaqSection(ctx context.Context, id int6
 func (h *faqSectionRepository) EditByIDFaqSection(ctx context.Context, req entity.FaqSectionEntity) error {
 	modelFaqSection := model.FaqSection{}
 
-	err = h.DB.Where("id =?", req.ID).First(&modelFaqSection).Error
+	err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelFaqSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDFaqSection - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDFaqSection", 1, err)
+		return dbError(err)
 	}
 	modelFaqSection.Description = req.Description
 	modelFaqSection.Title = req.Title
 
-	err = h.DB.Save(&modelFaqSection).Error
+	err = h.DB.WithContext(ctx).Save(&modelFaqSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDFaqSection - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDFaqSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -76,10 +76,10 @@ func (h *faqSectionRepository) EditByIDFaqSection(ctx context.Context, req entit
 // FetchAllFaqSection implements FaqSectionInterface.
 func (h *faqSectionRepository) FetchAllFaqSection(ctx context.Context) ([]entity.FaqSectionEntity, error) {
 	modelFaqSection := []model.FaqSection{}
-	err = h.DB.Select("id", "title", "description").Find(&modelFaqSection).Order("created_at DESC").Error
+	err := h.DB.WithContext(ctx).Select("id", "title", "description").Find(&modelFaqSection).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllFaqSection - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllFaqSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	var faqSectionRepositoryEntities []entity.FaqSectionEntity
@@ -97,10 +97,10 @@ func (h *faqSectionRepository) FetchAllFaqSection(ctx context.Context) ([]entity
 // FetchByIDFaqSection implements FaqSectionInterface.
 func (h *faqSectionRepository) FetchByIDFaqSection(ctx context.Context, id int64) (*entity.FaqSectionEntity, error) {
 	modelFaqSection := model.FaqSection{}
-	err = h.DB.Select("id", "title", "description").Where("id = ?", id).First(&modelFaqSection).Error
+	err = h.DB.WithContext(ctx).Select("id", "title", "description").Where("id = ?", id).First(&modelFaqSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDFaqSection - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDFaqSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.FaqSectionEntity{
@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -24,15 +24,17 @@ type heroSection struct {
 // CreateHeroSection implements HeroSectionInterface.
 func (h *heroSection) CreateHeroSection(ctx context.Context, req entity.HeroSectionEntity) error {
 	modelHeroSection := model.HeroSection{
-		Heading:    req.Heading,
-		SubHeading: req.SubHeading,
-		PathVideo:  &req.PathVideo,
-		PathBanner: req.Banner,
+		Heading:       req.Heading,
+		SubHeading:    req.SubHeading,
+		PathVideo:     &req.PathVideo,
+		PathBanner:    req.Banner,
+		BannerAlt:     nullString(req.BannerAlt),
+		BannerCaption: nullString(req.BannerCaption),
 	}
 
-	if err = h.DB.Create(&modelHeroSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateHeroSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelHeroSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateHeroSection", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -41,16 +43,16 @@ func (h *heroSection) CreateHeroSection(ctx context.Context, req entity.HeroSect
 func (h *heroSection) DeleteByIDHeroSection(ctx context.Context, id int64) error {
 	modelHeroSection := model.HeroSection{}
 
-	err = h.DB.Where("id = ?", id).First(&modelHeroSection).Error
+	err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelHeroSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDHeroSection - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDHeroSection", 1, err)
+		return dbError(err)
 	}
 
-	err = h.DB.Delete(&modelHeroSection).Error
+	err = h.DB.WithContext(ctx).Delete(&modelHeroSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDHeroSection - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDHeroSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -59,19 +61,21 @@ func (h *heroSection) DeleteByIDHeroSection(ctx context.Context, id int64) error
 func (h *heroSection) EditByIDHeroSection(ctx context.Context, req entity.HeroSectionEntity) error {
 	modelHeroSection := model.HeroSection{}
 
-	err = h.DB.Where("id =?", req.ID).First(&modelHeroSection).Error
+	err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelHeroSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDHeroSection - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDHeroSection", 1, err)
+		return dbError(err)
 	}
 	modelHeroSection.Heading = req.Heading
 	modelHeroSection.SubHeading = req.SubHeading
 	modelHeroSection.PathVideo = &req.PathVideo
 	modelHeroSection.PathBanner = req.Banner
-	err = h.DB.Save(&modelHeroSection).Error
+	modelHeroSection.BannerAlt = nullString(req.BannerAlt)
+	modelHeroSection.BannerCaption = nullString(req.BannerCaption)
+	err = h.DB.WithContext(ctx).Save(&modelHeroSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDHeroSection - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDHeroSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -79,20 +83,22 @@ func (h *heroSection) EditByIDHeroSection(ctx context.Context, req entity.HeroSe
 // FetchAllHeroSection implements HeroSectionInterface.
 func (h *heroSection) FetchAllHeroSection(ctx context.Context) ([]entity.HeroSectionEntity, error) {
 	modelHeroSection := []model.HeroSection{}
-	err = h.DB.Select("id", "heading", "sub_heading", "path_video", "path_banner").Find(&modelHeroSection).Order("created_at DESC").Error
+	err := h.DB.WithContext(ctx).Select("id", "heading", "sub_heading", "path_video", "path_banner", "banner_alt", "banner_caption").Find(&modelHeroSection).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllHeroSection - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllHeroSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	var heroSectionEntities []entity.HeroSectionEntity
 	for _, v := range modelHeroSection {
 		heroSectionEntities = append(heroSectionEntities, entity.HeroSectionEntity{
-			ID:         v.ID,
-			Heading:    v.Heading,
-			SubHeading: v.SubHeading,
-			PathVideo:  *v.PathVideo,
-			Banner:     v.PathBanner,
+			ID:            v.ID,
+			Heading:       v.Heading,
+			SubHeading:    v.SubHeading,
+			PathVideo:     *v.PathVideo,
+			Banner:        v.PathBanner,
+			BannerAlt:     stringValue(v.BannerAlt),
+			BannerCaption: stringValue(v.BannerCaption),
 		})
 	}
 
@@ -102,18 +108,20 @@ func (h *heroSection) FetchAllHeroSection(ctx context.Context) ([]entity.HeroSec
 // FetchByIDHeroSection implements HeroSectionInterface.
 func (h *heroSection) FetchByIDHeroSection(ctx context.Context, id int64) (*entity.HeroSectionEntity, error) {
 	modelHeroSection := model.HeroSection{}
-	err = h.DB.Where("id = ?", id).First(&modelHeroSection).Error
+	err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelHeroSection).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDHeroSection - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDHeroSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.HeroSectionEntity{
-		ID:         modelHeroSection.ID,
-		Heading:    modelHeroSection.Heading,
-		SubHeading: modelHeroSection.SubHeading,
-		PathVideo:  *modelHeroSection.PathVideo,
-		Banner:     modelHeroSection.PathBanner,
+		ID:            modelHeroSection.ID,
+		Heading:       modelHeroSection.Heading,
+		SubHeading:    modelHeroSection.SubHeading,
+		PathVideo:     *modelHeroSection.PathVideo,
+		Banner:        modelHeroSection.PathBanner,
+		BannerAlt:     stringValue(modelHeroSection.BannerAlt),
+		BannerCaption: stringValue(modelHeroSection.BannerCaption),
 	}, nil
 }
 
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type InquiryInterface interface {
+	CreateInquiry(ctx context.Context, req entity.InquiryEntity) error
+	FetchAllInquiry(ctx context.Context, filter entity.InquiryFilter) ([]entity.InquiryEntity, error)
+	FetchByIDInquiry(ctx context.Context, id int64) (*entity.InquiryEntity, error)
+	MarkReadByIDInquiry(ctx context.Context, id int64, read bool) error
+	ArchiveByIDInquiry(ctx context.Context, id int64, archived bool) error
+	ReplyByIDInquiry(ctx context.Context, req entity.InquiryReplyEntity, outbox entity.EmailOutboxEntity) error
+	DeleteByIDInquiry(ctx context.Context, id int64) error
+	CountUnreadInquiry(ctx context.Context) (int64, error)
+}
+
+type inquiry struct {
+	DB *gorm.DB
+}
+
+// CreateInquiry implements InquiryInterface.
+func (i *inquiry) CreateInquiry(ctx context.Context, req entity.InquiryEntity) error {
+	modelInquiry := model.Inquiry{
+		Name:    req.Name,
+		Email:   req.Email,
+		Subject: req.Subject,
+		Message: req.Message,
+	}
+	if req.PhoneNumber != "" {
+		modelInquiry.PhoneNumber = &req.PhoneNumber
+	}
+	if req.IpAddress != "" {
+		modelInquiry.IpAddress = &req.IpAddress
+	}
+
+	if err := i.DB.WithContext(ctx).Create(&modelInquiry).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateInquiry", 1, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+// FetchAllInquiry implements InquiryInterface.
+func (i *inquiry) FetchAllInquiry(ctx context.Context, filter entity.InquiryFilter) ([]entity.InquiryEntity, error) {
+	modelInquiry := []model.Inquiry{}
+
+	query := i.DB.WithContext(ctx).Select("id", "name", "email", "phone_number", "subject", "message", "read_at", "archived_at", "created_at")
+	if filter.Archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	switch filter.Status {
+	case "read":
+		query = query.Where("read_at IS NOT NULL")
+	case "unread":
+		query = query.Where("read_at IS NULL")
+	}
+
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("(name ILIKE ? OR email ILIKE ? OR subject ILIKE ?)", search, search, search)
+	}
+
+	if err := query.Order("created_at DESC").Find(&modelInquiry).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllInquiry", 1, err)
+		return nil, dbError(err)
+	}
+
+	var inquiryEntities []entity.InquiryEntity
+	for _, v := range modelInquiry {
+		inquiryEntities = append(inquiryEntities, inquiryEntity(v))
+	}
+
+	return inquiryEntities, nil
+}
+
+// FetchByIDInquiry implements InquiryInterface.
+// The replies are loaded oldest first so they read as a thread.
+func (i *inquiry) FetchByIDInquiry(ctx context.Context, id int64) (*entity.InquiryEntity, error) {
+	modelInquiry := model.Inquiry{}
+
+	err := i.DB.WithContext(ctx).Preload("Replies", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Where("id = ?", id).First(&modelInquiry).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDInquiry", 1, err)
+		return nil, dbError(err)
+	}
+
+	result := inquiryEntity(modelInquiry)
+	return &result, nil
+}
+
+// MarkReadByIDInquiry implements InquiryInterface.
+func (i *inquiry) MarkReadByIDInquiry(ctx context.Context, id int64, read bool) error {
+	var readAt *time.Time
+	if read {
+		now := time.Now()
+		readAt = &now
+	}
+
+	return i.updateInquiry(ctx, id, "read_at", readAt, "MarkReadByIDInquiry")
+}
+
+// ArchiveByIDInquiry implements InquiryInterface.
+func (i *inquiry) ArchiveByIDInquiry(ctx context.Context, id int64, archived bool) error {
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
+	}
+
+	return i.updateInquiry(ctx, id, "archived_at", archivedAt, "ArchiveByIDInquiry")
+}
+
+// ReplyByIDInquiry implements InquiryInterface.
+// The reply and its outgoing email are stored in one transaction.
+func (i *inquiry) ReplyByIDInquiry(ctx context.Context, req entity.InquiryReplyEntity, outbox entity.EmailOutboxEntity) error {
+	modelReply := model.InquiryReply{
+		InquiryID: req.InquiryID,
+		Message:   req.Message,
+	}
+	if req.UserID != 0 {
+		modelReply.UserID = &req.UserID
+	}
+
+	err := i.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&modelReply).Error; err != nil {
+			logger.Error(ctx, logger.Repository, "ReplyByIDInquiry", 1, err)
+			return dbError(err)
+		}
+
+		now := time.Now()
+		err := tx.Model(&model.Inquiry{}).Where("id = ?", req.InquiryID).Updates(map[string]interface{}{
+			"read_at":    gorm.Expr("COALESCE(read_at, ?)", now),
+			"updated_at": now,
+		}).Error
+		if err != nil {
+			logger.Error(ctx, logger.Repository, "ReplyByIDInquiry", 2, err)
+			return dbError(err)
+		}
+
+		if err := createEmailOutbox(tx, outbox); err != nil {
+			logger.Error(ctx, logger.Repository, "ReplyByIDInquiry", 3, err)
+			return dbError(err)
+		}
+		return nil
+	})
+	return dbError(err)
+}
+
+// DeleteByIDInquiry implements InquiryInterface.
+func (i *inquiry) DeleteByIDInquiry(ctx context.Context, id int64) error {
+	modelInquiry := model.Inquiry{}
+
+	err := i.DB.WithContext(ctx).Where("id = ?", id).First(&modelInquiry).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDInquiry", 1, err)
+		return dbError(err)
+	}
+
+	err = i.DB.WithContext(ctx).Delete(&modelInquiry).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDInquiry", 2, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+// CountUnreadInquiry implements InquiryInterface.
+func (i *inquiry) CountUnreadInquiry(ctx context.Context) (int64, error) {
+	var count int64
+	err := i.DB.WithContext(ctx).Model(&model.Inquiry{}).Where("read_at IS NULL AND archived_at IS NULL").Count(&count).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "CountUnreadInquiry", 1, err)
+		return 0, dbError(err)
+	}
+	return count, nil
+}
+
+func (i *inquiry) updateInquiry(ctx context.Context, id int64, column string, value *time.Time, op string) error {
+	modelInquiry := model.Inquiry{}
+
+	err := i.DB.WithContext(ctx).Where("id = ?", id).First(&modelInquiry).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, op, 1, err)
+		return dbError(err)
+	}
+
+	err = i.DB.WithContext(ctx).Model(&modelInquiry).Updates(map[string]interface{}{
+		column:       value,
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, op, 2, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+func inquiryEntity(v model.Inquiry) entity.InquiryEntity {
+	result := entity.InquiryEntity{
+		ID:         v.ID,
+		Name:       v.Name,
+		Email:      v.Email,
+		Subject:    v.Subject,
+		Message:    v.Message,
+		ReadAt:     v.ReadAt,
+		ArchivedAt: v.ArchivedAt,
+		CreatedAt:  v.CreatedAt,
+	}
+	if v.PhoneNumber != nil {
+		result.PhoneNumber = *v.PhoneNumber
+	}
+	if v.IpAddress != nil {
+		result.IpAddress = *v.IpAddress
+	}
+
+	for _, r := range v.Replies {
+		reply := entity.InquiryReplyEntity{
+			ID:        r.ID,
+			InquiryID: r.InquiryID,
+			Message:   r.Message,
+			CreatedAt: r.CreatedAt,
+		}
+		if r.UserID != nil {
+			reply.UserID = *r.UserID
+		}
+		result.Replies = append(result.Replies, reply)
+	}
+	return result
+}
+
+func NewInquiryRepository(DB *gorm.DB) InquiryInterface {
+	return &inquiry{
+		DB: DB,
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"desadangdang/utils/logger"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LastModifiedInterface interface {
+	FetchLastModified(ctx context.Context, tables []string) (time.Time, error)
+}
+
+type lastModified struct {
+	DB *gorm.DB
+}
+
+// FetchLastModified implements LastModifiedInterface.
+// It returns when a row of the tables last changed, a soft delete included,
+// or the zero time when they have no rows.
+func (l *lastModified) FetchLastModified(ctx context.Context, tables []string) (time.Time, error) {
+	queries := make([]string, 0, len(tables))
+	for _, table := range tables {
+		queries = append(queries, "SELECT GREATEST(MAX(updated_at), MAX(deleted_at)) AS changed_at FROM "+table)
+	}
+
+	var changedAt sql.NullTime
+	row := l.DB.WithContext(ctx).Raw("SELECT MAX(changed_at) FROM (" + strings.Join(queries, " UNION ALL ") + ") AS t").Row()
+	if err := row.Scan(&changedAt); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchLastModified", 1, err)
+		return time.Time{}, dbError(err)
+	}
+	if !changedAt.Valid {
+		return time.Time{}, nil
+	}
+
+	// the columns are timestamps without time zone holding the local time
+	// the app wrote, they are read back as if it was UTC
+	t := changedAt.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local), nil
+}
+
+func NewLastModifiedRepository(DB *gorm.DB) LastModifiedInterface {
+	return &lastModified{
+		DB: DB,
+	}
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"context"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// mediaUsageSources lists every column that may hold an uploaded file url.
+// Rich text columns can embed a file anywhere, so all columns are matched on
+// the unique file key rather than the full url. Soft deleted rows still count
+// because they can be restored from the trash.
+var mediaUsageSources = []struct {
+	Table  string
+	Column string
+}{
+	{"hero_sections", "path_banner"},
+	{"hero_sections", "path_video"},
+	{"client_sections", "path_icon"},
+	{"about_companies", "description"},
+	{"about_company_keynotes", "path_image"},
+	{"service_sections", "path_icon"},
+	{"service_details", "path_image"},
+	{"service_details", "path_pdf"},
+	{"service_details", "path_docx"},
+	{"service_details", "description"},
+	{"portofolio_sections", "thumbnail"},
+	{"portofolio_details", "description"},
+	{"portofolio_testimonials", "thumbnail"},
+	{"our_teams", "path_photo"},
+	{"posts", "featured_image"},
+	{"posts", "content"},
+	{"profiles", "content"},
+}
+
+type MediaInterface interface {
+	CreateMedia(ctx context.Context, req entity.MediaEntity) (int64, error)
+	FetchAllMedia(ctx context.Context, filter entity.MediaFilter) ([]entity.MediaEntity, error)
+	FetchByIDMedia(ctx context.Context, id int64) (*entity.MediaEntity, error)
+	EditAltTextByIDMedia(ctx context.Context, id int64, altText string) error
+	DeleteByIDMedia(ctx context.Context, id int64) error
+	FetchUsageMedia(ctx context.Context, fileKey string) ([]entity.MediaUsageEntity, error)
+	FetchOrphanMedia(ctx context.Context, before time.Time) ([]entity.MediaEntity, error)
+}
+
+type media struct {
+	DB *gorm.DB
+}
+
+// CreateMedia implements MediaInterface.
+func (m *media) CreateMedia(ctx context.Context, req entity.MediaEntity) (int64, error) {
+	modelMedia := model.Media{
+		FileKey:     req.FileKey,
+		Path:        req.Path,
+		URL:         req.URL,
+		Purpose:     req.Purpose,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+	}
+	if req.UserID != 0 {
+		modelMedia.UserID = &req.UserID
+	}
+	if req.Width != 0 {
+		modelMedia.Width = &req.Width
+		modelMedia.Height = &req.Height
+	}
+	if req.AltText != "" {
+		modelMedia.AltText = &req.AltText
+	}
+	if req.OriginalName != "" {
+		modelMedia.OriginalName = &req.OriginalName
+	}
+
+	if err := m.DB.WithContext(ctx).Create(&modelMedia).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateMedia", 1, err)
+		return 0, dbError(err)
+	}
+	return modelMedia.ID, nil
+}
+
+// FetchAllMedia implements MediaInterface.
+func (m *media) FetchAllMedia(ctx context.Context, filter entity.MediaFilter) ([]entity.MediaEntity, error) {
+	modelMedia := []model.Media{}
+
+	query := m.DB.WithContext(ctx).Model(&model.Media{})
+	if filter.Purpose != "" {
+		query = query.Where("purpose = ?", filter.Purpose)
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("(original_name ILIKE ? OR alt_text ILIKE ? OR path ILIKE ?)", search, search, search)
+	}
+
+	if err := query.Order("created_at DESC").Find(&modelMedia).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllMedia", 1, err)
+		return nil, dbError(err)
+	}
+
+	var mediaEntities []entity.MediaEntity
+	for _, v := range modelMedia {
+		mediaEntities = append(mediaEntities, mediaEntity(v))
+	}
+	return mediaEntities, nil
+}
+
+// FetchByIDMedia implements MediaInterface.
+func (m *media) FetchByIDMedia(ctx context.Context, id int64) (*entity.MediaEntity, error) {
+	modelMedia := model.Media{}
+
+	if err := m.DB.WithContext(ctx).Where("id = ?", id).First(&modelMedia).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDMedia", 1, err)
+		return nil, dbError(err)
+	}
+
+	result := mediaEntity(modelMedia)
+	return &result, nil
+}
+
+// EditAltTextByIDMedia implements MediaInterface.
+func (m *media) EditAltTextByIDMedia(ctx context.Context, id int64, altText string) error {
+	modelMedia := model.Media{}
+
+	if err := m.DB.WithContext(ctx).Where("id = ?", id).First(&modelMedia).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditAltTextByIDMedia", 1, err)
+		return dbError(err)
+	}
+
+	var value *string
+	if altText != "" {
+		value = &altText
+	}
+	err := m.DB.WithContext(ctx).Model(&modelMedia).Updates(map[string]interface{}{
+		"alt_text":   value,
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "EditAltTextByIDMedia", 2, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+// DeleteByIDMedia implements MediaInterface.
+func (m *media) DeleteByIDMedia(ctx context.Context, id int64) error {
+	if err := m.DB.WithContext(ctx).Where("id = ?", id).Delete(&model.Media{}).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDMedia", 1, err)
+		return dbError(err)
+	}
+	return nil
+}
+
+// FetchUsageMedia implements MediaInterface.
+func (m *media) FetchUsageMedia(ctx context.Context, fileKey string) ([]entity.MediaUsageEntity, error) {
+	var (
+		queries []string
+		args    []interface{}
+		pattern = "%" + escapeLike(fileKey) + "%"
+	)
+	for _, source := range mediaUsageSources {
+		queries = append(queries, fmt.Sprintf("SELECT '%s' AS resource, id AS resource_id, '%s' AS field FROM %s WHERE %s LIKE ?",
+			source.Table, source.Column, source.Table, source.Column))
+		args = append(args, pattern)
+	}
+
+	var rows []struct {
+		Resource   string
+		ResourceID int64
+		Field      string
+	}
+	if err := m.DB.WithContext(ctx).Raw(strings.Join(queries, " UNION ALL ")+" ORDER BY resource, resource_id", args...).Scan(&rows).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchUsageMedia", 1, err)
+		return nil, dbError(err)
+	}
+
+	var usages []entity.MediaUsageEntity
+	for _, r := range rows {
+		usages = append(usages, entity.MediaUsageEntity{
+			Resource:   r.Resource,
+			ResourceID: r.ResourceID,
+			Field:      r.Field,
+		})
+	}
+	return usages, nil
+}
+
+// FetchOrphanMedia implements MediaInterface.
+// Only files uploaded before the given time are returned, so an upload that
+// has not been attached to its form yet is not picked up.
+func (m *media) FetchOrphanMedia(ctx context.Context, before time.Time) ([]entity.MediaEntity, error) {
+	modelMedia := []model.Media{}
+
+	query := m.DB.WithContext(ctx).Where("created_at < ?", before)
+	for _, source := range mediaUsageSources {
+		query = query.Where(fmt.Sprintf(
+			`NOT EXISTS (SELECT 1 FROM %s WHERE %s LIKE '%%' || replace(media.file_key, '_', '\_') || '%%')`,
+			source.Table, source.Column))
+	}
+
+	if err := query.Order("created_at ASC").Find(&modelMedia).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchOrphanMedia", 1, err)
+		return nil, dbError(err)
+	}
+
+	var mediaEntities []entity.MediaEntity
+	for _, v := range modelMedia {
+		mediaEntities = append(mediaEntities, mediaEntity(v))
+	}
+	return mediaEntities, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func mediaEntity(v model.Media) entity.MediaEntity {
+	result := entity.MediaEntity{
+		ID:          v.ID,
+		FileKey:     v.FileKey,
+		Path:        v.Path,
+		URL:         v.URL,
+		Purpose:     v.Purpose,
+		ContentType: v.ContentType,
+		Size:        v.Size,
+		CreatedAt:   v.CreatedAt,
+	}
+	if v.UserID != nil {
+		result.UserID = *v.UserID
+	}
+	if v.Width != nil {
+		result.Width = *v.Width
+	}
+	if v.Height != nil {
+		result.Height = *v.Height
+	}
+	if v.AltText != nil {
+		result.AltText = *v.AltText
+	}
+	if v.OriginalName != nil {
+		result.OriginalName = *v.OriginalName
+	}
+	return result
+}
+
+func NewMediaRepository(DB *gorm.DB) MediaInterface {
+	return &media{
+		DB: DB,
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+// nullString stores an empty string as NULL.
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// stringValue reads a nullable text column.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -24,15 +24,17 @@ type ourTeamRepository struct {
 // CreateOurTeam implements OurTeamInterface.
 func (h *ourTeamRepository) CreateOurTeam(ctx context.Context, req entity.OurTeamEntity) error {
 	modelOurTeam := model.OurTeam{
-		Name:      req.Name,
-		Role:      req.Role,
-		PathPhoto: req.PathPhoto,
-		Tagline:   req.Tagline,
+		Name:         req.Name,
+		Role:         req.Role,
+		PathPhoto:    req.PathPhoto,
+		PhotoAlt:     nullString(req.PhotoAlt),
+		PhotoCaption: nullString(req.PhotoCaption),
+		Tagline:      req.Tagline,
 	}
 
-	if err = h.DB.Create(&modelOurTeam).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateOurTeam - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelOurTeam).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateOurTeam", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -41,16 +43,16 @@ func (h *ourTeamRepository) CreateOurTeam(ctx context.Context, req entity.OurTea
 func (h *ourTeamRepository) DeleteByIDOurTeam(ctx context.Context, id int64) error {
 	modelOurTeam := model.OurTeam{}
 
-	err = h.DB.Where("id = ?", id).First(&modelOurTeam).Error
+	err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelOurTeam).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDOurTeam - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDOurTeam", 1, err)
+		return dbError(err)
 	}
 
-	err = h.DB.Delete(&modelOurTeam).Error
+	err = h.DB.WithContext(ctx).Delete(&modelOurTeam).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDOurTeam - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDOurTeam", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -59,19 +61,21 @@ func (h *ourTeamRepository) DeleteByIDOurTeam(ctx context.Context, id int64) err
 func (h *ourTeamRepository) EditByIDOurTeam(ctx context.Context, req entity.OurTeamEntity) error {
 	modelOurTeam := model.OurTeam{}
 
-	err = h.DB.Where("id =?", req.ID).First(&modelOurTeam).Error
+	err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelOurTeam).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDOurTeam - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDOurTeam", 1, err)
+		return dbError(err)
 	}
 	modelOurTeam.Name = req.Name
 	modelOurTeam.Role = req.Role
 	modelOurTeam.PathPhoto = req.PathPhoto
+	modelOurTeam.PhotoAlt = nullString(req.PhotoAlt)
+	modelOurTeam.PhotoCaption = nullString(req.PhotoCaption)
 	modelOurTeam.Tagline = req.Tagline
-	err = h.DB.Save(&modelOurTeam).Error
+	err = h.DB.WithContext(ctx).Save(&modelOurTeam).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDOurTeam - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDOurTeam", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -79,20 +83,22 @@ func (h *ourTeamRepository) EditByIDOurTeam(ctx context.Context, req entity.OurT
 // FetchAllOurTeam implements OurTeamInterface.
 func (h *ourTeamRepository) FetchAllOurTeam(ctx context.Context) ([]entity.OurTeamEntity, error) {
 	modelOurTeam := []model.OurTeam{}
-	err = h.DB.Select("id", "name", "role", "path_photo", "tagline").Find(&modelOurTeam).Order("created_at DESC").Error
+	err := h.DB.WithContext(ctx).Select("id", "name", "role", "path_photo", "photo_alt", "photo_caption", "tagline").Find(&modelOurTeam).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllOurTeam - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllOurTeam", 1, err)
+		return nil, dbError(err)
 	}
 
 	var ourTeamRepositoryEntities []entity.OurTeamEntity
 	for _, v := range modelOurTeam {
 		ourTeamRepositoryEntities = append(ourTeamRepositoryEntities, entity.OurTeamEntity{
-			ID:        v.ID,
-			Name:      v.Name,
-			PathPhoto: v.PathPhoto,
-			Tagline:   v.Tagline,
-			Role:      v.Role,
+			ID:           v.ID,
+			Name:         v.Name,
+			PathPhoto:    v.PathPhoto,
+			PhotoAlt:     stringValue(v.PhotoAlt),
+			PhotoCaption: stringValue(v.PhotoCaption),
+			Tagline:      v.Tagline,
+			Role:         v.Role,
 		})
 	}
 
@@ -102,18 +108,20 @@ func (h *ourTeamRepository) FetchAllOurTeam(ctx context.Context) ([]entity.OurTe
 // FetchByIDOurTeam implements OurTeamInterface.
 func (h *ourTeamRepository) FetchByIDOurTeam(ctx context.Context, id int64) (*entity.OurTeamEntity, error) {
 	modelOurTeam := model.OurTeam{}
-	err = h.DB.Select("id", "name", "role", "path_photo", "tagline").Where("id = ?", id).First(&modelOurTeam).Error
+	err = h.DB.WithContext(ctx).Select("id", "name", "role", "path_photo", "photo_alt", "photo_caption", "tagline").Where("id = ?", id).First(&modelOurTeam).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDOurTeam - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDOurTeam", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.OurTeamEntity{
-		ID:        modelOurTeam.ID,
-		Name:      modelOurTeam.Name,
-		PathPhoto: modelOurTeam.PathPhoto,
-		Tagline:   modelOurTeam.Tagline,
-		Role:      modelOurTeam.Role,
+		ID:           modelOurTeam.ID,
+		Name:         modelOurTeam.Name,
+		PathPhoto:    modelOurTeam.PathPhoto,
+		PhotoAlt:     stringValue(modelOurTeam.PhotoAlt),
+		PhotoCaption: stringValue(modelOurTeam.PhotoCaption),
+		Tagline:      modelOurTeam.Tagline,
+		Role:         modelOurTeam.Role,
 	}, nil
 }
 
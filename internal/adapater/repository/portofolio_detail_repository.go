@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -25,7 +25,7 @@ type portofolioDetailRepository struct {
 
 // FetchDetailPotofolioByPortoID implements PortofolioDetailRepositoryInterface.
 func (h *portofolioDetailRepository) FetchDetailPotofolioByPortoID(ctx context.Context, portoID int64) (*entity.PortofolioDetailEntity, error) {
-	rows, err := h.DB.
+	rows, err := h.DB.WithContext(ctx).
 		Table("portofolio_details as pd").
 		Select("pd.id", "pd.title", "pd.category", "pd.client_name",
 			"pd.project_date", "pd.description", "pd.project_url", "ps.id", "ps.name", "ps.thumbnail").
@@ -34,9 +34,10 @@ func (h *portofolioDetailRepository) FetchDetailPotofolioByPortoID(ctx context.C
 		Order("ps.created_at DESC").
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchDetailPotofolioByPortoID - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchDetailPotofolioByPortoID", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	var portofolioDetailEntity entity.PortofolioDetailEntity
 	for rows.Next() {
@@ -52,11 +53,19 @@ func (h *portofolioDetailRepository) FetchDetailPotofolioByPortoID(ctx context.C
 			&portofolioDetailEntity.PortofolioSection.Thumbnail)
 
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchDetailPotofolioByPortoID - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchDetailPotofolioByPortoID", 2, err)
+			return nil, dbError(err)
 		}
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchDetailPotofolioByPortoID", 3, err)
+		return nil, dbError(err)
+	}
+	if portofolioDetailEntity.ID == 0 {
+		return nil, dbError(gorm.ErrRecordNotFound)
+	}
+
 	return &portofolioDetailEntity, nil
 }
 
@@ -72,9 +81,9 @@ func (h *portofolioDetailRepository) CreatePortofolioDetail(ctx context.Context,
 		Description:         req.Description,
 	}
 
-	if err = h.DB.Create(&modelPortofolioDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreatePortofolioDetail - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelPortofolioDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreatePortofolioDetail", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -83,14 +92,14 @@ func (h *portofolioDetailRepository) CreatePortofolioDetail(ctx context.Context,
 func (h *portofolioDetailRepository) DeleteByIDPortofolioDetail(ctx context.Context, id int64) error {
 	modelPortofolioDetail := model.PortofolioDetail{}
 
-	if err = h.DB.Where("id = ?", id).First(&modelPortofolioDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPortofolioDetail - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelPortofolioDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDPortofolioDetail", 1, err)
+		return dbError(err)
 	}
 
-	if err = h.DB.Delete(&modelPortofolioDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPortofolioDetail - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Delete(&modelPortofolioDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDPortofolioDetail", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -99,9 +108,9 @@ func (h *portofolioDetailRepository) DeleteByIDPortofolioDetail(ctx context.Cont
 func (h *portofolioDetailRepository) EditByIDPortofolioDetail(ctx context.Context, req entity.PortofolioDetailEntity) error {
 	modelPortofolioDetail := model.PortofolioDetail{}
 
-	if err = h.DB.Where("id =?", req.ID).First(&modelPortofolioDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPortofolioDetail - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelPortofolioDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDPortofolioDetail", 1, err)
+		return dbError(err)
 	}
 	modelPortofolioDetail.Title = req.Title
 	modelPortofolioDetail.Description = req.Description
@@ -111,16 +120,16 @@ func (h *portofolioDetailRepository) EditByIDPortofolioDetail(ctx context.Contex
 	modelPortofolioDetail.ProjectUrl = &req.ProjectUrl
 	modelPortofolioDetail.PortofolioSectionID = req.PortofolioSection.ID
 
-	if err = h.DB.Save(&modelPortofolioDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPortofolioDetail - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Save(&modelPortofolioDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDPortofolioDetail", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
 
 // FetchAllPortofolioDetail implements PortofolioDetailInterface.
 func (h *portofolioDetailRepository) FetchAllPortofolioDetail(ctx context.Context) ([]entity.PortofolioDetailEntity, error) {
-	rows, err := h.DB.
+	rows, err := h.DB.WithContext(ctx).
 		Table("portofolio_details as pd").
 		Select("pd.id", "pd.title", "pd.category", "pd.client_name", "pd.project_date", "ps.name").
 		Joins("inner join portofolio_sections as ps on ps.id = pd.portofolio_section_id").
@@ -129,9 +138,10 @@ func (h *portofolioDetailRepository) FetchAllPortofolioDetail(ctx context.Contex
 		Rows()
 
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllPortofolioDetail - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllPortofolioDetail", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	var portofolioDetailRepositoryEntities []entity.PortofolioDetailEntity
 	for rows.Next() {
@@ -144,19 +154,24 @@ func (h *portofolioDetailRepository) FetchAllPortofolioDetail(ctx context.Contex
 			&portofolioDetail.PortofolioSection.Name)
 
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchAllPortofolioDetail - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchAllPortofolioDetail", 2, err)
+			return nil, dbError(err)
 		}
 
 		portofolioDetailRepositoryEntities = append(portofolioDetailRepositoryEntities, portofolioDetail)
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllPortofolioDetail", 3, err)
+		return nil, dbError(err)
+	}
+
 	return portofolioDetailRepositoryEntities, nil
 }
 
 // FetchByIDPortofolioDetail implements PortofolioDetailInterface.
 func (h *portofolioDetailRepository) FetchByIDPortofolioDetail(ctx context.Context, id int64) (*entity.PortofolioDetailEntity, error) {
-	rows, err := h.DB.
+	rows, err := h.DB.WithContext(ctx).
 		Table("portofolio_details as pd").
 		Select("pd.id", "pd.title", "pd.category", "pd.client_name", "pd.project_date", "pd.description", "pd.project_url", "ps.id", "ps.name", "ps.thumbnail").
 		Joins("inner join portofolio_sections as ps on ps.id = pd.portofolio_section_id").
@@ -164,9 +179,10 @@ func (h *portofolioDetailRepository) FetchByIDPortofolioDetail(ctx context.Conte
 		Order("pd.created_at DESC").
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDPortofolioDetail - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDPortofolioDetail", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	var portofolioDetailEntity entity.PortofolioDetailEntity
 	for rows.Next() {
@@ -182,11 +198,19 @@ func (h *portofolioDetailRepository) FetchByIDPortofolioDetail(ctx context.Conte
 			&portofolioDetailEntity.PortofolioSection.Thumbnail)
 
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchByIDPortofolioDetail - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchByIDPortofolioDetail", 2, err)
+			return nil, dbError(err)
 		}
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDPortofolioDetail", 3, err)
+		return nil, dbError(err)
+	}
+	if portofolioDetailEntity.ID == 0 {
+		return nil, dbError(gorm.ErrRecordNotFound)
+	}
+
 	return &portofolioDetailEntity, nil
 }
 
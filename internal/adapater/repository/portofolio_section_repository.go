@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -24,14 +24,16 @@ type portofolioSectionRepository struct {
 // CreatePortofolioSection implements PortofolioSectionInterface.
 func (h *portofolioSectionRepository) CreatePortofolioSection(ctx context.Context, req entity.PortofolioSectionEntity) error {
 	modelPortofolioSection := model.PortofolioSection{
-		Thumbnail: &req.Thumbnail,
-		Name:      req.Name,
-		Tagline:   req.Tagline,
+		Thumbnail:        &req.Thumbnail,
+		ThumbnailAlt:     nullString(req.ThumbnailAlt),
+		ThumbnailCaption: nullString(req.ThumbnailCaption),
+		Name:             req.Name,
+		Tagline:          req.Tagline,
 	}
 
-	if err = h.DB.Create(&modelPortofolioSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreatePortofolioSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelPortofolioSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreatePortofolioSection", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -40,14 +42,20 @@ func (h *portofolioSectionRepository) CreatePortofolioSection(ctx context.Contex
 func (h *portofolioSectionRepository) DeleteByIDPortofolioSection(ctx context.Context, id int64) error {
 	modelPortofolioSection := model.PortofolioSection{}
 
-	if err = h.DB.Where("id = ?", id).First(&modelPortofolioSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPortofolioSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelPortofolioSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDPortofolioSection", 1, err)
+		return dbError(err)
 	}
 
-	if err = h.DB.Delete(&modelPortofolioSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPortofolioSection - 2: %v", err)
-		return err
+	err = h.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&modelPortofolioSection).Error; err != nil {
+			return dbError(err)
+		}
+		return softDeleteChildren(tx, "portofolio-sections", id)
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDPortofolioSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -56,17 +64,19 @@ func (h *portofolioSectionRepository) DeleteByIDPortofolioSection(ctx context.Co
 func (h *portofolioSectionRepository) EditByIDPortofolioSection(ctx context.Context, req entity.PortofolioSectionEntity) error {
 	modelPortofolioSection := model.PortofolioSection{}
 
-	if err = h.DB.Where("id =?", req.ID).First(&modelPortofolioSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPortofolioSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelPortofolioSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDPortofolioSection", 1, err)
+		return dbError(err)
 	}
 	modelPortofolioSection.Name = req.Name
 	modelPortofolioSection.Tagline = req.Tagline
 	modelPortofolioSection.Thumbnail = &req.Thumbnail
+	modelPortofolioSection.ThumbnailAlt = nullString(req.ThumbnailAlt)
+	modelPortofolioSection.ThumbnailCaption = nullString(req.ThumbnailCaption)
 
-	if err = h.DB.Save(&modelPortofolioSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPortofolioSection - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Save(&modelPortofolioSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDPortofolioSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -74,18 +84,20 @@ func (h *portofolioSectionRepository) EditByIDPortofolioSection(ctx context.Cont
 // FetchAllPortofolioSection implements PortofolioSectionInterface.
 func (h *portofolioSectionRepository) FetchAllPortofolioSection(ctx context.Context) ([]entity.PortofolioSectionEntity, error) {
 	modelPortofolioSection := []model.PortofolioSection{}
-	if err = h.DB.Select("id", "thumbnail", "tagline", "name").Find(&modelPortofolioSection).Order("created_at DESC").Error; err != nil {
-		log.Errorf("[REPOSITORY] FetchAllPortofolioSection - 1: %v", err)
-		return nil, err
+	if err := h.DB.WithContext(ctx).Select("id", "thumbnail", "thumbnail_alt", "thumbnail_caption", "tagline", "name").Find(&modelPortofolioSection).Order("created_at DESC").Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllPortofolioSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	var portofolioSectionRepositoryEntities []entity.PortofolioSectionEntity
 	for _, v := range modelPortofolioSection {
 		portofolioSectionRepositoryEntities = append(portofolioSectionRepositoryEntities, entity.PortofolioSectionEntity{
-			ID:        v.ID,
-			Thumbnail: *v.Thumbnail,
-			Name:      v.Name,
-			Tagline:   v.Tagline,
+			ID:               v.ID,
+			Thumbnail:        *v.Thumbnail,
+			ThumbnailAlt:     stringValue(v.ThumbnailAlt),
+			ThumbnailCaption: stringValue(v.ThumbnailCaption),
+			Name:             v.Name,
+			Tagline:          v.Tagline,
 		})
 	}
 
@@ -95,16 +107,18 @@ func (h *portofolioSectionRepository) FetchAllPortofolioSection(ctx context.Cont
 // FetchByIDPortofolioSection implements PortofolioSectionInterface.
 func (h *portofolioSectionRepository) FetchByIDPortofolioSection(ctx context.Context, id int64) (*entity.PortofolioSectionEntity, error) {
 	modelPortofolioSection := model.PortofolioSection{}
-	if err = h.DB.Select("id", "thumbnail", "tagline", "name").Where("id = ?", id).First(&modelPortofolioSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDPortofolioSection - 1: %v", err)
-		return nil, err
+	if err = h.DB.WithContext(ctx).Select("id", "thumbnail", "thumbnail_alt", "thumbnail_caption", "tagline", "name").Where("id = ?", id).First(&modelPortofolioSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDPortofolioSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.PortofolioSectionEntity{
-		ID:        modelPortofolioSection.ID,
-		Thumbnail: *modelPortofolioSection.Thumbnail,
-		Name:      modelPortofolioSection.Name,
-		Tagline:   modelPortofolioSection.Tagline,
+		ID:               modelPortofolioSection.ID,
+		Thumbnail:        *modelPortofolioSection.Thumbnail,
+		ThumbnailAlt:     stringValue(modelPortofolioSection.ThumbnailAlt),
+		ThumbnailCaption: stringValue(modelPortofolioSection.ThumbnailCaption),
+		Name:             modelPortofolioSection.Name,
+		Tagline:          modelPortofolioSection.Tagline,
 	}, nil
 }
 
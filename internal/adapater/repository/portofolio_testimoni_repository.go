@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -31,9 +31,9 @@ func (h *portofolioTestimonialRepository) CreatePortofolioTestimonial(ctx contex
 		Role:                req.Role,
 	}
 
-	if err = h.DB.Create(&modelPortofolioTestimonial).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreatePortofolioTestimonial - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelPortofolioTestimonial).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreatePortofolioTestimonial", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -42,14 +42,14 @@ func (h *portofolioTestimonialRepository) CreatePortofolioTestimonial(ctx contex
 func (h *portofolioTestimonialRepository) DeleteByIDPortofolioTestimonial(ctx context.Context, id int64) error {
 	modelPortofolioTestimonial := model.PortofolioTestimonial{}
 
-	if err = h.DB.Where("id = ?", id).First(&modelPortofolioTestimonial).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPortofolioTestimonial - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelPortofolioTestimonial).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDPortofolioTestimonial", 1, err)
+		return dbError(err)
 	}
 
-	if err = h.DB.Delete(&modelPortofolioTestimonial).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPortofolioTestimonial - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Delete(&modelPortofolioTestimonial).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDPortofolioTestimonial", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -58,9 +58,9 @@ func (h *portofolioTestimonialRepository) DeleteByIDPortofolioTestimonial(ctx co
 func (h *portofolioTestimonialRepository) EditByIDPortofolioTestimonial(ctx context.Context, req entity.PortofolioTestimonialEntity) error {
 	modelPortofolioTestimonial := model.PortofolioTestimonial{}
 
-	if err = h.DB.Where("id =?", req.ID).First(&modelPortofolioTestimonial).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPortofolioTestimonial - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelPortofolioTestimonial).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDPortofolioTestimonial", 1, err)
+		return dbError(err)
 	}
 	modelPortofolioTestimonial.Thumbnail = req.Thumbnail
 	modelPortofolioTestimonial.Message = req.Message
@@ -68,16 +68,16 @@ func (h *portofolioTestimonialRepository) EditByIDPortofolioTestimonial(ctx cont
 	modelPortofolioTestimonial.Role = req.Role
 	modelPortofolioTestimonial.PortofolioSectionID = req.PortofolioSection.ID
 
-	if err = h.DB.Save(&modelPortofolioTestimonial).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPortofolioTestimonial - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Save(&modelPortofolioTestimonial).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDPortofolioTestimonial", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
 
 // FetchAllPortofolioTestimonial implements PortofolioTestimonialInterface.
 func (h *portofolioTestimonialRepository) FetchAllPortofolioTestimonial(ctx context.Context) ([]entity.PortofolioTestimonialEntity, error) {
-	rows, err := h.DB.
+	rows, err := h.DB.WithContext(ctx).
 		Table("portofolio_testimonials as pd").
 		Select("pd.id", "pd.thumbnail", "pd.message", "pd.client_name", "pd.role", "ps.name").
 		Joins("inner join portofolio_sections as ps on ps.id = pd.portofolio_section_id").
@@ -86,9 +86,10 @@ func (h *portofolioTestimonialRepository) FetchAllPortofolioTestimonial(ctx cont
 		Rows()
 
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllPortofolioTestimonial - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllPortofolioTestimonial", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	var portofolioTestimonialRepositoryEntities []entity.PortofolioTestimonialEntity
 	for rows.Next() {
@@ -101,19 +102,24 @@ func (h *portofolioTestimonialRepository) FetchAllPortofolioTestimonial(ctx cont
 			&portofolioTestimonial.PortofolioSection.Name)
 
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchAllPortofolioTestimonial - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchAllPortofolioTestimonial", 2, err)
+			return nil, dbError(err)
 		}
 
 		portofolioTestimonialRepositoryEntities = append(portofolioTestimonialRepositoryEntities, portofolioTestimonial)
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllPortofolioTestimonial", 3, err)
+		return nil, dbError(err)
+	}
+
 	return portofolioTestimonialRepositoryEntities, nil
 }
 
 // FetchByIDPortofolioTestimonial implements PortofolioTestimonialInterface.
 func (h *portofolioTestimonialRepository) FetchByIDPortofolioTestimonial(ctx context.Context, id int64) (*entity.PortofolioTestimonialEntity, error) {
-	rows, err := h.DB.
+	rows, err := h.DB.WithContext(ctx).
 		Table("portofolio_testimonials as pd").
 		Select("pd.id", "pd.thumbnail", "pd.message", "pd.client_name", "pd.role", "ps.id", "ps.name", "ps.thumbnail").
 		Joins("inner join portofolio_sections as ps on ps.id = pd.portofolio_section_id").
@@ -121,9 +127,10 @@ func (h *portofolioTestimonialRepository) FetchByIDPortofolioTestimonial(ctx con
 		Order("pd.created_at DESC").
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDPortofolioTestimonial - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDPortofolioTestimonial", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	var portofolioTestimonialEntity entity.PortofolioTestimonialEntity
 	for rows.Next() {
@@ -137,11 +144,19 @@ func (h *portofolioTestimonialRepository) FetchByIDPortofolioTestimonial(ctx con
 			&portofolioTestimonialEntity.PortofolioSection.Thumbnail)
 
 		if err != nil {
-			log.Errorf("[REPOSITORY] FetchByIDPortofolioTestimonial - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "FetchByIDPortofolioTestimonial", 2, err)
+			return nil, dbError(err)
 		}
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDPortofolioTestimonial", 3, err)
+		return nil, dbError(err)
+	}
+	if portofolioTestimonialEntity.ID == 0 {
+		return nil, dbError(gorm.ErrRecordNotFound)
+	}
+
 	return &portofolioTestimonialEntity, nil
 }
 
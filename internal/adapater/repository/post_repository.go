@@ -2,12 +2,13 @@ package repository
 
 import (
 	"context"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 	"fmt"
 
 	"github.com/gosimple/slug"
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -18,21 +19,31 @@ type PostInterface interface {
 	FetchBySlugPost(ctx context.Context, slug string) (*entity.PostEntity, error)
 	EditByIDPost(ctx context.Context, req entity.PostEntity) error
 	DeleteByIDPost(ctx context.Context, id int64) error
-	CheckSlugUnique(slug string, id int64) bool
+	CheckSlugUnique(ctx context.Context, slug string, id int64) (bool, error)
 }
 
+// postSlugIndex keeps the slugs of the live posts unique.
+const postSlugIndex = "idx_posts_slug"
+
 type post struct {
 	DB *gorm.DB
 }
 
-func (p *post) CheckSlugUnique(slug string, id int64) bool {
+// CheckSlugUnique implements PostInterface.
+// The unique index on the slug still decides when two requests race.
+func (p *post) CheckSlugUnique(ctx context.Context, slug string, id int64) (bool, error) {
 	var count int64
-	err := p.DB.Model(&model.Post{}).Where("slug = ? AND id != ?", slug, id).Count(&count).Error
+	err := p.DB.WithContext(ctx).Model(&model.Post{}).Where("slug = ? AND id != ?", slug, id).Count(&count).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] CheckSlugUnique - 1: %v", err)
-		return false
+		logger.Error(ctx, logger.Repository, "CheckSlugUnique", 1, err)
+		return false, dbError(err)
 	}
-	return count == 0
+	return count == 0, nil
+}
+
+// slugTaken is the error of a post whose slug another post has.
+func slugTaken(slug string) error {
+	return apperr.Conflict(apperr.CodeSlugTaken, fmt.Sprintf("slug '%s' already exists", slug))
 }
 
 // CreatePost implements PostInterface.
@@ -43,9 +54,13 @@ func (p *post) CreatePost(ctx context.Context, req entity.PostEntity) error {
 	}
 
 	// Check if the slug is unique
-	if !p.CheckSlugUnique(req.Slug, 0) { // Passing 0 for the ID because it's a new post
-		log.Errorf("[REPOSITORY] CreatePost - Slug '%s' already exists", req.Slug)
-		return fmt.Errorf("slug '%s' already exists", req.Slug)
+	unique, err := p.CheckSlugUnique(ctx, req.Slug, 0) // Passing 0 for the ID because it's a new post
+	if err != nil {
+		return err
+	}
+	if !unique {
+		logger.Errorf(ctx, logger.Repository, "CreatePost", 0, "slug %q already exists", req.Slug)
+		return slugTaken(req.Slug)
 	}
 
 	modelPost := model.Post{
@@ -53,13 +68,18 @@ func (p *post) CreatePost(ctx context.Context, req entity.PostEntity) error {
 		Slug:          req.Slug,
 		Author:        req.Author,
 		FeaturedImage: req.FeaturedImage,
+		FeaturedImageAlt: nullString(req.FeaturedImageAlt),
+		FeaturedImageCaption: nullString(req.FeaturedImageCaption),
 		Content:       req.Content,
 		PublishedAt:   req.PublishedAt,
 	}
 
-	if err := p.DB.Create(&modelPost).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreatePost - 1: %v", err)
-		return err
+	if err := p.DB.WithContext(ctx).Create(&modelPost).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreatePost", 1, err)
+		if isUniqueViolation(err, postSlugIndex) {
+			return slugTaken(req.Slug)
+		}
+		return dbError(err)
 	}
 	return nil
 }
@@ -68,16 +88,16 @@ func (p *post) CreatePost(ctx context.Context, req entity.PostEntity) error {
 func (p *post) DeleteByIDPost(ctx context.Context, id int64) error {
 	modelPost := model.Post{}
 
-	err := p.DB.Where("id = ?", id).First(&modelPost).Error
+	err := p.DB.WithContext(ctx).Where("id = ?", id).First(&modelPost).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPost - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDPost", 1, err)
+		return dbError(err)
 	}
 
-	err = p.DB.Delete(&modelPost).Error
+	err = p.DB.WithContext(ctx).Delete(&modelPost).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDPost - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDPost", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -90,17 +110,21 @@ func (p *post) EditByIDPost(ctx context.Context, req entity.PostEntity) error {
 	}
 
 	// Check if the slug is unique (except the post with the same ID)
-	if !p.CheckSlugUnique(req.Slug, req.ID) { // Passing the actual ID of the post being edited
-		log.Errorf("[REPOSITORY] EditByIDPost - Slug '%s' already exists", req.Slug)
-		return fmt.Errorf("slug '%s' already exists", req.Slug)
+	unique, err := p.CheckSlugUnique(ctx, req.Slug, req.ID) // Passing the actual ID of the post being edited
+	if err != nil {
+		return err
+	}
+	if !unique {
+		logger.Errorf(ctx, logger.Repository, "EditByIDPost", 0, "slug %q already exists", req.Slug)
+		return slugTaken(req.Slug)
 	}
 
 	modelPost := model.Post{}
 
-	err := p.DB.Where("id = ?", req.ID).First(&modelPost).Error
+	err = p.DB.WithContext(ctx).Where("id = ?", req.ID).First(&modelPost).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPost - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDPost", 1, err)
+		return dbError(err)
 	}
 
 	// Update the post fields
@@ -108,14 +132,19 @@ func (p *post) EditByIDPost(ctx context.Context, req entity.PostEntity) error {
 	modelPost.Slug = req.Slug
 	modelPost.Author = req.Author
 	modelPost.FeaturedImage = req.FeaturedImage
+	modelPost.FeaturedImageAlt = nullString(req.FeaturedImageAlt)
+	modelPost.FeaturedImageCaption = nullString(req.FeaturedImageCaption)
 	modelPost.Content = req.Content
 	modelPost.PublishedAt = req.PublishedAt
 
 	// Save the updated post
-	err = p.DB.Save(&modelPost).Error
+	err = p.DB.WithContext(ctx).Save(&modelPost).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDPost - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDPost", 2, err)
+		if isUniqueViolation(err, postSlugIndex) {
+			return slugTaken(req.Slug)
+		}
+		return dbError(err)
 	}
 	return nil
 }
@@ -123,10 +152,10 @@ func (p *post) EditByIDPost(ctx context.Context, req entity.PostEntity) error {
 // FetchAllPosts implements PostInterface.
 func (p *post) FetchAllPosts(ctx context.Context) ([]entity.PostEntity, error) {
 	modelPosts := []model.Post{}
-	err := p.DB.Select("id", "title", "slug", "author", "featured_image", "content", "published_at").Find(&modelPosts).Order("created_at DESC").Error
+	err := p.DB.WithContext(ctx).Select("id", "title", "slug", "author", "featured_image", "featured_image_alt", "featured_image_caption", "content", "published_at").Find(&modelPosts).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllPosts - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllPosts", 1, err)
+		return nil, dbError(err)
 	}
 
 	var postEntities []entity.PostEntity
@@ -137,6 +166,8 @@ func (p *post) FetchAllPosts(ctx context.Context) ([]entity.PostEntity, error) {
 			Slug:         v.Slug,
 			Author:       v.Author,
 			FeaturedImage: v.FeaturedImage,
+			FeaturedImageAlt: stringValue(v.FeaturedImageAlt),
+			FeaturedImageCaption: stringValue(v.FeaturedImageCaption),
 			Content:      v.Content,
 			PublishedAt:  v.PublishedAt, // Include PublishedAt field
 		})
@@ -148,10 +179,10 @@ func (p *post) FetchAllPosts(ctx context.Context) ([]entity.PostEntity, error) {
 // FetchByIDPost implements PostInterface.
 func (p *post) FetchByIDPost(ctx context.Context, id int64) (*entity.PostEntity, error) {
 	modelPost := model.Post{}
-	err := p.DB.Where("id = ?", id).First(&modelPost).Error
+	err := p.DB.WithContext(ctx).Where("id = ?", id).First(&modelPost).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDPost - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDPost", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.PostEntity{
@@ -160,6 +191,8 @@ func (p *post) FetchByIDPost(ctx context.Context, id int64) (*entity.PostEntity,
 		Slug:         modelPost.Slug,
 		Author:       modelPost.Author,
 		FeaturedImage: modelPost.FeaturedImage,
+		FeaturedImageAlt: stringValue(modelPost.FeaturedImageAlt),
+		FeaturedImageCaption: stringValue(modelPost.FeaturedImageCaption),
 		Content:      modelPost.Content,
 		PublishedAt:  modelPost.PublishedAt, // Include PublishedAt field
 	}, nil
@@ -167,10 +200,10 @@ func (p *post) FetchByIDPost(ctx context.Context, id int64) (*entity.PostEntity,
 
 func (p *post) FetchBySlugPost(ctx context.Context, slug string) (*entity.PostEntity, error) {
     modelPost := model.Post{}
-    err := p.DB.Where("slug = ?", slug).First(&modelPost).Error
+    err := p.DB.WithContext(ctx).Where("slug = ?", slug).First(&modelPost).Error
     if err != nil {
-        log.Errorf("[REPOSITORY] FetchBySlugPost - 1: %v", err)
-        return nil, err
+        logger.Error(ctx, logger.Repository, "FetchBySlugPost", 1, err)
+        return nil, dbError(err)
     }
 
     return &entity.PostEntity{
@@ -179,6 +212,8 @@ func (p *post) FetchBySlugPost(ctx context.Context, slug string) (*entity.PostEn
         Slug:          modelPost.Slug,
         Author:        modelPost.Author,
         FeaturedImage: modelPost.FeaturedImage,
+        FeaturedImageAlt: stringValue(modelPost.FeaturedImageAlt),
+        FeaturedImageCaption: stringValue(modelPost.FeaturedImageCaption),
         Content:       modelPost.Content,
         PublishedAt:   modelPost.PublishedAt,
     }, nil
@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -21,10 +21,10 @@ type profile struct {
 // FetchByIDProfile implements ProfileInterface.
 func (p *profile) FetchByIDProfile(ctx context.Context, id int64) (*entity.ProfileEntity, error) {
 	modelProfile := model.Profile{}
-	err := p.DB.Where("id = ?", id).First(&modelProfile).Error
+	err := p.DB.WithContext(ctx).Where("id = ?", id).First(&modelProfile).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDProfile - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDProfile", 1, err)
+		return nil, dbError(err)
 	}
 
 	// Mapping model to entity
@@ -39,10 +39,10 @@ func (p *profile) FetchByIDProfile(ctx context.Context, id int64) (*entity.Profi
 func (p *profile) EditByIDProfile(ctx context.Context, req entity.ProfileEntity) error {
 	modelProfile := model.Profile{}
 
-	err := p.DB.Where("id = ?", req.ID).First(&modelProfile).Error
+	err := p.DB.WithContext(ctx).Where("id = ?", req.ID).First(&modelProfile).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDProfile - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDProfile", 1, err)
+		return dbError(err)
 	}
 
 	// Update the profile fields
@@ -50,10 +50,10 @@ func (p *profile) EditByIDProfile(ctx context.Context, req entity.ProfileEntity)
 	modelProfile.Content = req.Content
 
 	// Save the updated profile
-	err = p.DB.Save(&modelProfile).Error
+	err = p.DB.WithContext(ctx).Save(&modelProfile).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDProfile - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDProfile", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
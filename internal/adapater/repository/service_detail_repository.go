@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -25,25 +25,34 @@ type serviceDetailRepository struct {
 
 // GetByServiceIDDetail implements ServiceDetailRepositoryInterface.
 func (h *serviceDetailRepository) GetByServiceIDDetail(ctx context.Context, serviceId int64) (*entity.ServiceDetailEntity, error) {
-	rows, err := h.DB.Table("service details as ack").
-		Select("ack.id", "ack.path_image", "ack.description", "ack.path_pdf", "ack.path_docx", "ac.name").
+	rows, err := h.DB.WithContext(ctx).Table("service_details as ack").
+		Select("ack.id", "ack.service_id", "ack.title", "ack.path_image", "ack.description", "ack.path_pdf", "ack.path_docx", "ac.name").
 		Joins("inner join service_sections as ac on ac.id = ack.service_id").
-		Where("ack.deleted_at IS NULL").
+		Where("ack.service_id = ? AND ack.deleted_at IS NULL", serviceId).
 		Rows()
 	if err != nil {
-		log.Errorf("[REPOSITORY] GetByServiceIDDetail - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "GetByServiceIDDetail", 1, err)
+		return nil, dbError(err)
 	}
+	defer rows.Close()
 
 	serviceDetail := entity.ServiceDetailEntity{}
 	for rows.Next() {
-		err = rows.Scan(&serviceDetail.ID, &serviceDetail.PathImage, &serviceDetail.Description, &serviceDetail.PathPdf, &serviceDetail.PathDocx, &serviceDetail.ServiceName)
+		err = rows.Scan(&serviceDetail.ID, &serviceDetail.ServiceID, &serviceDetail.Title, &serviceDetail.PathImage, &serviceDetail.Description, &serviceDetail.PathPdf, &serviceDetail.PathDocx, &serviceDetail.ServiceName)
 		if err != nil {
-			log.Errorf("[REPOSITORY] GetByServiceIDDetail - 2: %v", err)
-			return nil, err
+			logger.Error(ctx, logger.Repository, "GetByServiceIDDetail", 2, err)
+			return nil, dbError(err)
 		}
 	}
 
+	if err = rows.Err(); err != nil {
+		logger.Error(ctx, logger.Repository, "GetByServiceIDDetail", 3, err)
+		return nil, dbError(err)
+	}
+	if serviceDetail.ID == 0 {
+		return nil, dbError(gorm.ErrRecordNotFound)
+	}
+
 	return &serviceDetail, nil
 }
 
@@ -58,9 +67,9 @@ func (h *serviceDetailRepository) CreateServiceDetail(ctx context.Context, req e
 		PathDocx:    req.PathDocx,
 	}
 
-	if err = h.DB.Create(&modelServiceDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateServiceDetail - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelServiceDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateServiceDetail", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -69,14 +78,14 @@ func (h *serviceDetailRepository) CreateServiceDetail(ctx context.Context, req e
 func (h *serviceDetailRepository) DeleteByIDServiceDetail(ctx context.Context, id int64) error {
 	modelServiceDetail := model.ServiceDetail{}
 
-	if err = h.DB.Where("id = ?", id).First(&modelServiceDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDServiceDetail - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelServiceDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDServiceDetail", 1, err)
+		return dbError(err)
 	}
 
-	if err = h.DB.Delete(&modelServiceDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDServiceDetail - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Delete(&modelServiceDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDServiceDetail", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -85,9 +94,9 @@ func (h *serviceDetailRepository) DeleteByIDServiceDetail(ctx context.Context, i
 func (h *serviceDetailRepository) EditByIDServiceDetail(ctx context.Context, req entity.ServiceDetailEntity) error {
 	modelServiceDetail := model.ServiceDetail{}
 
-	if err = h.DB.Where("id =?", req.ID).First(&modelServiceDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDServiceDetail - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelServiceDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDServiceDetail", 1, err)
+		return dbError(err)
 	}
 	modelServiceDetail.Description = req.Description
 	modelServiceDetail.PathImage = req.PathImage
@@ -96,9 +105,9 @@ func (h *serviceDetailRepository) EditByIDServiceDetail(ctx context.Context, req
 	modelServiceDetail.PathDocx = req.PathDocx
 	modelServiceDetail.Title = req.Title
 
-	if err = h.DB.Save(&modelServiceDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDServiceDetail - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Save(&modelServiceDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDServiceDetail", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -106,9 +115,9 @@ func (h *serviceDetailRepository) EditByIDServiceDetail(ctx context.Context, req
 // FetchAllServiceDetail implements ServiceDetailInterface.
 func (h *serviceDetailRepository) FetchAllServiceDetail(ctx context.Context) ([]entity.ServiceDetailEntity, error) {
 	modelServiceDetail := []model.ServiceDetail{}
-	if err = h.DB.Select("id", "path_image", "description", "title", "path_pdf", "path_docx", "service_id").Find(&modelServiceDetail).Order("created_at DESC").Error; err != nil {
-		log.Errorf("[REPOSITORY] FetchAllServiceDetail - 1: %v", err)
-		return nil, err
+	if err = h.DB.WithContext(ctx).Select("id", "path_image", "description", "title", "path_pdf", "path_docx", "service_id").Find(&modelServiceDetail).Order("created_at DESC").Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllServiceDetail", 1, err)
+		return nil, dbError(err)
 	}
 
 	var serviceDetailRepositoryEntities []entity.ServiceDetailEntity
@@ -130,9 +139,9 @@ func (h *serviceDetailRepository) FetchAllServiceDetail(ctx context.Context) ([]
 // FetchByIDServiceDetail implements ServiceDetailInterface.
 func (h *serviceDetailRepository) FetchByIDServiceDetail(ctx context.Context, id int64) (*entity.ServiceDetailEntity, error) {
 	modelServiceDetail := model.ServiceDetail{}
-	if err = h.DB.Select("id", "path_image", "description", "title", "path_pdf", "path_docx", "service_id").Where("id = ?", id).First(&modelServiceDetail).Error; err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDServiceDetail - 1: %v", err)
-		return nil, err
+	if err = h.DB.WithContext(ctx).Select("id", "path_image", "description", "title", "path_pdf", "path_docx", "service_id").Where("id = ?", id).First(&modelServiceDetail).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDServiceDetail", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.ServiceDetailEntity{
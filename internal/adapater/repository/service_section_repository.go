@@ -4,8 +4,8 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
@@ -29,9 +29,9 @@ func (h *serviceSectionRepository) CreateServiceSection(ctx context.Context, req
 		Tagline:  req.Tagline,
 	}
 
-	if err = h.DB.Create(&modelServiceSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateServiceSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Create(&modelServiceSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateServiceSection", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -40,14 +40,20 @@ func (h *serviceSectionRepository) CreateServiceSection(ctx context.Context, req
 func (h *serviceSectionRepository) DeleteByIDServiceSection(ctx context.Context, id int64) error {
 	modelServiceSection := model.ServiceSection{}
 
-	if err = h.DB.Where("id = ?", id).First(&modelServiceSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDServiceSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id = ?", id).First(&modelServiceSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDServiceSection", 1, err)
+		return dbError(err)
 	}
 
-	if err = h.DB.Delete(&modelServiceSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDServiceSection - 2: %v", err)
-		return err
+	err = h.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&modelServiceSection).Error; err != nil {
+			return dbError(err)
+		}
+		return softDeleteChildren(tx, "service-sections", id)
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "DeleteByIDServiceSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -56,17 +62,17 @@ func (h *serviceSectionRepository) DeleteByIDServiceSection(ctx context.Context,
 func (h *serviceSectionRepository) EditByIDServiceSection(ctx context.Context, req entity.ServiceSectionEntity) error {
 	modelServiceSection := model.ServiceSection{}
 
-	if err = h.DB.Where("id =?", req.ID).First(&modelServiceSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDServiceSection - 1: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Where("id =?", req.ID).First(&modelServiceSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDServiceSection", 1, err)
+		return dbError(err)
 	}
 	modelServiceSection.Name = req.Name
 	modelServiceSection.Tagline = req.Tagline
 	modelServiceSection.PathIcon = req.PathIcon
 
-	if err = h.DB.Save(&modelServiceSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] EditByIDServiceSection - 2: %v", err)
-		return err
+	if err = h.DB.WithContext(ctx).Save(&modelServiceSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "EditByIDServiceSection", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -74,9 +80,9 @@ func (h *serviceSectionRepository) EditByIDServiceSection(ctx context.Context, r
 // FetchAllServiceSection implements ServiceSectionInterface.
 func (h *serviceSectionRepository) FetchAllServiceSection(ctx context.Context) ([]entity.ServiceSectionEntity, error) {
 	modelServiceSection := []model.ServiceSection{}
-	if err = h.DB.Select("id", "path_icon", "tagline", "name").Find(&modelServiceSection).Order("created_at DESC").Error; err != nil {
-		log.Errorf("[REPOSITORY] FetchAllServiceSection - 1: %v", err)
-		return nil, err
+	if err := h.DB.WithContext(ctx).Select("id", "path_icon", "tagline", "name").Find(&modelServiceSection).Order("created_at DESC").Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchAllServiceSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	var serviceSectionRepositoryEntities []entity.ServiceSectionEntity
@@ -95,9 +101,9 @@ func (h *serviceSectionRepository) FetchAllServiceSection(ctx context.Context) (
 // FetchByIDServiceSection implements ServiceSectionInterface.
 func (h *serviceSectionRepository) FetchByIDServiceSection(ctx context.Context, id int64) (*entity.ServiceSectionEntity, error) {
 	modelServiceSection := model.ServiceSection{}
-	if err = h.DB.Select("id", "path_icon", "tagline", "name").Where("id = ?", id).First(&modelServiceSection).Error; err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDServiceSection - 1: %v", err)
-		return nil, err
+	if err = h.DB.WithContext(ctx).Select("id", "path_icon", "tagline", "name").Where("id = ?", id).First(&modelServiceSection).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "FetchByIDServiceSection", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.ServiceSectionEntity{
@@ -4,14 +4,15 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/labstack/gommon/log"
 	"gorm.io/gorm"
 )
 
 type StatisticInterface interface {
 	CreateStatistic(ctx context.Context, req entity.StatisticEntity) error
 	FetchAllStatistic(ctx context.Context) ([]entity.StatisticEntity, error)
+	StreamStatistic(ctx context.Context, fn func(entity.StatisticEntity) error) error
 	FetchByIDStatistic(ctx context.Context, id int64) (*entity.StatisticEntity, error)
 	EditByIDStatistic(ctx context.Context, req entity.StatisticEntity) error
 	DeleteByIDStatistic(ctx context.Context, id int64) error
@@ -29,9 +30,9 @@ func (s *statistic) CreateStatistic(ctx context.Context, req entity.StatisticEnt
 		Icon:  req.Icon,
 	}
 
-	if err := s.DB.Create(&modelStatistic).Error; err != nil {
-		log.Errorf("[REPOSITORY] CreateStatistic - 1: %v", err)
-		return err
+	if err := s.DB.WithContext(ctx).Create(&modelStatistic).Error; err != nil {
+		logger.Error(ctx, logger.Repository, "CreateStatistic", 1, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -40,16 +41,16 @@ func (s *statistic) CreateStatistic(ctx context.Context, req entity.StatisticEnt
 func (s *statistic) DeleteByIDStatistic(ctx context.Context, id int64) error {
 	modelStatistic := model.Statistic{}
 
-	err := s.DB.Where("id = ?", id).First(&modelStatistic).Error
+	err := s.DB.WithContext(ctx).Where("id = ?", id).First(&modelStatistic).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDStatistic - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDStatistic", 1, err)
+		return dbError(err)
 	}
 
-	err = s.DB.Delete(&modelStatistic).Error
+	err = s.DB.WithContext(ctx).Delete(&modelStatistic).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] DeleteByIDStatistic - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "DeleteByIDStatistic", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -58,18 +59,18 @@ func (s *statistic) DeleteByIDStatistic(ctx context.Context, id int64) error {
 func (s *statistic) EditByIDStatistic(ctx context.Context, req entity.StatisticEntity) error {
 	modelStatistic := model.Statistic{}
 
-	err := s.DB.Where("id = ?", req.ID).First(&modelStatistic).Error
+	err := s.DB.WithContext(ctx).Where("id = ?", req.ID).First(&modelStatistic).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDStatistic - 1: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDStatistic", 1, err)
+		return dbError(err)
 	}
 	modelStatistic.Name = req.Name
 	modelStatistic.Total = req.Total
 	modelStatistic.Icon = req.Icon
-	err = s.DB.Save(&modelStatistic).Error
+	err = s.DB.WithContext(ctx).Save(&modelStatistic).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] EditByIDStatistic - 2: %v", err)
-		return err
+		logger.Error(ctx, logger.Repository, "EditByIDStatistic", 2, err)
+		return dbError(err)
 	}
 	return nil
 }
@@ -77,10 +78,10 @@ func (s *statistic) EditByIDStatistic(ctx context.Context, req entity.StatisticE
 // FetchAllStatistic implements StatisticInterface.
 func (s *statistic) FetchAllStatistic(ctx context.Context) ([]entity.StatisticEntity, error) {
 	modelStatistic := []model.Statistic{}
-	err := s.DB.Select("id", "name", "total", "icon").Find(&modelStatistic).Order("created_at DESC").Error
+	err := s.DB.WithContext(ctx).Select("id", "name", "total", "icon").Find(&modelStatistic).Order("created_at DESC").Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchAllStatistic - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchAllStatistic", 1, err)
+		return nil, dbError(err)
 	}
 
 	var statisticEntities []entity.StatisticEntity
@@ -96,13 +97,37 @@ func (s *statistic) FetchAllStatistic(ctx context.Context) ([]entity.StatisticEn
 	return statisticEntities, nil
 }
 
+// StreamStatistic implements StatisticInterface.
+func (s *statistic) StreamStatistic(ctx context.Context, fn func(entity.StatisticEntity) error) error {
+	rows, err := s.DB.WithContext(ctx).Model(&model.Statistic{}).Select("id", "name", "total", "icon").Order("created_at DESC").Rows()
+	if err != nil {
+		logger.Error(ctx, logger.Repository, "StreamStatistic", 1, err)
+		return dbError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var stat entity.StatisticEntity
+		if err = rows.Scan(&stat.ID, &stat.Name, &stat.Total, &stat.Icon); err != nil {
+			logger.Error(ctx, logger.Repository, "StreamStatistic", 2, err)
+			return dbError(err)
+		}
+
+		if err = fn(stat); err != nil {
+			return dbError(err)
+		}
+	}
+
+	return dbError(rows.Err())
+}
+
 // FetchByIDStatistic implements StatisticInterface.
 func (s *statistic) FetchByIDStatistic(ctx context.Context, id int64) (*entity.StatisticEntity, error) {
 	modelStatistic := model.Statistic{}
-	err := s.DB.Where("id = ?", id).First(&modelStatistic).Error
+	err := s.DB.WithContext(ctx).Where("id = ?", id).First(&modelStatistic).Error
 	if err != nil {
-		log.Errorf("[REPOSITORY] FetchByIDStatistic - 1: %v", err)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "FetchByIDStatistic", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.StatisticEntity{
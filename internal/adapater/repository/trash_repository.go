@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/utils/logger"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownTrashResource = errors.New("unknown trash resource")
+	ErrTrashParentDeleted   = errors.New("parent record is in the trash")
+)
+
+type trashChild struct {
+	Resource   string
+	ForeignKey string
+}
+
+type trashResource struct {
+	Table string
+	// Label is the sql expression shown to identify a record in the trash.
+	Label string
+	// Parent and ForeignKey point at the record this one belongs to.
+	Parent     string
+	ForeignKey string
+	Children   []trashChild
+}
+
+// trashResources lists every soft deletable table by its resource name in
+// the api. Children are deleted and restored together with their parent.
+var trashResources = map[string]trashResource{
+	"users":           {Table: "users", Label: "name"},
+	"hero-sections":   {Table: "hero_sections", Label: "heading"},
+	"client-sections": {Table: "client_sections", Label: "name"},
+	"faq-sections":    {Table: "faq_sections", Label: "title"},
+	"about-companies": {
+		Table:    "about_companies",
+		Label:    "LEFT(description, 100)",
+		Children: []trashChild{{"about-company-keynotes", "about_company_id"}},
+	},
+	"about-company-keynotes": {Table: "about_company_keynotes", Label: "LEFT(keypoint, 100)", Parent: "about-companies", ForeignKey: "about_company_id"},
+	"service-sections": {
+		Table:    "service_sections",
+		Label:    "name",
+		Children: []trashChild{{"service-details", "service_id"}},
+	},
+	"service-details": {Table: "service_details", Label: "title", Parent: "service-sections", ForeignKey: "service_id"},
+	"appointments":    {Table: "appointments", Label: "name"},
+	"portofolio-sections": {
+		Table: "portofolio_sections",
+		Label: "name",
+		Children: []trashChild{
+			{"portofolio-details", "portofolio_section_id"},
+			{"portofolio-testimonials", "portofolio_section_id"},
+		},
+	},
+	"portofolio-details":      {Table: "portofolio_details", Label: "title", Parent: "portofolio-sections", ForeignKey: "portofolio_section_id"},
+	"portofolio-testimonials": {Table: "portofolio_testimonials", Label: "client_name", Parent: "portofolio-sections", ForeignKey: "portofolio_section_id"},
+	"our-teams":               {Table: "our_teams", Label: "name"},
+	"contact-us":              {Table: "contact_us", Label: "company_name"},
+	"statistics":              {Table: "statistics", Label: "name"},
+	"posts":                   {Table: "posts", Label: "title"},
+	"profiles":                {Table: "profiles", Label: "title"},
+	"inquiries":               {Table: "inquiries", Label: "subject"},
+}
+
+type TrashInterface interface {
+	FetchAllTrash(ctx context.Context, resource string) ([]entity.TrashEntity, error)
+	RestoreTrash(ctx context.Context, resource string, id int64) error
+	PurgeTrash(ctx context.Context, resource string, id int64) error
+	EmptyTrash(ctx context.Context, resource string) (int64, error)
+}
+
+type trashRepository struct {
+	DB *gorm.DB
+}
+
+// FetchAllTrash implements TrashInterface.
+// An empty resource lists the trash of every resource.
+func (t *trashRepository) FetchAllTrash(ctx context.Context, resource string) ([]entity.TrashEntity, error) {
+	names := []string{resource}
+	if resource == "" {
+		names = TrashResources()
+	}
+
+	results := []entity.TrashEntity{}
+	for _, name := range names {
+		res, ok := trashResources[name]
+		if !ok {
+			return nil, ErrUnknownTrashResource
+		}
+
+		rows := []struct {
+			ID        int64
+			Label     *string
+			DeletedAt time.Time
+		}{}
+		err := t.DB.WithContext(ctx).Table(res.Table).
+			Select("id, " + res.Label + " AS label, deleted_at").
+			Where("deleted_at IS NOT NULL").
+			Order("deleted_at DESC").
+			Scan(&rows).Error
+		if err != nil {
+			logger.Error(ctx, logger.Repository, "FetchAllTrash", 1, err)
+			return nil, dbError(err)
+		}
+
+		for _, row := range rows {
+			results = append(results, entity.TrashEntity{
+				Resource:  name,
+				ID:        row.ID,
+				Label:     stringValue(row.Label),
+				DeletedAt: row.DeletedAt,
+			})
+		}
+	}
+	return results, nil
+}
+
+// RestoreTrash implements TrashInterface.
+// Children deleted together with the record are restored with it, children
+// that were deleted on their own stay in the trash.
+func (t *trashRepository) RestoreTrash(ctx context.Context, resource string, id int64) error {
+	res, ok := trashResources[resource]
+	if !ok {
+		return ErrUnknownTrashResource
+	}
+
+	return t.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var deletedAt time.Time
+		row := tx.Table(res.Table).Select("deleted_at").Where("id = ? AND deleted_at IS NOT NULL", id).Row()
+		if err := row.Scan(&deletedAt); err != nil {
+			logger.Error(ctx, logger.Repository, "RestoreTrash", 1, err)
+			if errors.Is(err, sql.ErrNoRows) {
+				return gorm.ErrRecordNotFound
+			}
+			return dbError(err)
+		}
+
+		if res.Parent != "" {
+			var parentDeleted int64
+			err := tx.Table(trashResources[res.Parent].Table).
+				Where("id = (SELECT "+res.ForeignKey+" FROM "+res.Table+" WHERE id = ?) AND deleted_at IS NOT NULL", id).
+				Count(&parentDeleted).Error
+			if err != nil {
+				logger.Error(ctx, logger.Repository, "RestoreTrash", 2, err)
+				return dbError(err)
+			}
+			if parentDeleted > 0 {
+				return ErrTrashParentDeleted
+			}
+		}
+
+		// updated_at moves so the Last-Modified of the public reads does
+		restored := map[string]interface{}{"deleted_at": nil, "updated_at": time.Now()}
+		for _, child := range res.Children {
+			err := tx.Table(trashResources[child.Resource].Table).
+				Where(child.ForeignKey+" = ? AND deleted_at = ?", id, deletedAt).
+				Updates(restored).Error
+			if err != nil {
+				logger.Error(ctx, logger.Repository, "RestoreTrash", 3, err)
+				return dbError(err)
+			}
+		}
+
+		if err := tx.Table(res.Table).Where("id = ?", id).Updates(restored).Error; err != nil {
+			logger.Error(ctx, logger.Repository, "RestoreTrash", 4, err)
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+// PurgeTrash implements TrashInterface.
+// Children are removed by the ON DELETE CASCADE foreign keys. Records other
+// tables still point at, like a service with appointments, are kept by an
+// ON DELETE RESTRICT foreign key and fail as still referenced.
+func (t *trashRepository) PurgeTrash(ctx context.Context, resource string, id int64) error {
+	res, ok := trashResources[resource]
+	if !ok {
+		return ErrUnknownTrashResource
+	}
+
+	result := t.DB.WithContext(ctx).Exec("DELETE FROM "+res.Table+" WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if result.Error != nil {
+		logger.Error(ctx, logger.Repository, "PurgeTrash", 1, result.Error)
+		return dbError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// EmptyTrash implements TrashInterface.
+func (t *trashRepository) EmptyTrash(ctx context.Context, resource string) (int64, error) {
+	res, ok := trashResources[resource]
+	if !ok {
+		return 0, ErrUnknownTrashResource
+	}
+
+	result := t.DB.WithContext(ctx).Exec("DELETE FROM " + res.Table + " WHERE deleted_at IS NOT NULL")
+	if result.Error != nil {
+		logger.Error(ctx, logger.Repository, "EmptyTrash", 1, result.Error)
+		return 0, dbError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// TrashResources returns the resource names accepted by the trash.
+func TrashResources() []string {
+	names := make([]string, 0, len(trashResources))
+	for name := range trashResources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// softDeleteChildren moves the children of a record that was just soft
+// deleted into the trash with the same deleted_at, so restoring the parent
+// can tell them apart from children that were deleted earlier.
+func softDeleteChildren(tx *gorm.DB, resource string, id int64) error {
+	res := trashResources[resource]
+	for _, child := range res.Children {
+		err := tx.Exec("UPDATE "+trashResources[child.Resource].Table+" SET deleted_at = (SELECT deleted_at FROM "+res.Table+" WHERE id = ?) WHERE "+child.ForeignKey+" = ? AND deleted_at IS NULL", id, id).Error
+		if err != nil {
+			return dbError(err)
+		}
+	}
+	return nil
+}
+
+func NewTrashRepository(db *gorm.DB) TrashInterface {
+	return &trashRepository{
+		DB: db,
+	}
+}
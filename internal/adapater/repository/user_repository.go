@@ -4,14 +4,13 @@ import (
 	"context"
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/internal/core/domain/model"
+	"desadangdang/utils/logger"
 
-	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
 var (
-	err  error
-	code string
+	err error
 )
 
 type UserRepositoryInterface interface {
@@ -26,11 +25,10 @@ type userRepo struct {
 func (u *userRepo) GetUserByEmail(ctx context.Context, email string) (*entity.UserEntity, error) {
 	var modelUser model.User
 
-	err = u.db.Select("email", "password", "name", "id").Where("email = ?", email).First(&modelUser).Error
+	err = u.db.WithContext(ctx).Select("email", "password", "name", "id").Where("email = ?", email).First(&modelUser).Error
 	if err != nil {
-		code = "[REPOSITORY] GetUserByEmail - 1"
-		log.Err(err).Msg(code)
-		return nil, err
+		logger.Error(ctx, logger.Repository, "GetUserByEmail", 1, err)
+		return nil, dbError(err)
 	}
 
 	return &entity.UserEntity{
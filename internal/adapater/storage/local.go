@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"desadangdang/config"
+	"desadangdang/utils/logger"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// localStruct keeps objects on disk. The directory is served by an Echo
+// static route, so every object is public and signed urls are plain urls.
+type localStruct struct {
+	dir     string
+	baseURL string
+	secret  string
+}
+
+// Put implements StorageInterface.
+// The file is written to a temporary name first so readers never see a partial object.
+func (l *localStruct) Put(ctx context.Context, path string, file io.Reader, opts PutOptions) (string, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(l.dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		logger.Error(ctx, logger.Storage, "Local Put", 1, err)
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "Local Put", 2, err)
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, contextReader{ctx: ctx, r: file}); err != nil {
+		tmp.Close()
+		logger.Error(ctx, logger.Storage, "Local Put", 3, err)
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		logger.Error(ctx, logger.Storage, "Local Put", 4, err)
+		return "", err
+	}
+
+	return l.PublicURL(path), nil
+}
+
+// Get implements StorageInterface.
+func (l *localStruct) Get(ctx context.Context, path string) (io.ReadCloser, *ObjectInfo, error) {
+	info, err := l.Stat(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(filepath.Join(l.dir, filepath.FromSlash(info.Path)))
+	if err != nil {
+		return nil, nil, localError(err)
+	}
+	return file, info, nil
+}
+
+// Delete implements StorageInterface.
+func (l *localStruct) Delete(ctx context.Context, path string) error {
+	path, err := cleanPath(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(l.dir, filepath.FromSlash(path))); err != nil {
+		return localError(err)
+	}
+	return nil
+}
+
+// Stat implements StorageInterface.
+func (l *localStruct) Stat(ctx context.Context, path string) (*ObjectInfo, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(filepath.Join(l.dir, filepath.FromSlash(path)))
+	if err != nil {
+		return nil, localError(err)
+	}
+	if stat.IsDir() {
+		return nil, ErrObjectNotFound
+	}
+
+	return &ObjectInfo{
+		Path:         path,
+		Size:         stat.Size(),
+		ContentType:  mime.TypeByExtension(filepath.Ext(path)),
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+// Ping implements StorageInterface.
+func (l *localStruct) Ping(ctx context.Context) error {
+	stat, err := os.Stat(l.dir)
+	if err != nil {
+		return err
+	}
+	if !stat.IsDir() {
+		return fmt.Errorf("%s is not a directory", l.dir)
+	}
+	return nil
+}
+
+// PublicURL implements StorageInterface.
+func (l *localStruct) PublicURL(path string) string {
+	return joinURL(l.baseURL, path)
+}
+
+// SignedURL implements StorageInterface.
+func (l *localStruct) SignedURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return "", err
+	}
+	return l.PublicURL(path), nil
+}
+
+// SignedUploadURL implements StorageInterface.
+// The url points at LocalUploadRoute on the api itself, signed with an HMAC
+// over the path, content type, size limit and expiry.
+func (l *localStruct) SignedUploadURL(ctx context.Context, path string, opts PutOptions, expires time.Duration) (*SignedUpload, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(expires)
+	query := url.Values{}
+	query.Set("content_type", opts.ContentType)
+	query.Set("size", strconv.FormatInt(opts.Size, 10))
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", localSignature(l.secret, path, opts.ContentType, opts.Size, expiresAt.Unix()))
+
+	// Reuse the host of an absolute public url, a relative one stays relative to the api.
+	host := ""
+	if base, err := url.Parse(l.baseURL); err == nil && base.Host != "" {
+		host = base.Scheme + "://" + base.Host
+	}
+
+	return &SignedUpload{
+		URL:       host + joinURL(LocalUploadRoute, path) + "?" + query.Encode(),
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": opts.ContentType},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// VerifyLocalUpload checks the query of a url made by the local SignedUploadURL.
+// It returns the size limit the upload was signed for.
+func VerifyLocalUpload(secret, path string, query url.Values) (int64, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := strconv.ParseInt(query.Get("size"), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidSignature
+	}
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return 0, ErrInvalidSignature
+	}
+
+	expected := localSignature(secret, path, query.Get("content_type"), size, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return 0, ErrInvalidSignature
+	}
+	if time.Now().Unix() > expires {
+		return 0, ErrSignatureExpired
+	}
+	return size, nil
+}
+
+func localSignature(secret, path, contentType string, size, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%d", path, contentType, size, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func localError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrObjectNotFound
+	}
+	return err
+}
+
+// contextReader stops a copy once the request is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+func NewLocal(cfg *config.Config) (StorageInterface, error) {
+	dir := cfg.Storage.LocalDir
+	if dir == "" {
+		dir = DefaultLocalDir
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.Storage.PublicURL
+	if baseURL == "" {
+		baseURL = DefaultLocalRoute
+	}
+
+	return &localStruct{
+		dir:     dir,
+		baseURL: baseURL,
+		secret:  cfg.UploadSigningKey(),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"desadangdang/config"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocal(t *testing.T) (StorageInterface, string) {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "uploads")
+
+	cfg := &config.Config{}
+	cfg.Storage.LocalDir = dir
+	cfg.App.JwtSecretKey = "jwt-secret"
+	cfg.Upload.SigningKey = "test-secret"
+
+	s, err := NewLocal(cfg)
+	if err != nil {
+		t.Fatalf("NewLocal: %v", err)
+	}
+	return s, dir
+}
+
+func TestLocal(t *testing.T) {
+	s, _ := newTestLocal(t)
+	testBackend(t, s)
+}
+
+func TestLocalDeleteMissing(t *testing.T) {
+	s, _ := newTestLocal(t)
+	if err := s.Delete(context.Background(), "public/missing.txt"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Delete = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestLocalPathTraversal(t *testing.T) {
+	s, dir := newTestLocal(t)
+	ctx := context.Background()
+
+	for _, p := range []string{"../escape.txt", "../../escape.txt", `..\escape.txt`, "public/../../escape.txt"} {
+		if _, err := s.Put(ctx, p, strings.NewReader("x"), PutOptions{Size: 1}); err != nil {
+			t.Fatalf("Put(%q): %v", p, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "escape.txt")); err != nil {
+			t.Errorf("Put(%q) did not write inside the storage dir: %v", p, err)
+		}
+		if err := s.Delete(ctx, p); err != nil {
+			t.Errorf("Delete(%q): %v", p, err)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != filepath.Base(dir) {
+			t.Errorf("%s was written outside the storage dir", entry.Name())
+		}
+	}
+
+	for _, p := range []string{"", "/", ".."} {
+		if _, err := s.Put(ctx, p, strings.NewReader("x"), PutOptions{Size: 1}); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidPath", p, err)
+		}
+	}
+}
+
+func TestLocalSignedUpload(t *testing.T) {
+	s, _ := newTestLocal(t)
+	ctx := context.Background()
+	path := "public/uploads/doc.pdf"
+
+	upload, err := s.SignedUploadURL(ctx, path, PutOptions{ContentType: "application/pdf", Size: 1024}, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedUploadURL: %v", err)
+	}
+	u, err := url.Parse(upload.URL)
+	if err != nil {
+		t.Fatalf("parse %q: %v", upload.URL, err)
+	}
+	signedPath := strings.TrimPrefix(u.Path, LocalUploadRoute+"/")
+	if signedPath != path {
+		t.Fatalf("signed path = %q, want %q", signedPath, path)
+	}
+
+	size, err := VerifyLocalUpload("test-secret", signedPath, u.Query())
+	if err != nil || size != 1024 {
+		t.Fatalf("VerifyLocalUpload = %d, %v, want 1024", size, err)
+	}
+
+	if _, err := VerifyLocalUpload("jwt-secret", signedPath, u.Query()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyLocalUpload with the jwt secret = %v, want ErrInvalidSignature", err)
+	}
+	if _, err := VerifyLocalUpload("test-secret", "public/uploads/other.pdf", u.Query()); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyLocalUpload of another path = %v, want ErrInvalidSignature", err)
+	}
+
+	tampered := u.Query()
+	tampered.Set("size", "999999999")
+	if _, err := VerifyLocalUpload("test-secret", signedPath, tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyLocalUpload with a bigger size = %v, want ErrInvalidSignature", err)
+	}
+
+	expired, err := s.SignedUploadURL(ctx, path, PutOptions{ContentType: "application/pdf", Size: 1024}, -time.Minute)
+	if err != nil {
+		t.Fatalf("SignedUploadURL: %v", err)
+	}
+	u, _ = url.Parse(expired.URL)
+	if _, err := VerifyLocalUpload("test-secret", signedPath, u.Query()); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("VerifyLocalUpload of an expired url = %v, want ErrSignatureExpired", err)
+	}
+
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/utils/logger"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3Struct works with AWS S3 and compatible servers such as MinIO.
+type s3Struct struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+// Put implements StorageInterface.
+// Objects without a known size are sent as a multipart upload.
+func (s *s3Struct) Put(ctx context.Context, path string, file io.Reader, opts PutOptions) (string, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	size := opts.Size
+	if size == 0 {
+		size = -1
+	}
+	_, err = s.client.PutObject(ctx, s.bucket, path, file, size, minio.PutObjectOptions{
+		ContentType: opts.ContentType,
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "S3 Put", 1, err)
+		return "", err
+	}
+
+	return s.PublicURL(path), nil
+}
+
+// Get implements StorageInterface.
+func (s *s3Struct) Get(ctx context.Context, path string) (io.ReadCloser, *ObjectInfo, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	object, err := s.client.GetObject(ctx, s.bucket, path, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, s3Error(err)
+	}
+	stat, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, nil, s3Error(err)
+	}
+	return object, s3ObjectInfo(path, stat), nil
+}
+
+// Delete implements StorageInterface.
+func (s *s3Struct) Delete(ctx context.Context, path string) error {
+	path, err := cleanPath(path)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucket, path, minio.RemoveObjectOptions{}); err != nil {
+		logger.Error(ctx, logger.Storage, "S3 Delete", 1, err)
+		return s3Error(err)
+	}
+	return nil
+}
+
+// Stat implements StorageInterface.
+func (s *s3Struct) Stat(ctx context.Context, path string) (*ObjectInfo, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := s.client.StatObject(ctx, s.bucket, path, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+	return s3ObjectInfo(path, stat), nil
+}
+
+// Ping implements StorageInterface.
+func (s *s3Struct) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %q does not exist", s.bucket)
+	}
+	return nil
+}
+
+// PublicURL implements StorageInterface.
+func (s *s3Struct) PublicURL(path string) string {
+	return joinURL(s.publicURL, path)
+}
+
+// SignedURL implements StorageInterface.
+func (s *s3Struct) SignedURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	signed, err := s.client.PresignedGetObject(ctx, s.bucket, path, expires, url.Values{})
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "S3 SignedURL", 1, err)
+		return "", err
+	}
+	return signed.String(), nil
+}
+
+// SignedUploadURL implements StorageInterface.
+// The content type is part of the signature so the client cannot change it.
+func (s *s3Struct) SignedUploadURL(ctx context.Context, path string, opts PutOptions, expires time.Duration) (*SignedUpload, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", opts.ContentType)
+	signed, err := s.client.PresignHeader(ctx, http.MethodPut, s.bucket, path, expires, url.Values{}, header)
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "S3 SignedUploadURL", 1, err)
+		return nil, err
+	}
+
+	return &SignedUpload{
+		URL:       signed.String(),
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": opts.ContentType},
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
+}
+
+func s3Error(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotFound
+	}
+	return err
+}
+
+func s3ObjectInfo(path string, stat minio.ObjectInfo) *ObjectInfo {
+	return &ObjectInfo{
+		Path:         path,
+		Size:         stat.Size,
+		ContentType:  stat.ContentType,
+		LastModified: stat.LastModified,
+	}
+}
+
+func NewS3(cfg *config.Config) (StorageInterface, error) {
+	client, err := minio.New(cfg.S3.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.S3.AccessKey, cfg.S3.SecretKey, ""),
+		Secure: cfg.S3.UseSSL,
+		Region: cfg.S3.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an explicit public url objects are addressed path-style on the endpoint.
+	publicURL := cfg.Storage.PublicURL
+	if publicURL == "" {
+		scheme := "http"
+		if cfg.S3.UseSSL {
+			scheme = "https"
+		}
+		publicURL = fmt.Sprintf("%s://%s/%s", scheme, cfg.S3.Endpoint, cfg.S3.Bucket)
+	}
+
+	return &s3Struct{
+		client:    client,
+		bucket:    cfg.S3.Bucket,
+		publicURL: publicURL,
+	}, nil
+}
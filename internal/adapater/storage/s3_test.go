@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"desadangdang/config"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+// newTestS3 connects to the bucket named by the S3_TEST_* variables, e.g. a
+// local MinIO, and skips the test when S3_TEST_ENDPOINT is unset.
+func newTestS3(t *testing.T) StorageInterface {
+	t.Helper()
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set")
+	}
+
+	cfg := &config.Config{}
+	cfg.S3.Endpoint = endpoint
+	cfg.S3.AccessKey = os.Getenv("S3_TEST_ACCESS_KEY")
+	cfg.S3.SecretKey = os.Getenv("S3_TEST_SECRET_KEY")
+	cfg.S3.Bucket = os.Getenv("S3_TEST_BUCKET")
+	cfg.S3.Region = os.Getenv("S3_TEST_REGION")
+	cfg.S3.UseSSL = os.Getenv("S3_TEST_USE_SSL") == "true"
+
+	s, err := NewS3(cfg)
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+	return s
+}
+
+func TestS3(t *testing.T) {
+	testBackend(t, newTestS3(t))
+}
+
+func TestS3SignedUpload(t *testing.T) {
+	s := newTestS3(t)
+	ctx := context.Background()
+	path := "test/signed/" + time.Now().Format("150405.000000") + ".txt"
+	content := []byte("signed upload")
+
+	upload, err := s.SignedUploadURL(ctx, path, PutOptions{ContentType: "text/plain", Size: int64(len(content))}, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedUploadURL: %v", err)
+	}
+	t.Cleanup(func() { s.Delete(context.Background(), path) })
+
+	req, err := http.NewRequestWithContext(ctx, upload.Method, upload.URL, bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range upload.Headers {
+		req.Header.Set(k, v)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("upload status = %d, want 200", res.StatusCode)
+	}
+
+	info, err := s.Stat(ctx, path)
+	if err != nil || info.Size != int64(len(content)) {
+		t.Errorf("Stat = %+v, %v, want %d bytes", info, err, len(content))
+	}
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"desadangdang/config"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	DriverSupabase = "supabase"
+	DriverLocal    = "local"
+	DriverS3       = "s3"
+
+	DefaultLocalDir   = "storage/uploads"
+	DefaultLocalRoute = "/uploads"
+	// LocalUploadRoute receives the signed uploads of the local driver.
+	LocalUploadRoute = "/upload-file/local"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object not found")
+	ErrInvalidPath    = errors.New("invalid object path")
+
+	ErrInvalidSignature = errors.New("invalid upload signature")
+	ErrSignatureExpired = errors.New("upload signature expired")
+)
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Path         string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// SignedUpload is a pre-signed request the client sends the file with,
+// the headers must be sent exactly as given.
+type SignedUpload struct {
+	URL       string
+	Method    string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
+
+// PutOptions describes the object being written.
+// Size may be -1 when the length is not known up front.
+type PutOptions struct {
+	ContentType string
+	Size        int64
+}
+
+type StorageInterface interface {
+	// Put stores file at path, ex: public/uploads/photo.jpg, and returns its public url
+	Put(ctx context.Context, path string, file io.Reader, opts PutOptions) (string, error)
+	// Get returns the object content, the caller must close it
+	Get(ctx context.Context, path string) (io.ReadCloser, *ObjectInfo, error)
+	Delete(ctx context.Context, path string) error
+	Stat(ctx context.Context, path string) (*ObjectInfo, error)
+	PublicURL(path string) string
+	SignedURL(ctx context.Context, path string, expires time.Duration) (string, error)
+	// SignedUploadURL lets a client upload straight to the backend without passing through the api
+	SignedUploadURL(ctx context.Context, path string, opts PutOptions, expires time.Duration) (*SignedUpload, error)
+	// Ping checks that the backend is reachable and the bucket exists
+	Ping(ctx context.Context) error
+}
+
+// cleanPath normalizes an object path and rejects paths escaping the root.
+func cleanPath(p string) (string, error) {
+	p = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(p, "\\", "/")), "/")
+	if p == "" || p == "." {
+		return "", ErrInvalidPath
+	}
+	return p, nil
+}
+
+func joinURL(base, p string) string {
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(p, "/")
+}
+
+func NewStorage(cfg *config.Config) (StorageInterface, error) {
+	switch cfg.Storage.Driver {
+	case "", DriverSupabase:
+		return newTraced(DriverSupabase, NewSupabase(cfg)), nil
+	case DriverLocal:
+		driver, err := NewLocal(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return newTraced(DriverLocal, driver), nil
+	case DriverS3:
+		driver, err := NewS3(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return newTraced(DriverS3, driver), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
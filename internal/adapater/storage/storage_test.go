@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanPath(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		want string
+		err  error
+	}{
+		{path: "public/uploads/a.jpg", want: "public/uploads/a.jpg"},
+		{path: "/public//uploads/./a.jpg", want: "public/uploads/a.jpg"},
+		{path: `public\uploads\a.jpg`, want: "public/uploads/a.jpg"},
+		{path: "../../etc/passwd", want: "etc/passwd"},
+		{path: "public/../../../etc/passwd", want: "etc/passwd"},
+		{path: `..\..\etc\passwd`, want: "etc/passwd"},
+		{path: "", err: ErrInvalidPath},
+		{path: "/", err: ErrInvalidPath},
+		{path: "..", err: ErrInvalidPath},
+	} {
+		got, err := cleanPath(tc.path)
+		if !errors.Is(err, tc.err) || got != tc.want {
+			t.Errorf("cleanPath(%q) = %q, %v, want %q, %v", tc.path, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+// testBackend runs the object lifecycle every driver must support.
+func testBackend(t *testing.T, s StorageInterface) {
+	t.Helper()
+	ctx := context.Background()
+	path := "test/" + strings.ReplaceAll(t.Name(), "/", "_") + "/" + time.Now().Format("150405.000000") + ".txt"
+	content := []byte("hello storage")
+
+	if err := s.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+
+	url, err := s.Put(ctx, path, bytes.NewReader(content), PutOptions{ContentType: "text/plain", Size: int64(len(content))})
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if url != s.PublicURL(path) {
+		t.Errorf("Put returned %q, want the public url %q", url, s.PublicURL(path))
+	}
+
+	info, err := s.Stat(ctx, path)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if info.Size != int64(len(content)) || !strings.HasPrefix(info.ContentType, "text/plain") {
+		t.Errorf("Stat = %d bytes of %q, want %d bytes of text/plain", info.Size, info.ContentType, len(content))
+	}
+
+	body, _, err := s.Get(ctx, path)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || !bytes.Equal(got, content) {
+		t.Errorf("Get = %q, %v, want %q", got, err, content)
+	}
+
+	if err := s.Delete(ctx, path); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Stat(ctx, path); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Stat after Delete = %v, want ErrObjectNotFound", err)
+	}
+	if _, _, err := s.Get(ctx, path); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrObjectNotFound", err)
+	}
+}
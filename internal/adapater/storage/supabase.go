@@ -1,39 +1,259 @@
 package storage
 
 import (
+	"bytes"
+	"context"
 	"desadangdang/config"
+	"desadangdang/utils/logger"
+	"encoding/json"
+	"fmt"
 	"io"
-
-	"github.com/labstack/gommon/log"
-	storage_go "github.com/supabase-community/storage-go"
+	"net/http"
+	"strconv"
+	"time"
 )
 
-type SupabaseInterface interface {
-	// path must be filename ex: /img/photo.jpg
-	// file must be io.Reader
-	UploadFile(path string, file io.Reader) (string, error)
+// supabaseStruct talks to the Supabase storage REST api directly. The
+// storage-go client mutates shared headers per upload, which is not safe
+// once a single client is reused across requests.
+type supabaseStruct struct {
+	baseURL string
+	key     string
+	bucket  string
+	client  *http.Client
 }
 
-type supabaseStruct struct {
-	cfg *config.Config
+// Put implements StorageInterface.
+func (s *supabaseStruct) Put(ctx context.Context, path string, file io.Reader, opts PutOptions) (string, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPost, "/object/"+s.bucket+"/"+path, file)
+	if err != nil {
+		return "", err
+	}
+	if opts.ContentType != "" {
+		req.Header.Set("Content-Type", opts.ContentType)
+	}
+	if opts.Size >= 0 {
+		req.ContentLength = opts.Size
+	}
+	req.Header.Set("x-upsert", "false")
+
+	resp, err := s.do(req)
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "Supabase Put", 1, err)
+		return "", err
+	}
+	resp.Body.Close()
+
+	return s.PublicURL(path), nil
 }
 
-// UploadFile implements SupabaseInterface.
-func (s *supabaseStruct) UploadFile(path string, file io.Reader) (string, error) {
-	client := storage_go.NewClient(s.cfg.Supabase.StorageUrl, s.cfg.Supabase.StorageKey, map[string]string{"Content-Type": "image/png"})
+// Get implements StorageInterface.
+func (s *supabaseStruct) Get(ctx context.Context, path string) (io.ReadCloser, *ObjectInfo, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodGet, "/object/authenticated/"+s.bucket+"/"+path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, objectInfoFromHeader(path, resp.Header), nil
+}
+
+// Delete implements StorageInterface.
+func (s *supabaseStruct) Delete(ctx context.Context, path string) error {
+	path, err := cleanPath(path)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string][]string{"prefixes": {path}})
+	if err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodDelete, "/object/"+s.bucket, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "Supabase Delete", 1, err)
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Stat implements StorageInterface.
+func (s *supabaseStruct) Stat(ctx context.Context, path string) (*ObjectInfo, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(ctx, http.MethodHead, "/object/authenticated/"+s.bucket+"/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return objectInfoFromHeader(path, resp.Header), nil
+}
+
+// Ping implements StorageInterface.
+func (s *supabaseStruct) Ping(ctx context.Context) error {
+	req, err := s.newRequest(ctx, http.MethodGet, "/bucket/"+s.bucket, nil)
+	if err != nil {
+		return err
+	}
 
-	_, err := client.UploadFile(s.cfg.Supabase.StorageBucket, path, file)
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// PublicURL implements StorageInterface.
+func (s *supabaseStruct) PublicURL(path string) string {
+	return joinURL(s.baseURL, "/object/public/"+s.bucket+"/"+path)
+}
+
+// SignedURL implements StorageInterface.
+func (s *supabaseStruct) SignedURL(ctx context.Context, path string, expires time.Duration) (string, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]int{"expiresIn": int(expires.Seconds())})
+	if err != nil {
+		return "", err
+	}
+	req, err := s.newRequest(ctx, http.MethodPost, "/object/sign/"+s.bucket+"/"+path, bytes.NewReader(body))
 	if err != nil {
-		log.Errorf("Error uploading file: %v", err)
 		return "", err
 	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "Supabase SignedURL", 1, err)
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		SignedURL string `json:"signedURL"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return joinURL(s.baseURL, result.SignedURL), nil
+}
+
+// SignedUploadURL implements StorageInterface.
+// Supabase always issues upload tokens valid for two hours, expires only bounds the reported time.
+func (s *supabaseStruct) SignedUploadURL(ctx context.Context, path string, opts PutOptions, expires time.Duration) (*SignedUpload, error) {
+	path, err := cleanPath(path)
+	if err != nil {
+		return nil, err
+	}
 
-	result := client.GetPublicUrl(s.cfg.Supabase.StorageBucket, path)
-	return result.SignedURL, nil
+	req, err := s.newRequest(ctx, http.MethodPost, "/object/upload/sign/"+s.bucket+"/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		logger.Error(ctx, logger.Storage, "Supabase SignedUploadURL", 1, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &SignedUpload{
+		URL:       joinURL(s.baseURL, result.URL),
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": opts.ContentType, "x-upsert": "false"},
+		ExpiresAt: time.Now().Add(expires),
+	}, nil
+}
+
+func (s *supabaseStruct) newRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, joinURL(s.baseURL, endpoint), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.key)
+	req.Header.Set("apikey", s.key)
+	return req, nil
+}
+
+// do sends the request and turns non 2xx responses into errors.
+func (s *supabaseStruct) do(req *http.Request) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	// Supabase reports missing objects as 400 with a not_found error body.
+	if resp.StatusCode == http.StatusNotFound || bytes.Contains(message, []byte("not_found")) || bytes.Contains(message, []byte("Object not found")) {
+		return nil, ErrObjectNotFound
+	}
+	return nil, fmt.Errorf("supabase storage: %s: %s", resp.Status, bytes.TrimSpace(message))
+}
+
+func objectInfoFromHeader(path string, header http.Header) *ObjectInfo {
+	info := &ObjectInfo{
+		Path:        path,
+		Size:        -1,
+		ContentType: header.Get("Content-Type"),
+	}
+	if size, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil {
+		info.Size = size
+	}
+	if modified, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		info.LastModified = modified
+	}
+	return info
 }
 
-func NewSupabase(cfg *config.Config) SupabaseInterface {
+func NewSupabase(cfg *config.Config) StorageInterface {
 	return &supabaseStruct{
-		cfg: cfg,
+		baseURL: cfg.Supabase.StorageUrl,
+		key:     cfg.Supabase.StorageKey,
+		bucket:  cfg.Supabase.StorageBucket,
+		client:  &http.Client{Timeout: 5 * time.Minute},
 	}
 }
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"desadangdang/utils/tracing"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedStorage wraps a driver so every call to the backend gets a span.
+type tracedStorage struct {
+	driver string
+	next   StorageInterface
+}
+
+func newTraced(driver string, next StorageInterface) StorageInterface {
+	return &tracedStorage{driver: driver, next: next}
+}
+
+func (t *tracedStorage) start(ctx context.Context, operation, path string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "storage."+operation, trace.SpanKindClient,
+		attribute.String("storage.driver", t.driver),
+		attribute.String("storage.path", path),
+	)
+}
+
+// Put implements StorageInterface.
+func (t *tracedStorage) Put(ctx context.Context, path string, file io.Reader, opts PutOptions) (url string, err error) {
+	ctx, span := t.start(ctx, "put", path)
+	span.SetAttributes(attribute.Int64("storage.size", opts.Size), attribute.String("storage.content_type", opts.ContentType))
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Put(ctx, path, file, opts)
+}
+
+// Get implements StorageInterface.
+// The span covers opening the object, not reading it.
+func (t *tracedStorage) Get(ctx context.Context, path string) (body io.ReadCloser, info *ObjectInfo, err error) {
+	ctx, span := t.start(ctx, "get", path)
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Get(ctx, path)
+}
+
+// Delete implements StorageInterface.
+func (t *tracedStorage) Delete(ctx context.Context, path string) (err error) {
+	ctx, span := t.start(ctx, "delete", path)
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Delete(ctx, path)
+}
+
+// Stat implements StorageInterface.
+func (t *tracedStorage) Stat(ctx context.Context, path string) (info *ObjectInfo, err error) {
+	ctx, span := t.start(ctx, "stat", path)
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.Stat(ctx, path)
+}
+
+// PublicURL implements StorageInterface.
+func (t *tracedStorage) PublicURL(path string) string {
+	return t.next.PublicURL(path)
+}
+
+// SignedURL implements StorageInterface.
+func (t *tracedStorage) SignedURL(ctx context.Context, path string, expires time.Duration) (url string, err error) {
+	ctx, span := t.start(ctx, "signed_url", path)
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.SignedURL(ctx, path, expires)
+}
+
+// SignedUploadURL implements StorageInterface.
+func (t *tracedStorage) SignedUploadURL(ctx context.Context, path string, opts PutOptions, expires time.Duration) (signed *SignedUpload, err error) {
+	ctx, span := t.start(ctx, "signed_upload_url", path)
+	defer func() { tracing.End(span, err) }()
+
+	return t.next.SignedUploadURL(ctx, path, opts, expires)
+}
+
+// Ping implements StorageInterface.
+func (t *tracedStorage) Ping(ctx context.Context) error {
+	return t.next.Ping(ctx)
+}
@@ -3,34 +3,75 @@ package app
 import (
 	"context"
 	"desadangdang/config"
+	"desadangdang/database/migrate"
+	"desadangdang/database/migrations"
 	"desadangdang/internal/adapater/handler"
 	"desadangdang/internal/adapater/messaging"
+	"desadangdang/internal/adapater/messaging/mailtemplate"
 	"desadangdang/internal/adapater/repository"
 	"desadangdang/internal/adapater/storage"
 	"desadangdang/internal/core/service"
 	"desadangdang/utils/auth"
+	"desadangdang/utils/export"
+	"desadangdang/utils/health"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/metrics"
+	appmiddleware "desadangdang/utils/middleware"
+	"desadangdang/utils/openapi"
+	"desadangdang/utils/tracing"
 	"desadangdang/utils/validator"
-	"log"
+	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	en "github.com/go-playground/validator/v10/translations/en"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/rs/zerolog/log"
 )
 
 func RunServer() {
-	cfg := config.NewConfig()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error loading config")
+		return
+	}
+
+	baseLogger := logger.New(cfg)
+	logger.Setup(baseLogger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error setting up tracing")
+		return
+	}
+
 	db, err := cfg.ConnectionPostgres()
 	if err != nil {
-		log.Fatal("Error connecting to database: %v", err)
+		log.Fatal().Err(err).Msg("Error connecting to database")
+		return
+	}
+	if err = db.DB.Use(tracing.NewGormPlugin()); err != nil {
+		log.Fatal().Err(err).Msg("Error registering gorm tracing")
 		return
 	}
 
 	jwt := auth.NewJwt(cfg)
-	emailMessage := messaging.NewEmailMessaging(cfg)
+	emailMessage, err := messaging.NewEmailMessaging(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating email messaging")
+		return
+	}
+
+	mailRenderer, err := mailtemplate.NewRenderer(mailtemplate.Brand{Name: cfg.App.AppName})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error parsing email templates")
+		return
+	}
 
 	// Repositories
 	userRepo := repository.NewUserRepository(db.DB)
@@ -51,6 +92,11 @@ func RunServer() {
 	statisticRepo := repository.NewStatisticRepository(db.DB)
 	postRepo := repository.NewPostRepository(db.DB)
 	profileRepo := repository.NewProfileRepository(db.DB)
+	emailOutboxRepo := repository.NewEmailOutboxRepository(db.DB)
+	inquiryRepo := repository.NewInquiryRepository(db.DB)
+	mediaRepo := repository.NewMediaRepository(db.DB)
+	trashRepo := repository.NewTrashRepository(db.DB)
+	lastModifiedRepo := repository.NewLastModifiedRepository(db.DB)
 
 	// Services
 	userService := service.NewUserService(userRepo, cfg, jwt)
@@ -61,7 +107,7 @@ func RunServer() {
 	ourTeamService := service.NewOurTeamService(ourTeamRepo)
 	aboutCompanyKeynoteService := service.NewAboutCompanyKeynoteService(aboutCompanyKeynoteRepo, aboutCompanyRepo)
 	serviceSectionService := service.NewServiceSectionService(serviceSectionRepo)
-	appointmentService := service.NewAppointmentService(appointmentRepo, emailMessage)
+	appointmentService := service.NewAppointmentService(appointmentRepo, serviceSectionRepo, mailRenderer, cfg)
 	portofolioService := service.NewPortofolioSectionService(portofolioRepo)
 	portofolioDetailService := service.NewPortofolioDetailService(portofolioDetailRepo, portofolioRepo)
 	portofolioTestimonialService := service.NewPortofolioTestimonialService(portofolioTestimonialRepo, portofolioRepo)
@@ -72,52 +118,119 @@ func RunServer() {
 	// New Post Service
 	postService := service.NewPostService(postRepo)
 	profileService := service.NewProfileService(profileRepo)
+	emailOutboxService := service.NewEmailOutboxService(emailOutboxRepo, emailMessage, cfg)
+	inquiryService := service.NewInquiryService(inquiryRepo, mailRenderer, cfg)
+	trashService := service.NewTrashService(trashRepo)
+	lastModifiedService := service.NewLastModifiedService(lastModifiedRepo)
+	homeService := service.NewHomeService(service.HomeSections{
+		HeroSection:           heroSectionService,
+		ClientSection:         clientSectionService,
+		AboutCompany:          aboutCompanyService,
+		ServiceSection:        serviceSectionService,
+		PortofolioSection:     portofolioService,
+		PortofolioTestimonial: portofolioTestimonialService,
+		FaqSection:            faqService,
+		OurTeam:               ourTeamService,
+		Statistic:             statisticService,
+		ContactUs:             contactUsService,
+	}, cfg)
+
+	storageAdapter, err := storage.NewStorage(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating storage")
+	}
+	mediaService := service.NewMediaService(mediaRepo, storageAdapter, cfg)
 
-	storageAdapter := storage.NewSupabase(cfg)
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error getting database connection")
+	}
+	checker := health.New(time.Duration(cfg.App.HealthTimeout)*time.Second,
+		health.Check{Name: "database", Run: sqlDB.PingContext},
+		health.Check{Name: "migrations", Run: func(ctx context.Context) error {
+			applied, latest, err := migrate.New(sqlDB, migrations.FS).Version(ctx)
+			if err != nil {
+				return err
+			}
+			if applied < latest {
+				return fmt.Errorf("database is at version %d, want %d", applied, latest)
+			}
+			return nil
+		}},
+		health.Check{Name: "storage", Run: storageAdapter.Ping},
+		health.Check{Name: "email", Run: emailMessage.Ping},
+	)
 
 	e := echo.New()
+	e.HTTPErrorHandler = handler.HTTPErrorHandler
+	e.IPExtractor = ipExtractor(cfg)
 	e.Use(middleware.CORS())
+	e.Use(tracing.Middleware(cfg.App.AppName))
+	e.Use(appmiddleware.RequestLogger(baseLogger))
+	e.Use(metrics.Middleware())
+	e.Use(appmiddleware.DBTimeout(time.Duration(cfg.Psql.RequestTimeout)*time.Second, unbounded))
+	e.Use(middleware.Recover())
 
 	// Custom Validator
 	customValidator := validator.NewValidator()
 	en.RegisterDefaultTranslations(customValidator.Validator, customValidator.Translator)
 	e.Validator = customValidator
 
-	// Health check route
-	e.GET("/api/check", func(c echo.Context) error {
-		return c.String(200, "OK")
-	})
-
-	// Handlers
-	handler.NewUserHandler(e, userService)
-	handler.NewUploadImage(e, storageAdapter, cfg)
-	handler.NewHeroSectionHandler(e, cfg, heroSectionService)
-	handler.NewClientSectionHandler(e, clientSectionService, cfg)
-	handler.NewAboutCompanyHandler(e, aboutCompanyService, cfg)
-	handler.NewFaqSectionHandler(e, faqService, cfg)
-	handler.NewOurTeamHandler(e, cfg, ourTeamService)
-	handler.NewAboutCompanyKeynoteHandler(e, aboutCompanyKeynoteService, cfg)
-	handler.NewServiceSectionHandler(e, serviceSectionService, cfg)
-	handler.NewAppointmentHandler(e, appointmentService, cfg)
-	handler.NewPortofolioSectionHandler(e, portofolioService, cfg)
-	handler.NewPortofolioDetailHandler(e, portofolioDetailService, cfg)
-	handler.NewPortofolioTestimonialHandler(e, portofolioTestimonialService, cfg)
-	handler.NewContactUsHandler(e, contactUsService, cfg)
-	handler.NewServiceDetailHandler(e, serviceDetailService, cfg)
-	// New Statistic Handler
-	handler.NewStatisticHandler(e, cfg, statisticService)
-	handler.NewPostHandler(e, cfg, postService)
-	handler.NewProfileHandler(e, cfg, profileService)
+	if cfg.Metrics.Enabled {
+		if err = metrics.RegisterDB(sqlDB, cfg.Psql.DBName); err != nil {
+			log.Error().Err(err).Msg("Error registering database metrics")
+		}
+	}
+
+	doc := handler.OpenAPIDocument(cfg)
+	err = registerRoutes(e, cfg, services{
+		checker:               checker,
+		storage:               storageAdapter,
+		mailRenderer:          mailRenderer,
+		user:                  userService,
+		media:                 mediaService,
+		heroSection:           heroSectionService,
+		clientSection:         clientSectionService,
+		aboutCompany:          aboutCompanyService,
+		faqSection:            faqService,
+		ourTeam:               ourTeamService,
+		aboutCompanyKeynote:   aboutCompanyKeynoteService,
+		serviceSection:        serviceSectionService,
+		appointment:           appointmentService,
+		portofolioSection:     portofolioService,
+		portofolioDetail:      portofolioDetailService,
+		portofolioTestimonial: portofolioTestimonialService,
+		contactUs:             contactUsService,
+		serviceDetail:         serviceDetailService,
+		statistic:             statisticService,
+		post:                  postService,
+		profile:               profileService,
+		emailOutbox:           emailOutboxService,
+		inquiry:               inquiryService,
+		trash:                 trashService,
+		home:                  homeService,
+		lastModified:          lastModifiedService,
+	}, doc)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error registering routes")
+	}
+	if missing, _ := openapi.Diff(doc, e.Routes()); len(missing) > 0 {
+		log.Warn().Strs("routes", missing).Msg("Routes missing from the openapi document")
+	}
 
-	// Starting server
+	// Background email outbox worker
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	workerDone := make(chan struct{})
 	go func() {
-		if cfg.App.AppPort == "" {
-			cfg.App.AppPort = os.Getenv("APP_PORT")
-		}
+		defer close(workerDone)
+		emailOutboxService.RunWorker(workerCtx)
+	}()
 
+	// Starting server
+	go func() {
 		err := e.Start(":" + cfg.App.AppPort)
 		if err != nil {
-			log.Fatal("error starting server: ", err)
+			log.Fatal().Err(err).Msg("Error starting server")
 		}
 	}()
 
@@ -128,11 +241,63 @@ func RunServer() {
 	// Block until a signal is received.
 	<-quit
 
-	log.Println("server shutdown of 5 second.")
+	// Fail readiness first so the load balancer stops routing here while
+	// the requests already in flight finish.
+	checker.Shutdown()
+	if cfg.App.ShutdownDelay > 0 {
+		log.Info().Msgf("readiness failing, waiting %d second before shutdown.", cfg.App.ShutdownDelay)
+		time.Sleep(time.Duration(cfg.App.ShutdownDelay) * time.Second)
+	}
+
+	log.Info().Msg("server shutdown of 5 second.")
 
 	// gracefully shutdown the server, waiting max 5 seconds for current operations to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	e.Shutdown(ctx)
+
+	stopWorker()
+	select {
+	case <-workerDone:
+	case <-ctx.Done():
+	}
+
+	if err = shutdownTracing(ctx); err != nil {
+		log.Error().Err(err).Msg("Error flushing traces")
+	}
+}
+
+// unbounded picks the requests running as long as the client needs, the
+// uploads and the exports, by their route. Anything a client can add to any
+// request, like a query parameter, would let it lift the timeout anywhere.
+func unbounded(c echo.Context) bool {
+	if strings.HasPrefix(c.Path(), storage.LocalUploadRoute) {
+		return true
+	}
+
+	switch strings.TrimPrefix(c.Path(), handler.APIV1Prefix) {
+	case "/upload-image", "/upload-file":
+		return true
+	case "/appointments/admin", "/statistics/admin":
+		return export.IsSupported(c.QueryParam("format"))
+	}
+	return false
+}
+
+// ipExtractor reads the client ip from X-Forwarded-For only when a trusted
+// proxy sent the request, the rate limits and the stored ip addresses would
+// otherwise take any header a client makes up.
+func ipExtractor(cfg *config.Config) echo.IPExtractor {
+	if len(cfg.App.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range cfg.App.TrustedProxies {
+		// validated by config.Load
+		_, ipRange, _ := net.ParseCIDR(cidr)
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
 }
@@ -0,0 +1,32 @@
+package app
+
+import (
+	"desadangdang/config"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// RunConfigCheck prints the effective config with secrets redacted and
+// returns the validation problems, if any.
+func RunConfigCheck() error {
+	if file := config.FileUsed(); file != "" {
+		fmt.Printf("config file: %s\n\n", file)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tENV\tVALUE\tSOURCE")
+	for _, s := range config.EffectiveSettings() {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Key, s.Env, s.Value, s.Source)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	fmt.Println("config is valid")
+	return nil
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/internal/adapater/storage"
+	"desadangdang/internal/core/service"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunMediaCleanup deletes uploaded files that no record references anymore.
+// With dryRun the orphans are only listed.
+func RunMediaCleanup(olderThan time.Duration, dryRun bool) error {
+	cfg, err := config.Load(config.GroupDatabase, config.GroupStorage)
+	if err != nil {
+		return err
+	}
+
+	db, err := cfg.ConnectionPostgres()
+	if err != nil {
+		return err
+	}
+
+	storageAdapter, err := storage.NewStorage(cfg)
+	if err != nil {
+		return err
+	}
+
+	mediaService := service.NewMediaService(repository.NewMediaRepository(db.DB), storageAdapter, cfg)
+	results, err := mediaService.CleanupOrphanMedia(context.Background(), olderThan, dryRun)
+
+	action := "deleted"
+	if dryRun {
+		action = "orphan"
+	}
+	for _, val := range results {
+		log.Info().Msgf("%s media %d %s (%d bytes)", action, val.ID, val.Path, val.Size)
+	}
+	log.Info().Msgf("%d media %s", len(results), action)
+
+	return err
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/database/migrate"
+	"desadangdang/database/migrations"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunMigrateUp applies every pending migration.
+func RunMigrateUp() error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	done, err := migrator.Up(context.Background())
+	for _, mig := range done {
+		log.Info().Msgf("applied %06d_%s", mig.Version, mig.Name)
+	}
+	if err == nil && len(done) == 0 {
+		log.Info().Msg("no pending migrations")
+	}
+	return err
+}
+
+// RunMigrateDown rolls back the last n applied migrations.
+func RunMigrateDown(n int) error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	done, err := migrator.Down(context.Background(), n)
+	for _, mig := range done {
+		log.Info().Msgf("rolled back %06d_%s", mig.Version, mig.Name)
+	}
+	return err
+}
+
+// RunMigrateStatus prints every migration and when it was applied.
+func RunMigrateStatus() error {
+	migrator, err := newMigrator()
+	if err != nil {
+		return err
+	}
+
+	results, err := migrator.Status(context.Background())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, mig := range results {
+		status, appliedAt := "pending", "-"
+		if mig.AppliedAt != nil {
+			status, appliedAt = "applied", mig.AppliedAt.Format("02 Jan 2006 15:04:05")
+		}
+		if mig.Missing {
+			status = "missing file"
+		}
+		fmt.Fprintf(w, "%06d\t%s\t%s\t%s\n", mig.Version, mig.Name, status, appliedAt)
+	}
+	return w.Flush()
+}
+
+// RunMigrateCreate adds an empty up and down file for a new version to dir.
+func RunMigrateCreate(dir, name string) error {
+	up, down, err := migrate.Create(dir, name)
+	if err != nil {
+		return err
+	}
+	log.Info().Msgf("created %s", up)
+	log.Info().Msgf("created %s", down)
+	return nil
+}
+
+func newMigrator() (*migrate.Migrator, error) {
+	cfg, err := config.Load(config.GroupDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := cfg.ConnectionPostgres()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, migrations.FS), nil
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler"
+	"desadangdang/internal/adapater/storage"
+	"desadangdang/utils/openapi"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RunOpenAPI writes the openapi document to w.
+func RunOpenAPI(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(handler.OpenAPIDocument(config.NewConfig()))
+}
+
+// RunOpenAPICheck registers the routes the way the server does, with the
+// optional ones switched on, and fails when a route is missing from the
+// document or a documented one isn't registered.
+func RunOpenAPICheck() error {
+	cfg := config.NewConfig()
+	cfg.Metrics.Enabled = true
+	cfg.Storage.Driver = storage.DriverLocal
+	cfg.API.LegacyRoutes = true
+
+	doc := handler.OpenAPIDocument(cfg)
+	e := echo.New()
+	if err := registerRoutes(e, cfg, services{}, doc); err != nil {
+		return err
+	}
+
+	missing, stale := openapi.Diff(doc, e.Routes())
+	for _, route := range missing {
+		fmt.Println("not documented:", route)
+	}
+	for _, route := range stale {
+		fmt.Println("not registered:", route)
+	}
+	if len(missing) > 0 || len(stale) > 0 {
+		return fmt.Errorf("openapi document is out of date: %d routes not documented, %d not registered", len(missing), len(stale))
+	}
+
+	operations := 0
+	for _, ops := range doc.Paths {
+		operations += len(ops)
+	}
+	fmt.Printf("all %d routes are documented\n", operations)
+	return nil
+}
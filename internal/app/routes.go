@@ -0,0 +1,244 @@
+package app
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler"
+	"desadangdang/internal/adapater/messaging/mailtemplate"
+	"desadangdang/internal/adapater/storage"
+	"desadangdang/internal/core/service"
+	"desadangdang/utils/health"
+	"desadangdang/utils/metrics"
+	appmiddleware "desadangdang/utils/middleware"
+	"desadangdang/utils/openapi"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// services holds what the handlers are built from. The constructors only keep
+// them, so the routes can be listed with zero values, see RunOpenAPICheck.
+type services struct {
+	checker               *health.Checker
+	storage               storage.StorageInterface
+	mailRenderer          mailtemplate.RendererInterface
+	user                  service.UserServiceInterface
+	media                 service.MediaServiceInterface
+	heroSection           service.HeroSectionServiceInterface
+	clientSection         service.ClientSectionServiceInterface
+	aboutCompany          service.AboutCompanyServiceInterface
+	faqSection            service.FaqSectionServiceInterface
+	ourTeam               service.OurTeamServiceInterface
+	aboutCompanyKeynote   service.AboutCompanyKeynoteServiceInterface
+	serviceSection        service.ServiceSectionServiceInterface
+	appointment           service.AppointmentServiceInterface
+	portofolioSection     service.PortofolioSectionServiceInterface
+	portofolioDetail      service.PortofolioDetailServiceInterface
+	portofolioTestimonial service.PortofolioTestimonialServiceInterface
+	contactUs             service.ContactUsServiceInterface
+	serviceDetail         service.ServiceDetailServiceInterface
+	statistic             service.StatisticServiceInterface
+	post                  service.PostServiceInterface
+	profile               service.ProfileServiceInterface
+	emailOutbox           service.EmailOutboxServiceInterface
+	inquiry               service.InquiryServiceInterface
+	trash                 service.TrashServiceInterface
+	home                  service.HomeServiceInterface
+	lastModified          service.LastModifiedServiceInterface
+}
+
+// handlerSet is the handlers of an api version, registered on the group of
+// the version in order. A new version starts from the previous set and adds
+// or replaces handlers by name, the ones it keeps are registered by the same
+// function. It runs once per group, so state the versions must share, like
+// a rate limit, is built outside of it:
+//
+//	v2 := v1.with("posts", func(g *echo.Group) { handler.NewPostV2Handler(g, cfg, s.post) })
+//	v2.register(e.Group("/api/v2"))
+type handlerSet struct {
+	names     []string
+	registers map[string]func(g *echo.Group)
+}
+
+// with returns a copy of h where name is registered by register. A new name
+// goes last, a replaced one keeps its place.
+func (h handlerSet) with(name string, register func(g *echo.Group)) handlerSet {
+	next := handlerSet{
+		names:     append([]string{}, h.names...),
+		registers: make(map[string]func(g *echo.Group), len(h.registers)+1),
+	}
+	for n, r := range h.registers {
+		next.registers[n] = r
+	}
+	if _, ok := next.registers[name]; !ok {
+		next.names = append(next.names, name)
+	}
+	next.registers[name] = register
+	return next
+}
+
+// without returns a copy of h without the handlers named names.
+func (h handlerSet) without(names ...string) handlerSet {
+	next := handlerSet{registers: make(map[string]func(g *echo.Group), len(h.registers))}
+	for _, name := range h.names {
+		if !slices.Contains(names, name) {
+			next.names = append(next.names, name)
+			next.registers[name] = h.registers[name]
+		}
+	}
+	return next
+}
+
+func (h handlerSet) register(g *echo.Group) {
+	for _, name := range h.names {
+		h.registers[name](g)
+	}
+}
+
+// v1Handlers returns the handlers of /api/v1.
+func v1Handlers(cfg *config.Config, s services) handlerSet {
+	inquiryLimiter := handler.InquiryRateLimiter()
+
+	return handlerSet{}.
+		with("auth", func(g *echo.Group) { handler.NewUserHandler(g, s.user) }).
+		with("uploads", func(g *echo.Group) { handler.NewUploadImage(g, s.storage, s.media, cfg) }).
+		with("media", func(g *echo.Group) { handler.NewMediaHandler(g, s.media, cfg) }).
+		with("hero-sections", func(g *echo.Group) { handler.NewHeroSectionHandler(g, cfg, s.heroSection) }).
+		with("client-sections", func(g *echo.Group) { handler.NewClientSectionHandler(g, s.clientSection, cfg) }).
+		with("about-companies", func(g *echo.Group) { handler.NewAboutCompanyHandler(g, s.aboutCompany, cfg) }).
+		with("faq-sections", func(g *echo.Group) { handler.NewFaqSectionHandler(g, s.faqSection, cfg) }).
+		with("our-teams", func(g *echo.Group) { handler.NewOurTeamHandler(g, cfg, s.ourTeam) }).
+		with("about-company-keynotes", func(g *echo.Group) {
+			handler.NewAboutCompanyKeynoteHandler(g, s.aboutCompanyKeynote, cfg)
+		}).
+		with("service-sections", func(g *echo.Group) { handler.NewServiceSectionHandler(g, s.serviceSection, cfg) }).
+		with("appointments", func(g *echo.Group) { handler.NewAppointmentHandler(g, s.appointment, cfg) }).
+		with("portofolio-sections", func(g *echo.Group) {
+			handler.NewPortofolioSectionHandler(g, s.portofolioSection, cfg)
+		}).
+		with("portofolio-details", func(g *echo.Group) {
+			handler.NewPortofolioDetailHandler(g, s.portofolioDetail, cfg)
+		}).
+		with("portofolio-testimonials", func(g *echo.Group) {
+			handler.NewPortofolioTestimonialHandler(g, s.portofolioTestimonial, cfg)
+		}).
+		with("contact-us", func(g *echo.Group) { handler.NewContactUsHandler(g, s.contactUs, cfg) }).
+		with("service-details", func(g *echo.Group) { handler.NewServiceDetailHandler(g, s.serviceDetail, cfg) }).
+		with("statistics", func(g *echo.Group) { handler.NewStatisticHandler(g, cfg, s.statistic) }).
+		with("posts", func(g *echo.Group) { handler.NewPostHandler(g, cfg, s.post) }).
+		with("profile", func(g *echo.Group) { handler.NewProfileHandler(g, cfg, s.profile) }).
+		with("email-outbox", func(g *echo.Group) { handler.NewEmailOutboxHandler(g, s.emailOutbox, cfg) }).
+		with("email-templates", func(g *echo.Group) { handler.NewEmailTemplateHandler(g, s.mailRenderer, cfg) }).
+		with("inquiries", func(g *echo.Group) { handler.NewInquiryHandler(g, s.inquiry, cfg, inquiryLimiter) }).
+		with("trash", func(g *echo.Group) { handler.NewTrashHandler(g, s.trash, cfg) }).
+		with("home", func(g *echo.Group) { handler.NewHomeHandler(g, s.home) })
+}
+
+// registerRoutes registers every route of the api, doc is served as the
+// openapi document.
+func registerRoutes(e *echo.Echo, cfg *config.Config, s services, doc *openapi.Document) error {
+	// Health check route
+	e.GET("/api/check", func(c echo.Context) error {
+		return c.String(200, "OK")
+	})
+
+	if cfg.Metrics.Enabled {
+		e.GET("/metrics", metrics.Handler(cfg.Metrics.Token))
+	}
+
+	if cfg.Storage.Driver == storage.DriverLocal {
+		localDir := cfg.Storage.LocalDir
+		if localDir == "" {
+			localDir = storage.DefaultLocalDir
+		}
+		e.Static(storage.DefaultLocalRoute, localDir)
+		handler.NewLocalUpload(e, s.storage, cfg)
+	}
+
+	handler.NewHealthHandler(e, s.checker)
+	e.Use(handler.InvalidateHome(s.home))
+
+	v1 := v1Handlers(cfg, s)
+	v1.register(e.Group(handler.APIV1Prefix))
+	paths := apiPaths(e.Routes())
+
+	// The paths from before the versioning keep working until the sunset
+	if cfg.API.LegacyRoutes {
+		sunset, err := time.Parse(time.DateOnly, cfg.API.LegacySunset)
+		if err != nil {
+			return fmt.Errorf("parse API_LEGACY_SUNSET: %w", err)
+		}
+
+		// home came with /api/v1, it never had an unversioned path
+		v1.without("home").register(e.Group(""))
+		e.Use(appmiddleware.Deprecated(paths, handler.LegacyDeprecatedAt, sunset, handler.APIV1Prefix))
+	}
+	e.Use(appmiddleware.HTTPCache(cacheControl(cfg, paths), lastModified(s.lastModified)))
+
+	_, err := handler.NewOpenAPIHandler(e, doc)
+	return err
+}
+
+// apiPaths returns the paths of the /api/v1 routes without the prefix, the
+// way the legacy routes are registered.
+func apiPaths(routes []*echo.Route) []string {
+	var paths []string
+	for _, r := range routes {
+		if path, ok := strings.CutPrefix(r.Path, handler.APIV1Prefix); ok {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// cacheControl returns the Cache-Control of the api routes, the other routes
+// get none. Admin routes, writes and requests with a token are never stored,
+// the public reads get the value of their route group, like posts.
+func cacheControl(cfg *config.Config, paths []string) func(c echo.Context) string {
+	api := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		api[p] = true
+	}
+
+	return func(c echo.Context) string {
+		path, versioned := strings.CutPrefix(c.Path(), handler.APIV1Prefix)
+		if !versioned && !(cfg.API.LegacyRoutes && api[path]) {
+			return ""
+		}
+
+		method := c.Request().Method
+		if (method != http.MethodGet && method != http.MethodHead) ||
+			strings.Contains(path+"/", "/admin/") ||
+			c.Request().Header.Get(echo.HeaderAuthorization) != "" {
+			return "no-store"
+		}
+
+		if control, ok := cfg.HTTPCache.Groups[routeGroup(path)]; ok {
+			return control
+		}
+		return cfg.HTTPCache.Control
+	}
+}
+
+// lastModified returns the Last-Modified of the public reads, taken from the
+// tables of their route group. Without one the response only has an ETag.
+func lastModified(lastModifiedService service.LastModifiedServiceInterface) func(c echo.Context) (time.Time, bool) {
+	return func(c echo.Context) (time.Time, bool) {
+		if lastModifiedService == nil {
+			return time.Time{}, false
+		}
+
+		group := routeGroup(strings.TrimPrefix(c.Path(), handler.APIV1Prefix))
+		modified, ok, err := lastModifiedService.FetchLastModified(c.Request().Context(), group)
+		return modified, ok && err == nil
+	}
+}
+
+// routeGroup returns the first segment of an api path, like posts.
+func routeGroup(path string) string {
+	group, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return group
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"desadangdang/config"
+	"desadangdang/internal/adapater/handler"
+	"desadangdang/internal/adapater/storage"
+	"desadangdang/utils/openapi"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TestRoutesDocumented fails when a registered route is missing from the
+// openapi document, or a documented one isn't registered.
+func TestRoutesDocumented(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Metrics.Enabled = true
+	cfg.Storage.Driver = storage.DriverLocal
+	cfg.API.LegacyRoutes = true
+	cfg.API.LegacySunset = "2027-04-30"
+
+	doc := handler.OpenAPIDocument(cfg)
+	e := echo.New()
+	if err := registerRoutes(e, cfg, services{}, doc); err != nil {
+		t.Fatalf("register routes: %v", err)
+	}
+
+	missing, stale := openapi.Diff(doc, e.Routes())
+	for _, route := range missing {
+		t.Errorf("not documented: %s", route)
+	}
+	for _, route := range stale {
+		t.Errorf("not registered: %s", route)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/database/seeds"
+)
+
+// RunSeed runs the named seeders, or every seeder allowed in the configured
+// environment when names is empty.
+func RunSeed(names []string, opts seeds.Options) error {
+	cfg, err := config.Load(config.GroupDatabase)
+	if err != nil {
+		return err
+	}
+
+	db, err := cfg.ConnectionPostgres()
+	if err != nil {
+		return err
+	}
+
+	return seeds.Run(context.Background(), db.DB, cfg.App.AppEnv, names, opts)
+}
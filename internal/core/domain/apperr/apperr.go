@@ -0,0 +1,165 @@
+// Package apperr holds the errors shared by every layer. Repositories and
+// services return them, the echo error handler turns them into a status and
+// a stable code. Message is always safe to show, the cause is only logged.
+package apperr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindTooLarge
+	KindUnsupportedType
+	KindCanceled
+	KindTimeout
+)
+
+// StatusClientClosedRequest is the nginx status for a client that went away
+// before the response was ready.
+const StatusClientClosedRequest = 499
+
+// Status returns the http status of the kind.
+func (k Kind) Status() int {
+	switch k {
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case KindUnsupportedType:
+		return http.StatusUnsupportedMediaType
+	case KindCanceled:
+		return StatusClientClosedRequest
+	case KindTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Codes are part of the api, clients may branch on them. Never rename one.
+const (
+	CodeInternal         = "internal_error"
+	CodeNotFound         = "not_found"
+	CodeRouteNotFound    = "route_not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeConflict         = "conflict"
+	CodeDuplicate        = "duplicate"
+	CodeReferenced       = "still_referenced"
+	CodeValidation       = "validation_failed"
+	CodeInvalidBody      = "invalid_body"
+	CodeInvalidID        = "invalid_id"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeTooLarge         = "too_large"
+	CodeUnsupportedType  = "unsupported_type"
+	CodeCanceled         = "request_canceled"
+	CodeTimeout          = "timeout"
+
+	CodeWrongCredentials   = "wrong_credentials"
+	CodeUserExists         = "user_exists"
+	CodeSlugTaken          = "slug_taken"
+	CodeMediaInUse         = "media_in_use"
+	CodeTrashParentDeleted = "trash_parent_deleted"
+)
+
+var (
+	ErrInternal     = New(KindInternal, CodeInternal, "internal server error")
+	ErrNotFound     = New(KindNotFound, CodeNotFound, "data not found")
+	ErrInvalidBody  = New(KindValidation, CodeInvalidBody, "invalid request body")
+	ErrInvalidID    = New(KindValidation, CodeInvalidID, "invalid id")
+	ErrUnauthorized = New(KindUnauthorized, CodeUnauthorized, "Unauthorized")
+	ErrForbidden    = New(KindForbidden, CodeForbidden, "Forbidden")
+	ErrCanceled     = New(KindCanceled, CodeCanceled, "request canceled")
+	ErrTimeout      = New(KindTimeout, CodeTimeout, "request timed out")
+)
+
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	// Err is the cause, it is logged but never sent to the client.
+	Err error
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func NotFound(message string) *Error {
+	return New(KindNotFound, CodeNotFound, message)
+}
+
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func Validation(message string) *Error {
+	return New(KindValidation, CodeValidation, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(KindUnauthorized, CodeUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(KindForbidden, CodeForbidden, message)
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors with the same kind and code, so a wrapped copy still
+// matches the sentinel it was made from.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Code == e.Code
+}
+
+// Wrap returns a copy of e caused by err.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// From returns the domain error in err's chain. A cancelled or timed out
+// context gets its own kind, anything else is internal.
+func From(err error) *Error {
+	var appErr *Error
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.Is(err, context.Canceled):
+		return ErrCanceled.Wrap(err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout.Wrap(err)
+	default:
+		return ErrInternal.Wrap(err)
+	}
+}
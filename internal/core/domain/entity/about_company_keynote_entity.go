@@ -5,5 +5,7 @@ type AboutCompanyKeynoteEntity struct {
 	AboutCompanyID          int64
 	Keynote                 string
 	PathImage               string
+	ImageAlt                string
+	ImageCaption            string
 	AboutCompanyDescription string
 }
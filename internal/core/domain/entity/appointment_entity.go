@@ -13,3 +13,10 @@ type AppointmentEntity struct {
 	MeetAt      time.Time
 	ServiceName string
 }
+
+type AppointmentFilter struct {
+	Search    string
+	ServiceID int64
+	StartDate *time.Time
+	EndDate   *time.Time
+}
@@ -1,7 +1,9 @@
 package entity
 
 type ClientSectionEntity struct {
-	ID       int64
-	Name     string
-	PathIcon string
+	ID          int64
+	Name        string
+	PathIcon    string
+	IconAlt     string
+	IconCaption string
 }
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusDead    = "dead"
+)
+
+type EmailOutboxEntity struct {
+	ID            int64
+	FromAddress   string
+	ToAddress     string
+	Subject       string
+	Body          string
+	TextBody      string
+	Traceparent   string
+	Status        string
+	Attempts      int
+	MaxAttempts   int
+	LastError     string
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+}
@@ -1,9 +1,11 @@
 package entity
 
 type HeroSectionEntity struct {
-	ID         int64
-	Heading    string
-	SubHeading string
-	PathVideo  string
-	Banner     string
+	ID            int64
+	Heading       string
+	SubHeading    string
+	PathVideo     string
+	Banner        string
+	BannerAlt     string
+	BannerCaption string
 }
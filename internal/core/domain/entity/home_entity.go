@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// HomeEntity is every section of the home page. AboutCompany and ContactUs
+// are nil until one is created.
+type HomeEntity struct {
+	HeroSections           []HeroSectionEntity
+	ClientSections         []ClientSectionEntity
+	AboutCompany           *AboutCompanyEntity
+	ServiceSections        []ServiceSectionEntity
+	PortofolioSections     []PortofolioSectionEntity
+	PortofolioTestimonials []PortofolioTestimonialEntity
+	FaqSections            []FaqSectionEntity
+	OurTeams               []OurTeamEntity
+	Statistics             []StatisticEntity
+	ContactUs              *ContactUsEntity
+	// AssembledAt is when the sections were read, a cached page can be
+	// older than its tables.
+	AssembledAt time.Time
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+type InquiryEntity struct {
+	ID          int64
+	Name        string
+	Email       string
+	PhoneNumber string
+	Subject     string
+	Message     string
+	IpAddress   string
+	ReadAt      *time.Time
+	ArchivedAt  *time.Time
+	CreatedAt   time.Time
+	Replies     []InquiryReplyEntity
+}
+
+type InquiryReplyEntity struct {
+	ID        int64
+	InquiryID int64
+	UserID    int64
+	Message   string
+	CreatedAt time.Time
+}
+
+type InquiryFilter struct {
+	Status   string
+	Archived bool
+	Search   string
+}
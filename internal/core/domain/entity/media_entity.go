@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+type MediaEntity struct {
+	ID           int64
+	UserID       int64
+	FileKey      string
+	Path         string
+	URL          string
+	Purpose      string
+	ContentType  string
+	Size         int64
+	Width        int
+	Height       int
+	AltText      string
+	OriginalName string
+	CreatedAt    time.Time
+	Usages       []MediaUsageEntity
+}
+
+// MediaUsageEntity points at a record whose column references the file.
+type MediaUsageEntity struct {
+	Resource   string
+	ResourceID int64
+	Field      string
+}
+
+type MediaFilter struct {
+	Search  string
+	Purpose string
+}
+
+// MediaUploadEntity is a direct upload request together with the signed
+// request and ticket handed back to the client.
+type MediaUploadEntity struct {
+	Purpose      string
+	ContentType  string
+	Size         int64
+	OriginalName string
+	Path         string
+	Token        string
+	URL          string
+	Method       string
+	Headers      map[string]string
+	ExpiresAt    time.Time
+}
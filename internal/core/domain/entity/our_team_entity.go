@@ -1,9 +1,11 @@
 package entity
 
 type OurTeamEntity struct {
-	ID        int64
-	Name      string
-	Role      string
-	PathPhoto string
-	Tagline   string
+	ID           int64
+	Name         string
+	Role         string
+	PathPhoto    string
+	PhotoAlt     string
+	PhotoCaption string
+	Tagline      string
 }
@@ -1,8 +1,10 @@
 package entity
 
 type PortofolioSectionEntity struct {
-	ID        int64
-	Name      string
-	Tagline   string
-	Thumbnail string
+	ID               int64
+	Name             string
+	Tagline          string
+	Thumbnail        string
+	ThumbnailAlt     string
+	ThumbnailCaption string
 }
@@ -8,6 +8,8 @@ type PostEntity struct {
 	Slug          string
 	Author       string
 	FeaturedImage string
+	FeaturedImageAlt string
+	FeaturedImageCaption string
 	Content      string
 	PublishedAt  time.Time
 }
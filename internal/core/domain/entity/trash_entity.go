@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// TrashEntity is a soft deleted record that can still be restored or purged.
+type TrashEntity struct {
+	Resource  string
+	ID        int64
+	Label     string
+	DeletedAt time.Time
+}
@@ -11,6 +11,8 @@ type AboutCompanyKeynote struct {
 	AboutCompanyID int64
 	Keypoint       string
 	PathImage      *string
+	ImageAlt       *string
+	ImageCaption   *string
 	CreatedAt      time.Time
 	UpdatedAt      *time.Time
 	DeletedAt      gorm.DeletedAt `gorm:"index"`
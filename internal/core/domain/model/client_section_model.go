@@ -7,10 +7,12 @@ import (
 )
 
 type ClientSection struct {
-	ID        int64 `gorm:"id,primaryKey"`
-	Name      string
-	PathIcon  string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID          int64 `gorm:"id,primaryKey"`
+	Name        string
+	PathIcon    string
+	IconAlt     *string
+	IconCaption *string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
 }
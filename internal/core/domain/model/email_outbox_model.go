@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+type EmailOutbox struct {
+	ID            int64 `gorm:"id,primaryKey"`
+	FromAddress   string
+	ToAddress     string
+	Subject       string
+	Body          string
+	TextBody      *string
+	Traceparent   *string
+	Status        string
+	Attempts      int
+	MaxAttempts   int
+	LastError     *string
+	NextAttemptAt time.Time
+	SentAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+}
+
+func (EmailOutbox) TableName() string {
+	return "email_outbox"
+}
@@ -7,12 +7,14 @@ import (
 )
 
 type HeroSection struct {
-	ID         int64 `gorm:"id,primaryKey"`
-	Heading    string
-	SubHeading string
-	PathVideo  *string
-	PathBanner string
-	CreatedAt  time.Time
-	UpdatedAt  *time.Time
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
+	ID            int64 `gorm:"id,primaryKey"`
+	Heading       string
+	SubHeading    string
+	PathVideo     *string
+	PathBanner    string
+	BannerAlt     *string
+	BannerCaption *string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
 }
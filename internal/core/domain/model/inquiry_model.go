@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Inquiry struct {
+	ID          int64 `gorm:"id,primaryKey"`
+	Name        string
+	Email       string
+	PhoneNumber *string
+	Subject     string
+	Message     string
+	IpAddress   *string
+	ReadAt      *time.Time
+	ArchivedAt  *time.Time
+	Replies     []InquiryReply
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+type InquiryReply struct {
+	ID        int64 `gorm:"id,primaryKey"`
+	InquiryID int64
+	UserID    *int64
+	Message   string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
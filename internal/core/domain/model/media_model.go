@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type Media struct {
+	ID           int64 `gorm:"id,primaryKey"`
+	UserID       *int64
+	FileKey      string
+	Path         string
+	URL          string `gorm:"column:url"`
+	Purpose      string
+	ContentType  string
+	Size         int64
+	Width        *int
+	Height       *int
+	AltText      *string
+	OriginalName *string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+func (Media) TableName() string {
+	return "media"
+}
@@ -7,12 +7,14 @@ import (
 )
 
 type OurTeam struct {
-	ID        int64 `gorm:"id,primaryKey"`
-	Name      string
-	Role      string
-	PathPhoto string
-	Tagline   string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID           int64 `gorm:"id,primaryKey"`
+	Name         string
+	Role         string
+	PathPhoto    string
+	PhotoAlt     *string
+	PhotoCaption *string
+	Tagline      string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
 }
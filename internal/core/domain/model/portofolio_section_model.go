@@ -7,11 +7,13 @@ import (
 )
 
 type PortofolioSection struct {
-	ID        int64 `gorm:"id,primaryKey"`
-	Name      string
-	Tagline   string
-	Thumbnail *string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID               int64 `gorm:"id,primaryKey"`
+	Name             string
+	Tagline          string
+	Thumbnail        *string
+	ThumbnailAlt     *string
+	ThumbnailCaption *string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
 }
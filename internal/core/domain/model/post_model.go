@@ -12,6 +12,8 @@ type Post struct {
 	Slug          string         `gorm:"slug"`
 	Author       string         `gorm:"author"`
 	FeaturedImage string         `gorm:"featured_image"`
+	FeaturedImageAlt *string
+	FeaturedImageCaption *string
 	Content      string         `gorm:"content"`
 	PublishedAt  time.Time      `gorm:"published_at"`
 	CreatedAt   time.Time      `gorm:"created_at"`
@@ -4,8 +4,7 @@ import (
 	"context"
 	"desadangdang/internal/adapater/repository"
 	"desadangdang/internal/core/domain/entity"
-
-	"github.com/labstack/gommon/log"
+	"desadangdang/utils/logger"
 )
 
 type AboutCompanyKeynoteServiceInterface interface {
@@ -31,7 +30,7 @@ func (c *aboutCompanyKeynoteService) FetchByCompanyID(ctx context.Context, compa
 func (c *aboutCompanyKeynoteService) CreateAboutCompanyKeynote(ctx context.Context, req entity.AboutCompanyKeynoteEntity) error {
 	_, err := c.aboutCompanyRepo.FetchByIDAboutCompany(ctx, req.AboutCompanyID)
 	if err != nil {
-		log.Errorf("[SERVICE] CreateAboutCompanyKeynote - 1: %v", err)
+		logger.Error(ctx, logger.Service, "CreateAboutCompanyKeynote", 1, err)
 		return err
 	}
 
@@ -47,7 +46,7 @@ func (c *aboutCompanyKeynoteService) DeleteByIDAboutCompanyKeynote(ctx context.C
 func (c *aboutCompanyKeynoteService) EditByIDAboutCompanyKeynote(ctx context.Context, req entity.AboutCompanyKeynoteEntity) error {
 	_, err := c.aboutCompanyRepo.FetchByIDAboutCompany(ctx, req.AboutCompanyID)
 	if err != nil {
-		log.Errorf("[SERVICE] EditByIDAboutCompanyKeynote - 1: %v", err)
+		logger.Error(ctx, logger.Service, "EditByIDAboutCompanyKeynote", 1, err)
 		return err
 	}
 	return c.aboutCompanyKeynoteRepo.EditByIDAboutCompanyKeynote(ctx, req)
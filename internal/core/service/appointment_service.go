@@ -2,51 +2,105 @@ package service
 
 import (
 	"context"
-	"desadangdang/internal/adapater/messaging"
+	"desadangdang/config"
+	"desadangdang/internal/adapater/messaging/mailtemplate"
 	"desadangdang/internal/adapater/repository"
 	"desadangdang/internal/core/domain/entity"
-	"fmt"
-
-	"github.com/labstack/gommon/log"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/metrics"
 )
 
 type AppointmentServiceInterface interface {
-	FetchAllAppointment(ctx context.Context) ([]entity.AppointmentEntity, error)
+	FetchAllAppointment(ctx context.Context, filter entity.AppointmentFilter) ([]entity.AppointmentEntity, error)
+	StreamAppointment(ctx context.Context, filter entity.AppointmentFilter, fn func(entity.AppointmentEntity) error) error
 	FetchByIDAppointment(ctx context.Context, id int64) (*entity.AppointmentEntity, error)
 	DeleteByIDAppointment(ctx context.Context, id int64) error
 	CreateAppointment(ctx context.Context, req entity.AppointmentEntity) error
 }
 
 type appointmentService struct {
-	appointmentRepo repository.AppointmentRepositoryInterface
-	sendEmail       messaging.EmailMessagingInterface
+	appointmentRepo    repository.AppointmentRepositoryInterface
+	serviceSectionRepo repository.ServiceSectionRepositoryInterface
+	mailRenderer       mailtemplate.RendererInterface
+	cfg                *config.Config
 }
 
 // CreateAppointment implements AppointmentServiceInterface.
 func (c *appointmentService) CreateAppointment(ctx context.Context, req entity.AppointmentEntity) error {
-	email, err := c.appointmentRepo.CreateAppointment(ctx, req)
+	serviceSection, err := c.serviceSectionRepo.FetchByIDServiceSection(ctx, req.ServiceID)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CreateAppointment", 1, err)
+		return err
+	}
+
+	received, err := c.mailRenderer.Render(mailtemplate.AppointmentReceivedData{
+		Name:        req.Name,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		ServiceName: serviceSection.Name,
+		Brief:       req.Brief,
+		Budget:      req.Budget,
+		MeetAt:      req.MeetAt,
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CreateAppointment", 2, err)
+		return err
+	}
+
+	confirmation, err := c.mailRenderer.Render(mailtemplate.AppointmentConfirmationData{
+		Name:        req.Name,
+		PhoneNumber: req.PhoneNumber,
+		ServiceName: serviceSection.Name,
+		MeetAt:      req.MeetAt,
+	})
 	if err != nil {
-		log.Errorf("[SERVICE] CreateAppointment - 1: %v", err)
+		logger.Error(ctx, logger.Service, "CreateAppointment", 3, err)
 		return err
 	}
 
-	body := fmt.Sprintf("You have received a new appointment request from %s", email)
-	err = c.sendEmail.SendEmailAppointment(nil, email, "New Appointment", body)
+	outbox := []entity.EmailOutboxEntity{
+		c.outboxMessage(c.cfg.Email.Reciever, received),
+		c.outboxMessage(req.Email, confirmation),
+	}
+
+	err = c.appointmentRepo.CreateAppointment(ctx, req, outbox)
 	if err != nil {
-		log.Errorf("[SERVICE] CreateAppointment - 2: %v", err)
+		logger.Error(ctx, logger.Service, "CreateAppointment", 4, err)
 		return err
 	}
+	metrics.AppointmentCreated()
 	return nil
 }
 
+func (c *appointmentService) outboxMessage(to string, msg *mailtemplate.Message) entity.EmailOutboxEntity {
+	from := c.cfg.Email.Sender
+	if from == "" {
+		from = c.cfg.Email.Username
+	}
+
+	return entity.EmailOutboxEntity{
+		FromAddress: from,
+		ToAddress:   to,
+		Subject:     msg.Subject,
+		Body:        msg.HTML,
+		TextBody:    msg.Text,
+		MaxAttempts: c.cfg.Email.OutboxMaxAttempts,
+	}
+}
+
 // DeleteByIDAppointment implements AppointmentServiceInterface.
 func (c *appointmentService) DeleteByIDAppointment(ctx context.Context, id int64) error {
 	return c.appointmentRepo.DeleteByIDAppointment(ctx, id)
 }
 
 // FetchAllAppointment implements AppointmentServiceInterface.
-func (c *appointmentService) FetchAllAppointment(ctx context.Context) ([]entity.AppointmentEntity, error) {
-	return c.appointmentRepo.FetchAllAppointment(ctx)
+func (c *appointmentService) FetchAllAppointment(ctx context.Context, filter entity.AppointmentFilter) ([]entity.AppointmentEntity, error) {
+	return c.appointmentRepo.FetchAllAppointment(ctx, filter)
+}
+
+// StreamAppointment implements AppointmentServiceInterface.
+func (c *appointmentService) StreamAppointment(ctx context.Context, filter entity.AppointmentFilter, fn func(entity.AppointmentEntity) error) error {
+	return c.appointmentRepo.StreamAppointment(ctx, filter, fn)
 }
 
 // FetchByIDAppointment implements AppointmentServiceInterface.
@@ -54,9 +108,11 @@ func (c *appointmentService) FetchByIDAppointment(ctx context.Context, id int64)
 	return c.appointmentRepo.FetchByIDAppointment(ctx, id)
 }
 
-func NewAppointmentService(appointmentRepo repository.AppointmentRepositoryInterface, sendEmail messaging.EmailMessagingInterface) AppointmentServiceInterface {
+func NewAppointmentService(appointmentRepo repository.AppointmentRepositoryInterface, serviceSectionRepo repository.ServiceSectionRepositoryInterface, mailRenderer mailtemplate.RendererInterface, cfg *config.Config) AppointmentServiceInterface {
 	return &appointmentService{
-		appointmentRepo: appointmentRepo,
-		sendEmail:       sendEmail,
+		appointmentRepo:    appointmentRepo,
+		serviceSectionRepo: serviceSectionRepo,
+		mailRenderer:       mailRenderer,
+		cfg:                cfg,
 	}
 }
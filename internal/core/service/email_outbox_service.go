@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/internal/adapater/messaging"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/tracing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	emailOutboxBaseBackoff = 30 * time.Second
+	emailOutboxMaxBackoff  = time.Hour
+	emailOutboxLease       = 5 * time.Minute
+)
+
+type EmailOutboxServiceInterface interface {
+	FetchAllEmailOutbox(ctx context.Context, status string) ([]entity.EmailOutboxEntity, error)
+	FetchByIDEmailOutbox(ctx context.Context, id int64) (*entity.EmailOutboxEntity, error)
+	RetryByIDEmailOutbox(ctx context.Context, id int64) error
+	ProcessEmailOutbox(ctx context.Context) error
+	RunWorker(ctx context.Context)
+}
+
+type emailOutboxService struct {
+	emailOutboxRepo repository.EmailOutboxInterface
+	sendEmail       messaging.EmailMessagingInterface
+	interval        time.Duration
+	batchSize       int
+}
+
+// FetchAllEmailOutbox implements EmailOutboxServiceInterface.
+func (e *emailOutboxService) FetchAllEmailOutbox(ctx context.Context, status string) ([]entity.EmailOutboxEntity, error) {
+	return e.emailOutboxRepo.FetchAllEmailOutbox(ctx, status)
+}
+
+// FetchByIDEmailOutbox implements EmailOutboxServiceInterface.
+func (e *emailOutboxService) FetchByIDEmailOutbox(ctx context.Context, id int64) (*entity.EmailOutboxEntity, error) {
+	return e.emailOutboxRepo.FetchByIDEmailOutbox(ctx, id)
+}
+
+// RetryByIDEmailOutbox implements EmailOutboxServiceInterface.
+func (e *emailOutboxService) RetryByIDEmailOutbox(ctx context.Context, id int64) error {
+	return e.emailOutboxRepo.RetryByIDEmailOutbox(ctx, id)
+}
+
+// ProcessEmailOutbox implements EmailOutboxServiceInterface.
+func (e *emailOutboxService) ProcessEmailOutbox(ctx context.Context) error {
+	messages, err := e.emailOutboxRepo.ClaimDueEmailOutbox(ctx, e.batchSize, emailOutboxLease)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "ProcessEmailOutbox", 1, err)
+		return err
+	}
+
+	for _, msg := range messages {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		e.deliverEmailOutbox(ctx, msg)
+	}
+
+	return nil
+}
+
+// deliverEmailOutbox sends one claimed email and records the outcome. Its
+// span is linked to the request that queued the email.
+func (e *emailOutboxService) deliverEmailOutbox(ctx context.Context, msg entity.EmailOutboxEntity) {
+	ctx, span := tracing.StartLinked(ctx, "email.outbox.deliver", trace.SpanKindConsumer, msg.Traceparent, attribute.Int64("email.outbox.id", msg.ID))
+
+	sendErr := e.sendEmail.SendEmail(ctx, msg.FromAddress, msg.ToAddress, msg.Subject, msg.TextBody, msg.Body)
+	tracing.End(span, sendErr)
+	if sendErr == nil {
+		if err := e.emailOutboxRepo.MarkSentEmailOutbox(ctx, msg.ID); err != nil {
+			logger.Error(ctx, logger.Service, "ProcessEmailOutbox", 2, err)
+		}
+		return
+	}
+
+	attempts := msg.Attempts + 1
+	dead := attempts >= msg.MaxAttempts
+	logger.Errorf(ctx, logger.Service, "ProcessEmailOutbox", 3, "email %d attempt %d failed: %v", msg.ID, attempts, sendErr)
+
+	err := e.emailOutboxRepo.MarkFailedEmailOutbox(ctx, msg.ID, attempts, sendErr.Error(), time.Now().Add(emailOutboxBackoff(attempts)), dead)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "ProcessEmailOutbox", 4, err)
+	}
+}
+
+// RunWorker implements EmailOutboxServiceInterface.
+// It blocks until ctx is cancelled.
+func (e *emailOutboxService) RunWorker(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = e.ProcessEmailOutbox(ctx)
+		}
+	}
+}
+
+// emailOutboxBackoff doubles the delay on every attempt: 30s, 1m, 2m, ... capped at 1h.
+func emailOutboxBackoff(attempts int) time.Duration {
+	backoff := emailOutboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= emailOutboxMaxBackoff {
+			return emailOutboxMaxBackoff
+		}
+	}
+	return backoff
+}
+
+func NewEmailOutboxService(emailOutboxRepo repository.EmailOutboxInterface, sendEmail messaging.EmailMessagingInterface, cfg *config.Config) EmailOutboxServiceInterface {
+	interval := time.Duration(cfg.Email.OutboxInterval) * time.Second
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	batchSize := cfg.Email.OutboxBatchSize
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+
+	return &emailOutboxService{
+		emailOutboxRepo: emailOutboxRepo,
+		sendEmail:       sendEmail,
+		interval:        interval,
+		batchSize:       batchSize,
+	}
+}
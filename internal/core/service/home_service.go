@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/internal/core/domain/entity"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type HomeServiceInterface interface {
+	FetchHome(ctx context.Context) (*entity.HomeEntity, error)
+	// InvalidateHome drops the cached home page, the next fetch assembles it
+	// again.
+	InvalidateHome()
+}
+
+// HomeSections are the services the home page is assembled from.
+type HomeSections struct {
+	HeroSection           HeroSectionServiceInterface
+	ClientSection         ClientSectionServiceInterface
+	AboutCompany          AboutCompanyServiceInterface
+	ServiceSection        ServiceSectionServiceInterface
+	PortofolioSection     PortofolioSectionServiceInterface
+	PortofolioTestimonial PortofolioTestimonialServiceInterface
+	FaqSection            FaqSectionServiceInterface
+	OurTeam               OurTeamServiceInterface
+	Statistic             StatisticServiceInterface
+	ContactUs             ContactUsServiceInterface
+}
+
+type homeService struct {
+	sections HomeSections
+	ttl      time.Duration
+
+	mu       sync.Mutex
+	cached   *entity.HomeEntity
+	cachedAt time.Time
+	// generation counts the invalidations, a page assembled across one is
+	// already stale and isn't cached.
+	generation uint64
+}
+
+// FetchHome implements HomeServiceInterface.
+// The cached page is returned while it is younger than the ttl, otherwise
+// every section is fetched at the same time.
+func (h *homeService) FetchHome(ctx context.Context) (*entity.HomeEntity, error) {
+	h.mu.Lock()
+	if h.cached != nil && time.Since(h.cachedAt) < h.ttl {
+		home := h.cached
+		h.mu.Unlock()
+		return home, nil
+	}
+	generation := h.generation
+	h.mu.Unlock()
+
+	home, err := h.assemble(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ttl > 0 && generation == h.generation {
+		h.cached = home
+		h.cachedAt = time.Now()
+	}
+	return home, nil
+}
+
+// InvalidateHome implements HomeServiceInterface.
+func (h *homeService) InvalidateHome() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.generation++
+	h.cached = nil
+}
+
+// assemble fetches the sections concurrently, the first error cancels the
+// others.
+func (h *homeService) assemble(ctx context.Context) (*entity.HomeEntity, error) {
+	var (
+		home     = &entity.HomeEntity{AssembledAt: time.Now()}
+		contacts []entity.ContactUsEntity
+	)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		home.HeroSections, err = h.sections.HeroSection.FetchAllHeroSection(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.ClientSections, err = h.sections.ClientSection.FetchAllClientSection(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.AboutCompany, err = h.sections.AboutCompany.FetchAllCompanyAndKeynote(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.ServiceSections, err = h.sections.ServiceSection.FetchAllServiceSection(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.PortofolioSections, err = h.sections.PortofolioSection.FetchAllPortofolioSection(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.PortofolioTestimonials, err = h.sections.PortofolioTestimonial.FetchAllPortofolioTestimonial(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.FaqSections, err = h.sections.FaqSection.FetchAllFaqSection(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.OurTeams, err = h.sections.OurTeam.FetchAllOurTeam(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		home.Statistics, err = h.sections.Statistic.FetchAllStatistic(ctx)
+		return err
+	})
+	g.Go(func() (err error) {
+		contacts, err = h.sections.ContactUs.FetchAllContactUs(ctx)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if home.AboutCompany != nil && home.AboutCompany.ID == 0 {
+		home.AboutCompany = nil
+	}
+	if len(contacts) > 0 {
+		home.ContactUs = &contacts[0]
+	}
+	return home, nil
+}
+
+// NewHomeService caches the home page for API_HOME_CACHE_TTL seconds, 0
+// assembles it on every fetch.
+func NewHomeService(sections HomeSections, cfg *config.Config) HomeServiceInterface {
+	return &homeService{
+		sections: sections,
+		ttl:      time.Duration(cfg.API.HomeCacheTTL) * time.Second,
+	}
+}
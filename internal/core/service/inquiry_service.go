@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/internal/adapater/messaging/mailtemplate"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/utils/logger"
+)
+
+type InquiryServiceInterface interface {
+	CreateInquiry(ctx context.Context, req entity.InquiryEntity) error
+	FetchAllInquiry(ctx context.Context, filter entity.InquiryFilter) ([]entity.InquiryEntity, error)
+	FetchByIDInquiry(ctx context.Context, id int64) (*entity.InquiryEntity, error)
+	MarkReadByIDInquiry(ctx context.Context, id int64, read bool) error
+	ArchiveByIDInquiry(ctx context.Context, id int64, archived bool) error
+	ReplyByIDInquiry(ctx context.Context, req entity.InquiryReplyEntity) error
+	DeleteByIDInquiry(ctx context.Context, id int64) error
+	CountUnreadInquiry(ctx context.Context) (int64, error)
+}
+
+type inquiryService struct {
+	inquiryRepo  repository.InquiryInterface
+	mailRenderer mailtemplate.RendererInterface
+	cfg          *config.Config
+}
+
+// CreateInquiry implements InquiryServiceInterface.
+func (i *inquiryService) CreateInquiry(ctx context.Context, req entity.InquiryEntity) error {
+	return i.inquiryRepo.CreateInquiry(ctx, req)
+}
+
+// FetchAllInquiry implements InquiryServiceInterface.
+func (i *inquiryService) FetchAllInquiry(ctx context.Context, filter entity.InquiryFilter) ([]entity.InquiryEntity, error) {
+	return i.inquiryRepo.FetchAllInquiry(ctx, filter)
+}
+
+// FetchByIDInquiry implements InquiryServiceInterface.
+// Opening an inquiry marks it as read, it is read again so the returned
+// inquiry has the stored read_at.
+func (i *inquiryService) FetchByIDInquiry(ctx context.Context, id int64) (*entity.InquiryEntity, error) {
+	result, err := i.inquiryRepo.FetchByIDInquiry(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "FetchByIDInquiry", 1, err)
+		return nil, err
+	}
+	if result.ReadAt != nil {
+		return result, nil
+	}
+
+	if err = i.inquiryRepo.MarkReadByIDInquiry(ctx, id, true); err != nil {
+		logger.Error(ctx, logger.Service, "FetchByIDInquiry", 2, err)
+		return nil, err
+	}
+
+	result, err = i.inquiryRepo.FetchByIDInquiry(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "FetchByIDInquiry", 3, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// MarkReadByIDInquiry implements InquiryServiceInterface.
+func (i *inquiryService) MarkReadByIDInquiry(ctx context.Context, id int64, read bool) error {
+	return i.inquiryRepo.MarkReadByIDInquiry(ctx, id, read)
+}
+
+// ArchiveByIDInquiry implements InquiryServiceInterface.
+func (i *inquiryService) ArchiveByIDInquiry(ctx context.Context, id int64, archived bool) error {
+	return i.inquiryRepo.ArchiveByIDInquiry(ctx, id, archived)
+}
+
+// ReplyByIDInquiry implements InquiryServiceInterface.
+func (i *inquiryService) ReplyByIDInquiry(ctx context.Context, req entity.InquiryReplyEntity) error {
+	result, err := i.inquiryRepo.FetchByIDInquiry(ctx, req.InquiryID)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "ReplyByIDInquiry", 1, err)
+		return err
+	}
+
+	msg, err := i.mailRenderer.Render(mailtemplate.ContactReplyData{
+		Name:            result.Name,
+		Topic:           result.Subject,
+		OriginalMessage: result.Message,
+		Reply:           req.Message,
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Service, "ReplyByIDInquiry", 2, err)
+		return err
+	}
+
+	from := i.cfg.Email.Sender
+	if from == "" {
+		from = i.cfg.Email.Username
+	}
+
+	outbox := entity.EmailOutboxEntity{
+		FromAddress: from,
+		ToAddress:   result.Email,
+		Subject:     msg.Subject,
+		Body:        msg.HTML,
+		TextBody:    msg.Text,
+		MaxAttempts: i.cfg.Email.OutboxMaxAttempts,
+	}
+
+	if err = i.inquiryRepo.ReplyByIDInquiry(ctx, req, outbox); err != nil {
+		logger.Error(ctx, logger.Service, "ReplyByIDInquiry", 3, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteByIDInquiry implements InquiryServiceInterface.
+func (i *inquiryService) DeleteByIDInquiry(ctx context.Context, id int64) error {
+	return i.inquiryRepo.DeleteByIDInquiry(ctx, id)
+}
+
+// CountUnreadInquiry implements InquiryServiceInterface.
+func (i *inquiryService) CountUnreadInquiry(ctx context.Context) (int64, error) {
+	return i.inquiryRepo.CountUnreadInquiry(ctx)
+}
+
+func NewInquiryService(inquiryRepo repository.InquiryInterface, mailRenderer mailtemplate.RendererInterface, cfg *config.Config) InquiryServiceInterface {
+	return &inquiryService{
+		inquiryRepo:  inquiryRepo,
+		mailRenderer: mailRenderer,
+		cfg:          cfg,
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/utils/logger"
+	"time"
+)
+
+type LastModifiedServiceInterface interface {
+	FetchLastModified(ctx context.Context, group string) (time.Time, bool, error)
+}
+
+// homeTables are the tables the home page is assembled from.
+var homeTables = []string{
+	"hero_sections",
+	"client_sections",
+	"about_companies",
+	"about_company_keynotes",
+	"service_sections",
+	"portofolio_sections",
+	"portofolio_testimonials",
+	"faq_sections",
+	"our_teams",
+	"statistics",
+	"contact_us",
+}
+
+// lastModifiedTables lists the tables the public reads of each route group
+// are read from, joins included.
+var lastModifiedTables = map[string][]string{
+	"home":                    homeTables,
+	"hero-sections":           {"hero_sections"},
+	"client-sections":         {"client_sections"},
+	"about-companies":         {"about_companies", "about_company_keynotes"},
+	"about-company-keynotes":  {"about_company_keynotes", "about_companies"},
+	"service-sections":        {"service_sections"},
+	"service-details":         {"service_details", "service_sections"},
+	"portofolio-sections":     {"portofolio_sections"},
+	"portofolio-details":      {"portofolio_details", "portofolio_sections"},
+	"portofolio-testimonials": {"portofolio_testimonials", "portofolio_sections"},
+	"faq-sections":            {"faq_sections"},
+	"our-teams":               {"our_teams"},
+	"statistics":              {"statistics"},
+	"contact-us":              {"contact_us"},
+	"posts":                   {"posts"},
+	"profile":                 {"profiles"},
+}
+
+type lastModifiedService struct {
+	lastModifiedRepo repository.LastModifiedInterface
+}
+
+// FetchLastModified implements LastModifiedServiceInterface.
+// It returns false for the groups that aren't read from tables, and for the
+// ones with no rows yet.
+func (l *lastModifiedService) FetchLastModified(ctx context.Context, group string) (time.Time, bool, error) {
+	tables, ok := lastModifiedTables[group]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	modified, err := l.lastModifiedRepo.FetchLastModified(ctx, tables)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "FetchLastModified", 1, err)
+		return time.Time{}, false, err
+	}
+	return modified, !modified.IsZero(), nil
+}
+
+func NewLastModifiedService(lastModifiedRepo repository.LastModifiedInterface) LastModifiedServiceInterface {
+	return &lastModifiedService{
+		lastModifiedRepo: lastModifiedRepo,
+	}
+}
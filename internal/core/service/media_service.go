@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"desadangdang/config"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/internal/adapater/storage"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/imageproc"
+	"desadangdang/utils/logger"
+	"desadangdang/utils/metrics"
+	"desadangdang/utils/upload"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MediaServiceInterface interface {
+	CreateMedia(ctx context.Context, req entity.MediaEntity) (int64, error)
+	FetchAllMedia(ctx context.Context, filter entity.MediaFilter) ([]entity.MediaEntity, error)
+	FetchByIDMedia(ctx context.Context, id int64) (*entity.MediaEntity, error)
+	EditAltTextByIDMedia(ctx context.Context, id int64, altText string) error
+	DeleteByIDMedia(ctx context.Context, id int64) error
+	CleanupOrphanMedia(ctx context.Context, olderThan time.Duration, dryRun bool) ([]entity.MediaEntity, error)
+	CreateMediaUpload(ctx context.Context, req entity.MediaUploadEntity) (*entity.MediaUploadEntity, error)
+	CompleteMediaUpload(ctx context.Context, token string, userID int64, altText string) (*entity.MediaEntity, error)
+}
+
+const (
+	// signedUploadExpiry bounds when the upload must start.
+	signedUploadExpiry = 15 * time.Minute
+	// uploadTicketExpiry leaves time for a large upload to finish before completion.
+	uploadTicketExpiry = 2 * time.Hour
+)
+
+type mediaService struct {
+	mediaRepo      repository.MediaInterface
+	storageService storage.StorageInterface
+	policy         upload.Policy
+	secret         string
+}
+
+// CreateMedia implements MediaServiceInterface.
+func (m *mediaService) CreateMedia(ctx context.Context, req entity.MediaEntity) (int64, error) {
+	return m.mediaRepo.CreateMedia(ctx, req)
+}
+
+// FetchAllMedia implements MediaServiceInterface.
+func (m *mediaService) FetchAllMedia(ctx context.Context, filter entity.MediaFilter) ([]entity.MediaEntity, error) {
+	return m.mediaRepo.FetchAllMedia(ctx, filter)
+}
+
+// FetchByIDMedia implements MediaServiceInterface.
+func (m *mediaService) FetchByIDMedia(ctx context.Context, id int64) (*entity.MediaEntity, error) {
+	result, err := m.mediaRepo.FetchByIDMedia(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "FetchByIDMedia", 1, err)
+		return nil, err
+	}
+
+	result.Usages, err = m.mediaRepo.FetchUsageMedia(ctx, result.FileKey)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "FetchByIDMedia", 2, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// EditAltTextByIDMedia implements MediaServiceInterface.
+func (m *mediaService) EditAltTextByIDMedia(ctx context.Context, id int64, altText string) error {
+	return m.mediaRepo.EditAltTextByIDMedia(ctx, id, altText)
+}
+
+// DeleteByIDMedia implements MediaServiceInterface.
+// A file that is still referenced by any record is kept.
+func (m *mediaService) DeleteByIDMedia(ctx context.Context, id int64) error {
+	result, err := m.FetchByIDMedia(ctx, id)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "DeleteByIDMedia", 1, err)
+		return err
+	}
+
+	if len(result.Usages) > 0 {
+		logger.Errorf(ctx, logger.Service, "DeleteByIDMedia", 2, "media %d used by %d records", id, len(result.Usages))
+		return conv.ErrMediaInUse
+	}
+
+	return m.deleteMedia(ctx, *result)
+}
+
+// CleanupOrphanMedia implements MediaServiceInterface.
+func (m *mediaService) CleanupOrphanMedia(ctx context.Context, olderThan time.Duration, dryRun bool) ([]entity.MediaEntity, error) {
+	results, err := m.mediaRepo.FetchOrphanMedia(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CleanupOrphanMedia", 1, err)
+		return nil, err
+	}
+	if dryRun {
+		return results, nil
+	}
+
+	for i, val := range results {
+		if err = m.deleteMedia(ctx, val); err != nil {
+			logger.Error(ctx, logger.Service, "CleanupOrphanMedia", 2, err)
+			return results[:i], err
+		}
+	}
+	return results, nil
+}
+
+// CreateMediaUpload implements MediaServiceInterface.
+// Images are not accepted because their variants are generated on the server.
+func (m *mediaService) CreateMediaUpload(ctx context.Context, req entity.MediaUploadEntity) (*entity.MediaUploadEntity, error) {
+	if req.Purpose == upload.PurposeImage {
+		return nil, upload.ErrImageNotDirect
+	}
+	if err := m.policy.Check(req.Purpose, req.ContentType, req.Size); err != nil {
+		logger.Error(ctx, logger.Service, "CreateMediaUpload", 1, err)
+		return nil, err
+	}
+
+	fileKey := fmt.Sprintf("%s_%d", uuid.New().String(), time.Now().Unix())
+	req.Path = fmt.Sprintf("public/uploads/%s%s", fileKey, upload.Extension(req.ContentType))
+
+	signed, err := m.storageService.SignedUploadURL(ctx, req.Path, storage.PutOptions{
+		ContentType: req.ContentType,
+		Size:        req.Size,
+	}, signedUploadExpiry)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CreateMediaUpload", 2, err)
+		return nil, err
+	}
+
+	req.Token, err = upload.SignTicket(m.secret, upload.Ticket{
+		FileKey:      fileKey,
+		Path:         req.Path,
+		Purpose:      req.Purpose,
+		ContentType:  req.ContentType,
+		Size:         req.Size,
+		OriginalName: req.OriginalName,
+		ExpiresAt:    time.Now().Add(uploadTicketExpiry).Unix(),
+	})
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CreateMediaUpload", 3, err)
+		return nil, err
+	}
+
+	req.URL = signed.URL
+	req.Method = signed.Method
+	req.Headers = signed.Headers
+	req.ExpiresAt = signed.ExpiresAt
+	return &req, nil
+}
+
+// CompleteMediaUpload implements MediaServiceInterface.
+// The client never touched the api with the file, so the stored object is
+// sniffed again and removed when it does not match the ticket.
+func (m *mediaService) CompleteMediaUpload(ctx context.Context, token string, userID int64, altText string) (*entity.MediaEntity, error) {
+	ticket, err := upload.VerifyTicket(m.secret, token)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CompleteMediaUpload", 1, err)
+		return nil, err
+	}
+
+	body, info, err := m.storageService.Get(ctx, ticket.Path)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CompleteMediaUpload", 2, err)
+		return nil, err
+	}
+	defer body.Close()
+
+	size := info.Size
+	if size < 0 {
+		size = ticket.Size
+	}
+	validFile, err := m.policy.Validate(ticket.Purpose, size, body)
+	if err == nil && (size > ticket.Size || validFile.ContentType != ticket.ContentType) {
+		err = upload.ErrUnsupportedType
+		if size > ticket.Size {
+			err = upload.ErrFileTooLarge
+		}
+	}
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CompleteMediaUpload", 3, err)
+		if errDelete := m.storageService.Delete(ctx, ticket.Path); errDelete != nil {
+			logger.Error(ctx, logger.Service, "CompleteMediaUpload", 4, errDelete)
+		}
+		return nil, err
+	}
+
+	result := entity.MediaEntity{
+		UserID:       userID,
+		FileKey:      ticket.FileKey,
+		Path:         ticket.Path,
+		URL:          m.storageService.PublicURL(ticket.Path),
+		Purpose:      ticket.Purpose,
+		ContentType:  validFile.ContentType,
+		Size:         size,
+		AltText:      altText,
+		OriginalName: ticket.OriginalName,
+	}
+	result.ID, err = m.mediaRepo.CreateMedia(ctx, result)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "CompleteMediaUpload", 5, err)
+		return nil, err
+	}
+	metrics.UploadStored(result.Purpose, result.Size)
+	return &result, nil
+}
+
+// deleteMedia removes the stored objects first, so a failure leaves the row
+// in place and the cleanup can be retried.
+func (m *mediaService) deleteMedia(ctx context.Context, val entity.MediaEntity) error {
+	paths := []string{val.Path}
+	if val.Purpose == upload.PurposeImage {
+		paths = append(paths, imageproc.VariantPathsOf(val.Path)...)
+	}
+
+	for _, path := range paths {
+		err := m.storageService.Delete(ctx, path)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+			logger.Error(ctx, logger.Service, "deleteMedia", 1, err)
+			return err
+		}
+	}
+
+	return m.mediaRepo.DeleteByIDMedia(ctx, val.ID)
+}
+
+func NewMediaService(mediaRepo repository.MediaInterface, storageService storage.StorageInterface, cfg *config.Config) MediaServiceInterface {
+	return &mediaService{
+		mediaRepo:      mediaRepo,
+		storageService: storageService,
+		policy:         upload.PolicyFromConfig(cfg),
+		secret:         cfg.UploadSigningKey(),
+	}
+}
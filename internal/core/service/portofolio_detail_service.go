@@ -4,8 +4,7 @@ import (
 	"context"
 	"desadangdang/internal/adapater/repository"
 	"desadangdang/internal/core/domain/entity"
-
-	"github.com/labstack/gommon/log"
+	"desadangdang/utils/logger"
 )
 
 type PortofolioDetailServiceInterface interface {
@@ -32,7 +31,7 @@ func (c *portofolioDetailService) FetchDetailPotofolioByPortoID(ctx context.Cont
 func (c *portofolioDetailService) CreatePortofolioDetail(ctx context.Context, req entity.PortofolioDetailEntity) error {
 	_, err = c.portofolioSectionRepo.FetchByIDPortofolioSection(ctx, req.PortofolioSection.ID)
 	if err != nil {
-		log.Errorf("[SERVICE] CreatePortofolioDetail - 1: %v", err)
+		logger.Error(ctx, logger.Service, "CreatePortofolioDetail", 1, err)
 		return err
 	}
 	return c.portofolioDetailRepo.CreatePortofolioDetail(ctx, req)
@@ -47,7 +46,7 @@ func (c *portofolioDetailService) DeleteByIDPortofolioDetail(ctx context.Context
 func (c *portofolioDetailService) EditByIDPortofolioDetail(ctx context.Context, req entity.PortofolioDetailEntity) error {
 	_, err = c.portofolioSectionRepo.FetchByIDPortofolioSection(ctx, req.PortofolioSection.ID)
 	if err != nil {
-		log.Errorf("[SERVICE] EditByIDPortofolioDetail - 1: %v", err)
+		logger.Error(ctx, logger.Service, "EditByIDPortofolioDetail", 1, err)
 		return err
 	}
 	return c.portofolioDetailRepo.EditByIDPortofolioDetail(ctx, req)
@@ -4,8 +4,7 @@ import (
 	"context"
 	"desadangdang/internal/adapater/repository"
 	"desadangdang/internal/core/domain/entity"
-
-	"github.com/labstack/gommon/log"
+	"desadangdang/utils/logger"
 )
 
 type PortofolioTestimonialServiceInterface interface {
@@ -25,7 +24,7 @@ type portofolioTestimonialService struct {
 func (c *portofolioTestimonialService) CreatePortofolioTestimonial(ctx context.Context, req entity.PortofolioTestimonialEntity) error {
 	_, err = c.portofolioSectionRepo.FetchByIDPortofolioSection(ctx, req.PortofolioSection.ID)
 	if err != nil {
-		log.Errorf("[SERVICE] CreatePortofolioTestimonial - 1: %v", err)
+		logger.Error(ctx, logger.Service, "CreatePortofolioTestimonial", 1, err)
 		return err
 	}
 	return c.portofolioTestimonialRepo.CreatePortofolioTestimonial(ctx, req)
@@ -40,7 +39,7 @@ func (c *portofolioTestimonialService) DeleteByIDPortofolioTestimonial(ctx conte
 func (c *portofolioTestimonialService) EditByIDPortofolioTestimonial(ctx context.Context, req entity.PortofolioTestimonialEntity) error {
 	_, err = c.portofolioSectionRepo.FetchByIDPortofolioSection(ctx, req.PortofolioSection.ID)
 	if err != nil {
-		log.Errorf("[SERVICE] EditByIDPortofolioTestimonial - 1: %v", err)
+		logger.Error(ctx, logger.Service, "EditByIDPortofolioTestimonial", 1, err)
 		return err
 	}
 	return c.portofolioTestimonialRepo.EditByIDPortofolioTestimonial(ctx, req)
@@ -9,6 +9,7 @@ import (
 type StatisticServiceInterface interface {
 	CreateStatistic(ctx context.Context, req entity.StatisticEntity) error
 	FetchAllStatistic(ctx context.Context) ([]entity.StatisticEntity, error)
+	StreamStatistic(ctx context.Context, fn func(entity.StatisticEntity) error) error
 	FetchByIDStatistic(ctx context.Context, id int64) (*entity.StatisticEntity, error)
 	EditByIDStatistic(ctx context.Context, req entity.StatisticEntity) error
 	DeleteByIDStatistic(ctx context.Context, id int64) error
@@ -43,6 +44,11 @@ func (s *statisticService) FetchByIDStatistic(ctx context.Context, id int64) (*e
 	return s.statisticRepo.FetchByIDStatistic(ctx, id)
 }
 
+// StreamStatistic implements StatisticServiceInterface.
+func (s *statisticService) StreamStatistic(ctx context.Context, fn func(entity.StatisticEntity) error) error {
+	return s.statisticRepo.StreamStatistic(ctx, fn)
+}
+
 func NewStatisticService(statisticRepo repository.StatisticInterface) StatisticServiceInterface {
 	return &statisticService{
 		statisticRepo: statisticRepo,
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"desadangdang/internal/adapater/repository"
+	"desadangdang/internal/core/domain/entity"
+	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type TrashServiceInterface interface {
+	FetchAllTrash(ctx context.Context, resource string) ([]entity.TrashEntity, error)
+	RestoreTrash(ctx context.Context, resource string, id int64) error
+	PurgeTrash(ctx context.Context, resource string, id int64) error
+	EmptyTrash(ctx context.Context, resource string) (int64, error)
+}
+
+type trashService struct {
+	trashRepo repository.TrashInterface
+}
+
+// FetchAllTrash implements TrashServiceInterface.
+func (t *trashService) FetchAllTrash(ctx context.Context, resource string) ([]entity.TrashEntity, error) {
+	results, err := t.trashRepo.FetchAllTrash(ctx, resource)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "FetchAllTrash", 1, err)
+		return nil, trashError(err)
+	}
+	return results, nil
+}
+
+// RestoreTrash implements TrashServiceInterface.
+func (t *trashService) RestoreTrash(ctx context.Context, resource string, id int64) error {
+	if err := t.trashRepo.RestoreTrash(ctx, resource, id); err != nil {
+		logger.Error(ctx, logger.Service, "RestoreTrash", 1, err)
+		return trashError(err)
+	}
+	return nil
+}
+
+// PurgeTrash implements TrashServiceInterface.
+func (t *trashService) PurgeTrash(ctx context.Context, resource string, id int64) error {
+	if err := t.trashRepo.PurgeTrash(ctx, resource, id); err != nil {
+		logger.Error(ctx, logger.Service, "PurgeTrash", 1, err)
+		return trashError(err)
+	}
+	return nil
+}
+
+// EmptyTrash implements TrashServiceInterface.
+func (t *trashService) EmptyTrash(ctx context.Context, resource string) (int64, error) {
+	count, err := t.trashRepo.EmptyTrash(ctx, resource)
+	if err != nil {
+		logger.Error(ctx, logger.Service, "EmptyTrash", 1, err)
+		return 0, trashError(err)
+	}
+	return count, nil
+}
+
+func trashError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrUnknownTrashResource), errors.Is(err, gorm.ErrRecordNotFound):
+		return conv.ErrNotFound
+	case errors.Is(err, repository.ErrTrashParentDeleted):
+		return conv.ErrTrashParentDeleted
+	default:
+		return err
+	}
+}
+
+func NewTrashService(trashRepo repository.TrashInterface) TrashServiceInterface {
+	return &trashService{
+		trashRepo: trashRepo,
+	}
+}
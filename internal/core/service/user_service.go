@@ -7,14 +7,12 @@ import (
 	"desadangdang/internal/core/domain/entity"
 	"desadangdang/utils/auth"
 	"desadangdang/utils/conv"
+	"desadangdang/utils/logger"
 	"errors"
-
-	"github.com/rs/zerolog/log"
 )
 
 var (
-	err  error
-	code string
+	err error
 )
 
 type UserServiceInterface interface {
@@ -31,16 +29,17 @@ type userService struct {
 func (u *userService) LoginAdmin(ctx context.Context, req entity.UserEntity) (string, error) {
 	user, err := u.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
-		code = "[SERVICE] LoginAdmin - 1"
-		log.Err(err).Msg(code)
+		logger.Error(ctx, logger.Service, "LoginAdmin", 1, err)
+		// An unknown email answers like a wrong password, so accounts can't be probed
+		if errors.Is(err, conv.ErrNotFound) {
+			return "", conv.ErrWrongEmailOrPassword
+		}
 		return "", err
 	}
 
 	if checkPass := conv.CheckPasswordHash(req.Password, user.Password); !checkPass {
-		code = "[SERVICE] LoginAdmin - 2"
-		err = errors.New("invalid password")
-		log.Err(err).Msg(code)
-		return "", err
+		logger.Error(ctx, logger.Service, "LoginAdmin", 2, errors.New("invalid password"))
+		return "", conv.ErrWrongEmailOrPassword
 	}
 
 	jwtData := &entity.JwtData{
@@ -48,8 +47,7 @@ func (u *userService) LoginAdmin(ctx context.Context, req entity.UserEntity) (st
 	}
 	token, _, err := u.jwtAuth.GenerateToken(jwtData)
 	if err != nil {
-		code = "[SERVICE] LoginAdmin - 3"
-		log.Err(err).Msg(code)
+		logger.Error(ctx, logger.Service, "LoginAdmin", 3, err)
 		return "", err
 	}
 
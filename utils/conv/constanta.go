@@ -1,6 +1,6 @@
 package conv
 
-import "errors"
+import "desadangdang/internal/core/domain/apperr"
 
 type contextKey string
 
@@ -13,9 +13,11 @@ const (
 )
 
 var (
-	ErrInternalServerError  = errors.New("internal server error")
-	ErrNotFound             = errors.New("data not found")
-	ErrUserAlreadyExist     = errors.New("user already exist")
-	ErrBadParamInput        = errors.New("given param is not valid")
-	ErrWrongEmailOrPassword = errors.New("wrong email/password")
+	ErrInternalServerError  = apperr.ErrInternal
+	ErrNotFound             = apperr.ErrNotFound
+	ErrUserAlreadyExist     = apperr.Conflict(apperr.CodeUserExists, "user already exist")
+	ErrBadParamInput        = apperr.Validation("given param is not valid")
+	ErrWrongEmailOrPassword = apperr.New(apperr.KindUnauthorized, apperr.CodeWrongCredentials, "wrong email/password")
+	ErrMediaInUse           = apperr.Conflict(apperr.CodeMediaInUse, "media is still in use")
+	ErrTrashParentDeleted   = apperr.Conflict(apperr.CodeTrashParentDeleted, "restore the parent record first")
 )
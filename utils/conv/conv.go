@@ -2,8 +2,6 @@ package conv
 
 import (
 	"desadangdang/internal/core/domain/entity"
-	"fmt"
-	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
@@ -20,26 +18,12 @@ func CheckPasswordHash(password, hash string) bool {
 	return err == nil
 }
 
-func SetHTTPStatusCode(err error) int {
-	if err == nil {
-		return http.StatusOK
-	}
-	switch err.Error() {
-	case ErrInternalServerError.Error():
-		return http.StatusInternalServerError
-	case ErrNotFound.Error():
-		return http.StatusNotFound
-	case ErrWrongEmailOrPassword.Error():
-		return http.StatusBadRequest
-	default:
-		return http.StatusInternalServerError
-	}
-}
-
+// GetUserIDByContext returns 0 when the route is not behind CheckToken.
 func GetUserIDByContext(ctx echo.Context) int64 {
-	u := ctx.Get("user")
-	fmt.Println("[CONV] GetUserIDByContext - User:", u)
-	claims := u.(*entity.JwtData)
+	claims, ok := ctx.Get("user").(*entity.JwtData)
+	if !ok || claims == nil {
+		return 0
+	}
 	return int64(claims.UserID)
 }
 
@@ -0,0 +1,265 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	dateTimeLayout = "2006-01-02 15:04"
+	sheetName      = "Sheet1"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+// Decimal is a fixed scale number, ex: DECIMAL(10,1) budget columns.
+type Decimal struct {
+	Value float64
+	Scale int
+}
+
+// Writer writes a table row by row so callers can feed it straight from a
+// database cursor. CSV rows reach the client as they are written, XLSX is a
+// zip archive that is only sent once Close completes it.
+type Writer interface {
+	WriteHeader(headers []string) error
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+func IsSupported(format string) bool {
+	return format == FormatCSV || format == FormatXLSX
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func FileName(name, format string) string {
+	return fmt.Sprintf("%s_%s.%s", name, time.Now().Format("20060102_150405"), format)
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type flusher interface {
+	Flush()
+}
+
+type csvWriter struct {
+	out  io.Writer
+	csv  *csv.Writer
+	rows int
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{out: w, csv: csv.NewWriter(w)}
+}
+
+// WriteHeader implements Writer.
+func (c *csvWriter) WriteHeader(headers []string) error {
+	// UTF-8 BOM so spreadsheet apps detect the encoding
+	if _, err := c.out.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return err
+	}
+	return c.csv.Write(headers)
+}
+
+// WriteRow implements Writer.
+func (c *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = formatText(v)
+	}
+
+	if err := c.csv.Write(record); err != nil {
+		return err
+	}
+
+	c.rows++
+	if c.rows%100 == 0 {
+		return c.flush()
+	}
+	return nil
+}
+
+// Close implements Writer.
+func (c *csvWriter) Close() error {
+	return c.flush()
+}
+
+func (c *csvWriter) flush() error {
+	c.csv.Flush()
+	if f, ok := c.out.(flusher); ok {
+		f.Flush()
+	}
+	return c.csv.Error()
+}
+
+// xlsxWriter can't stream the response, the workbook is a zip archive written
+// by excelize in Close. Memory stays bounded all the same: the stream writer
+// moves the rows to a temp file past 16 MB.
+type xlsxWriter struct {
+	out           io.Writer
+	file          *excelize.File
+	stream        *excelize.StreamWriter
+	row           int
+	headerStyle   int
+	dateStyle     int
+	decimalStyles map[int]int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	headerStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, err
+	}
+
+	dateFormat := "yyyy-mm-dd hh:mm"
+	dateStyle, err := file.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{
+		out:           w,
+		file:          file,
+		stream:        stream,
+		row:           1,
+		headerStyle:   headerStyle,
+		dateStyle:     dateStyle,
+		decimalStyles: map[int]int{},
+	}, nil
+}
+
+// WriteHeader implements Writer.
+func (x *xlsxWriter) WriteHeader(headers []string) error {
+	cells := make([]interface{}, len(headers))
+	for i, h := range headers {
+		cells[i] = excelize.Cell{StyleID: x.headerStyle, Value: h}
+	}
+
+	if err := x.stream.SetColWidth(1, len(headers), 20); err != nil {
+		return err
+	}
+	return x.writeCells(cells)
+}
+
+// WriteRow implements Writer.
+func (x *xlsxWriter) WriteRow(values []interface{}) error {
+	cells := make([]interface{}, len(values))
+	for i, v := range values {
+		switch val := v.(type) {
+		case time.Time:
+			if val.IsZero() {
+				cells[i] = ""
+				continue
+			}
+			cells[i] = excelize.Cell{StyleID: x.dateStyle, Value: val}
+		case Decimal:
+			style, err := x.decimalStyle(val.Scale)
+			if err != nil {
+				return err
+			}
+			cells[i] = excelize.Cell{StyleID: style, Value: val.Value}
+		default:
+			cells[i] = v
+		}
+	}
+	return x.writeCells(cells)
+}
+
+// Close implements Writer.
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := x.file.WriteTo(x.out)
+	return err
+}
+
+func (x *xlsxWriter) writeCells(cells []interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	x.row++
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxWriter) decimalStyle(scale int) (int, error) {
+	if style, ok := x.decimalStyles[scale]; ok {
+		return style, nil
+	}
+
+	numFmt := "#,##0"
+	if scale > 0 {
+		numFmt += "." + strings.Repeat("0", scale)
+	}
+
+	style, err := x.file.NewStyle(&excelize.Style{CustomNumFmt: &numFmt})
+	if err != nil {
+		return 0, err
+	}
+
+	x.decimalStyles[scale] = style
+	return style, nil
+}
+
+func formatText(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case time.Time:
+		if val.IsZero() {
+			return ""
+		}
+		return val.Format(dateTimeLayout)
+	case Decimal:
+		return strconv.FormatFloat(val.Value, 'f', val.Scale, 64)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case int:
+		return strconv.Itoa(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
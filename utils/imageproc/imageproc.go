@@ -0,0 +1,194 @@
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	FormatWebP = "webp"
+	FormatJPEG = "jpeg"
+
+	jpegQuality     = 82
+	originalQuality = 92
+
+	// MaxPixels bounds the canvas of the images that are decoded. The header
+	// of a small file can claim a huge one, and the decoder allocates it.
+	MaxPixels = 40_000_000
+)
+
+var ErrTooManyPixels = errors.New("image dimensions are too large")
+
+// Size is a named variant, the width is an upper bound and images are never upscaled.
+type Size struct {
+	Name  string
+	Width int
+}
+
+var Sizes = []Size{
+	{Name: "thumbnail", Width: 320},
+	{Name: "medium", Width: 768},
+	{Name: "large", Width: 1600},
+}
+
+// sizesFor returns the sizes generated for an image of the given width: the
+// ones it is scaled down to, then the first it fits in unscaled. Larger sizes
+// would repeat that variant.
+func sizesFor(width int) []Size {
+	for i, size := range Sizes {
+		if size.Width >= width {
+			return Sizes[:i+1]
+		}
+	}
+	return Sizes
+}
+
+type Variant struct {
+	Name        string
+	Format      string
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Process decodes an image, fixes its EXIF orientation and encodes the sizes
+// it needs as WebP and JPEG. Re-encoding drops all metadata, EXIF included.
+func Process(data []byte) ([]Variant, error) {
+	if err := checkPixels(data); err != nil {
+		return nil, err
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	img := applyOrientation(toRGBA(decoded), jpegOrientation(data))
+
+	var variants []Variant
+	for _, size := range sizesFor(img.Bounds().Dx()) {
+		resized := resize(img, size.Width)
+		bounds := resized.Bounds()
+
+		// WebP output is lossless, the encoder is pure Go and keeps the build free of cgo.
+		var webpBuf bytes.Buffer
+		if err := nativewebp.Encode(&webpBuf, resized, nil); err != nil {
+			return nil, err
+		}
+		variants = append(variants, Variant{
+			Name:        size.Name,
+			Format:      FormatWebP,
+			ContentType: "image/webp",
+			Extension:   ".webp",
+			Width:       bounds.Dx(),
+			Height:      bounds.Dy(),
+			Data:        webpBuf.Bytes(),
+		})
+
+		var jpegBuf bytes.Buffer
+		if err := jpeg.Encode(&jpegBuf, flatten(resized), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		variants = append(variants, Variant{
+			Name:        size.Name,
+			Format:      FormatJPEG,
+			ContentType: "image/jpeg",
+			Extension:   ".jpg",
+			Width:       bounds.Dx(),
+			Height:      bounds.Dy(),
+			Data:        jpegBuf.Bytes(),
+		})
+	}
+
+	return variants, nil
+}
+
+// Original returns the upload as it should be stored. JPEG metadata is removed,
+// and a rotated photo is re-encoded upright since dropping EXIF loses the rotation.
+func Original(data []byte, contentType string) ([]byte, error) {
+	if contentType != "image/jpeg" {
+		return data, nil
+	}
+
+	orientation := jpegOrientation(data)
+	if orientation <= 1 {
+		return stripJPEGMetadata(data), nil
+	}
+
+	if err := checkPixels(data); err != nil {
+		return nil, err
+	}
+	decoded, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, applyOrientation(toRGBA(decoded), orientation), &jpeg.Options{Quality: originalQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Dimensions returns the upright width and height of an image.
+func Dimensions(data []byte) (int, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, err
+	}
+	if jpegOrientation(data) >= 5 {
+		return config.Height, config.Width, nil
+	}
+	return config.Width, config.Height, nil
+}
+
+// checkPixels reads the image header alone and refuses canvases over MaxPixels.
+func checkPixels(data []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if int64(config.Width)*int64(config.Height) > MaxPixels {
+		return ErrTooManyPixels
+	}
+	return nil
+}
+
+func resize(img *image.RGBA, width int) *image.RGBA {
+	bounds := img.Bounds()
+	if bounds.Dx() <= width {
+		return img
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// flatten puts the image on a white background since JPEG has no alpha channel.
+func flatten(img *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(img.Bounds())
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Over)
+	return dst
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+	return dst
+}
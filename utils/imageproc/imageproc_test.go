@@ -0,0 +1,72 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func testPhoto(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			fx, fy := float64(x)/float64(width), float64(y)/float64(height)
+			v := 128 + 60*math.Sin(fx*13+fy*7) + 40*math.Cos(fx*fy*40)
+			img.SetRGBA(x, y, color.RGBA{uint8(v), uint8(v*0.8 + 40*fy), uint8(255 - v), 255})
+		}
+	}
+	return img
+}
+
+func TestProcessSizes(t *testing.T) {
+	var src bytes.Buffer
+	if err := jpeg.Encode(&src, testPhoto(400, 300), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	variants, err := Process(src.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	widths := map[string]int{}
+	for _, v := range variants {
+		widths[v.Name+v.Extension] = v.Width
+
+		if v.Format != FormatWebP {
+			continue
+		}
+		decoded, err := webp.Decode(bytes.NewReader(v.Data))
+		if err != nil {
+			t.Fatalf("%s.webp: decode: %v", v.Name, err)
+		}
+		if got := decoded.Bounds().Size(); got != image.Pt(v.Width, v.Height) {
+			t.Errorf("%s.webp decodes as %v, want %dx%d", v.Name, got, v.Width, v.Height)
+		}
+	}
+	want := map[string]int{"thumbnail.webp": 320, "thumbnail.jpg": 320, "medium.webp": 400, "medium.jpg": 400}
+	if len(widths) != len(want) {
+		t.Errorf("variants %v, want %v", widths, want)
+	}
+	for name, width := range want {
+		if widths[name] != width {
+			t.Errorf("%s is %dpx wide, want %d", name, widths[name], width)
+		}
+	}
+
+	if paths := VariantPathsOf(OriginalPath("public/uploads", "0f3a-12_1700000000", ".jpg", 400)); len(paths) != 4 {
+		t.Errorf("VariantPathsOf = %v, want the 4 generated variants", paths)
+	}
+
+	set, ok := SetFromURL("https://cdn.example.com" + OriginalPath("/public/uploads", "0f3a-12_1700000000", ".jpg", 400))
+	if !ok {
+		t.Fatal("SetFromURL rejected a processed original")
+	}
+	if got, want := set.SrcSet(FormatWebP), "https://cdn.example.com/public/uploads/0f3a-12_1700000000/thumbnail.webp 320w, https://cdn.example.com/public/uploads/0f3a-12_1700000000/medium.webp 400w"; got != want {
+		t.Errorf("SrcSet = %q, want %q", got, want)
+	}
+}
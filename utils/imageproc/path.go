@@ -0,0 +1,101 @@
+package imageproc
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Processed images live in their own folder:
+//
+//	public/uploads/<id>/original-1200w.jpg
+//	public/uploads/<id>/medium.webp
+//
+// so the variants of any stored url can be derived without a lookup. The
+// original carries its width, which tells the sizes that were generated.
+const originalName = "original"
+
+var originalPattern = regexp.MustCompile(`/public/uploads/[0-9a-f-]+_\d+/original-(\d+)w\.[a-z0-9]+$`)
+
+func OriginalPath(dir, id, ext string, width int) string {
+	return fmt.Sprintf("%s/%s/%s-%dw%s", dir, id, originalName, width, ext)
+}
+
+// originalWidth reads the width out of an original's path or url.
+func originalWidth(p string) (int, bool) {
+	match := originalPattern.FindStringSubmatch(p)
+	if match == nil {
+		return 0, false
+	}
+	width, err := strconv.Atoi(match[1])
+	return width, err == nil
+}
+
+func VariantPath(dir, id string, v Variant) string {
+	return fmt.Sprintf("%s/%s/%s%s", dir, id, v.Name, v.Extension)
+}
+
+// VariantPathsOf lists the variant paths stored next to an original.
+func VariantPathsOf(originalPath string) []string {
+	dir := path.Dir(originalPath)
+	sizes := Sizes
+	if width, ok := originalWidth("/" + strings.TrimPrefix(originalPath, "/")); ok {
+		sizes = sizesFor(width)
+	}
+
+	var paths []string
+	for _, size := range sizes {
+		paths = append(paths, dir+"/"+size.Name+".webp", dir+"/"+size.Name+".jpg")
+	}
+	return paths
+}
+
+// SetVariant holds the urls of one size.
+type SetVariant struct {
+	Name  string
+	Width int
+	WebP  string
+	JPEG  string
+}
+
+// Set is the srcset data of a processed image.
+type Set struct {
+	Src      string
+	Variants []SetVariant
+}
+
+// SetFromURL derives the variant urls of an uploaded original.
+// Urls from before the pipeline existed have no variants and return false.
+func SetFromURL(url string) (*Set, bool) {
+	width, ok := originalWidth(url)
+	if !ok {
+		return nil, false
+	}
+
+	base := url[:strings.LastIndex(url, "/")+1]
+	set := &Set{Src: url}
+	for _, size := range sizesFor(width) {
+		set.Variants = append(set.Variants, SetVariant{
+			Name:  size.Name,
+			Width: min(size.Width, width),
+			WebP:  base + size.Name + ".webp",
+			JPEG:  base + size.Name + ".jpg",
+		})
+	}
+	return set, true
+}
+
+// SrcSet formats the urls of one format, ex: "a.webp 320w, b.webp 768w".
+func (s *Set) SrcSet(format string) string {
+	parts := make([]string, 0, len(s.Variants))
+	for _, v := range s.Variants {
+		url := v.JPEG
+		if format == FormatWebP {
+			url = v.WebP
+		}
+		parts = append(parts, fmt.Sprintf("%s %dw", url, v.Width))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+// Package logger sets up the zerolog logger shared by every layer. Request
+// scoped fields travel in the context, so code logs through Ctx(ctx).
+package logger
+
+import (
+	"context"
+	"desadangdang/config"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Layers name the part of the code a log line comes from.
+const (
+	Handler    = "handler"
+	Service    = "service"
+	Repository = "repository"
+	Storage    = "storage"
+	Messaging  = "messaging"
+)
+
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// New builds a logger with the configured level and format.
+func New(cfg *config.Config) zerolog.Logger {
+	level, err := zerolog.ParseLevel(strings.ToLower(cfg.Log.Level))
+	if err != nil || level == zerolog.NoLevel {
+		level = zerolog.InfoLevel
+	}
+
+	var out io.Writer = os.Stdout
+	if cfg.Log.Format == FormatConsole {
+		out = zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
+	}
+
+	return zerolog.New(out).Level(level).With().Timestamp().Str("app", cfg.App.AppName).Logger()
+}
+
+// Setup makes l the global logger and the fallback of Ctx for contexts
+// without a request logger, ex: background workers and cli commands.
+func Setup(l zerolog.Logger) {
+	log.Logger = l
+	zerolog.DefaultContextLogger = &log.Logger
+}
+
+// Ctx returns the logger carried by ctx.
+func Ctx(ctx context.Context) *zerolog.Logger {
+	return zerolog.Ctx(ctx)
+}
+
+// Error logs err together with the layer, operation and step that identify
+// where it happened.
+func Error(ctx context.Context, layer, op string, step int, err error) {
+	Ctx(ctx).Error().Err(err).Str("layer", layer).Str("op", op).Int("step", step).Msg(op + " failed")
+}
+
+// Errorf is Error for failures without an error value.
+func Errorf(ctx context.Context, layer, op string, step int, format string, args ...any) {
+	Ctx(ctx).Error().Str("layer", layer).Str("op", op).Int("step", step).Msgf(format, args...)
+}
@@ -0,0 +1,133 @@
+// Package metrics holds the prometheus collectors of the api and serves them
+// on /metrics.
+package metrics
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "desadangdang"
+
+// Registry only holds the collectors below, so nothing registered by a
+// dependency on the default registry ends up in the output.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	emails = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Emails handed to the transport by result, success or failure.",
+	}, []string{"result"})
+
+	uploadSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upload_size_bytes",
+		Help:      "Size of stored uploads by purpose.",
+		// 64KB up to 256MB
+		Buckets: prometheus.ExponentialBuckets(64<<10, 4, 8),
+	}, []string{"purpose"})
+
+	appointments = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "appointments_created_total",
+		Help:      "Appointments created through the api.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		emails,
+		uploadSize,
+		appointments,
+	)
+}
+
+// RegisterDB exports the connection pool stats of db.
+func RegisterDB(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// EmailSent counts one email by the error returned from the transport.
+func EmailSent(err error) {
+	if err != nil {
+		emails.WithLabelValues("failure").Inc()
+		return
+	}
+	emails.WithLabelValues("success").Inc()
+}
+
+// UploadStored records the size of an upload that was stored.
+func UploadStored(purpose string, size int64) {
+	uploadSize.WithLabelValues(purpose).Observe(float64(size))
+}
+
+// AppointmentCreated counts one created appointment.
+func AppointmentCreated() {
+	appointments.Inc()
+}
+
+// Middleware records the count and latency of every request. The route is
+// the registered path, so ids in the url don't create new series.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			status := strconv.Itoa(c.Response().Status)
+			method := c.Request().Method
+
+			httpRequests.WithLabelValues(method, route, status).Inc()
+			httpDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
+
+// Handler serves the registry. Requests must send the token as a bearer
+// token in the Authorization header.
+func Handler(token string) echo.HandlerFunc {
+	metricsHandler := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	return func(c echo.Context) error {
+		given := strings.TrimPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			return c.NoContent(http.StatusUnauthorized)
+		}
+		metricsHandler.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
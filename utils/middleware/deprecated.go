@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Deprecated marks the requests routed to one of paths, echo route paths like
+// /posts/:id. They get the Deprecation (RFC 9745) and Sunset (RFC 8594)
+// headers, and a Link to the same request under successor, the prefix that
+// replaces them.
+func Deprecated(paths []string, deprecatedAt, sunset time.Time, successor string) echo.MiddlewareFunc {
+	deprecated := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		deprecated[p] = true
+	}
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	sunsetAt := sunset.UTC().Format(http.TimeFormat)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if deprecated[c.Path()] {
+				header := c.Response().Header()
+				header.Set("Deprecation", deprecation)
+				if !sunset.IsZero() {
+					header.Set("Sunset", sunsetAt)
+				}
+				header.Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successor, c.Request().URL.RequestURI()))
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerETag            = "ETag"
+	headerIfNoneMatch     = "If-None-Match"
+	headerLastModified    = "Last-Modified"
+	headerIfModifiedSince = "If-Modified-Since"
+)
+
+// HTTPCache sets the Cache-Control returned by cacheControl, requests it
+// returns an empty value for are left alone. A value letting the response be
+// stored is only sent with a 200, any other status gets no-store so an error
+// is never cached.
+//
+// Successful GET responses get an ETag hashed from the body, and the
+// Last-Modified returned by lastModified when it has one. It is read before
+// the handler runs so it is never newer than the body, a handler serving a
+// cached body sets an earlier one itself. A request is answered
+// with 304 Not Modified when its If-None-Match lists the ETag, or, when it
+// sends no If-None-Match, when its If-Modified-Since is not older than the
+// Last-Modified.
+func HTTPCache(cacheControl func(c echo.Context) string, lastModified func(c echo.Context) (time.Time, bool)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			value := cacheControl(c)
+			if value == "" {
+				return next(c)
+			}
+
+			res := c.Response()
+			if strings.Contains(value, "no-store") {
+				res.Header().Set(echo.HeaderCacheControl, value)
+				return next(c)
+			}
+
+			req := c.Request()
+			get := req.Method == http.MethodGet
+			var modified time.Time
+			hasModified := false
+			if get {
+				modified, hasModified = lastModified(c)
+			}
+
+			writer := res.Writer
+			buf := &bufferedWriter{ResponseWriter: writer}
+			res.Writer = buf
+			err := next(c)
+			res.Writer = writer
+
+			if err != nil || buf.status != http.StatusOK {
+				res.Header().Set(echo.HeaderCacheControl, "no-store")
+				buf.flush()
+				return err
+			}
+
+			res.Header().Set(echo.HeaderCacheControl, value)
+			if !get {
+				buf.flush()
+				return nil
+			}
+
+			sum := sha256.Sum256(buf.body.Bytes())
+			etag := `"` + base64.RawURLEncoding.EncodeToString(sum[:18]) + `"`
+			res.Header().Set(headerETag, etag)
+			// a handler serving a cached body sets when it was read
+			if read, err := http.ParseTime(res.Header().Get(headerLastModified)); err == nil && (!hasModified || read.Before(modified)) {
+				modified, hasModified = read, true
+			}
+			if hasModified {
+				// http dates have no fraction of a second, and are never in the future
+				if now := time.Now(); modified.After(now) {
+					modified = now
+				}
+				modified = modified.Truncate(time.Second)
+				res.Header().Set(headerLastModified, modified.UTC().Format(http.TimeFormat))
+			}
+
+			if notModified(req, etag, modified, hasModified) {
+				res.Header().Del(echo.HeaderContentType)
+				res.Header().Del(echo.HeaderContentLength)
+				res.Status = http.StatusNotModified
+				res.Size = 0
+				writer.WriteHeader(http.StatusNotModified)
+				return nil
+			}
+			buf.flush()
+			return nil
+		}
+	}
+}
+
+// notModified reports whether the body the client has is still current.
+// If-None-Match decides when it is sent, If-Modified-Since is ignored then.
+func notModified(req *http.Request, etag string, modified time.Time, hasModified bool) bool {
+	if header := req.Header.Get(headerIfNoneMatch); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !hasModified {
+		return false
+	}
+	since, err := http.ParseTime(req.Header.Get(headerIfModifiedSince))
+	return err == nil && !modified.After(since)
+}
+
+// bufferedWriter holds the response until the ETag is known.
+type bufferedWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) flush() {
+	if w.status == 0 {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const testCacheControl = "public, max-age=60"
+
+var testModified = time.Date(2026, 10, 1, 8, 30, 15, 0, time.UTC)
+
+func newTestCache(modified time.Time, handler echo.HandlerFunc) *echo.Echo {
+	e := echo.New()
+	e.Use(HTTPCache(
+		func(c echo.Context) string { return testCacheControl },
+		func(c echo.Context) (time.Time, bool) { return modified, !modified.IsZero() },
+	))
+	e.GET("/posts", handler)
+	return e
+}
+
+func okHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"title": "Panen raya"})
+}
+
+func serve(e *echo.Echo, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHTTPCacheHeaders(t *testing.T) {
+	rec := serve(newTestCache(testModified, okHandler), nil)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get(echo.HeaderCacheControl); got != testCacheControl {
+		t.Errorf("Cache-Control = %q, want %q", got, testCacheControl)
+	}
+	if rec.Header().Get(headerETag) == "" {
+		t.Error("no ETag")
+	}
+	if got, want := rec.Header().Get(headerLastModified), "Thu, 01 Oct 2026 08:30:15 GMT"; got != want {
+		t.Errorf("Last-Modified = %q, want %q", got, want)
+	}
+}
+
+func TestHTTPCacheIfNoneMatch(t *testing.T) {
+	e := newTestCache(testModified, okHandler)
+	etag := serve(e, nil).Header().Get(headerETag)
+
+	rec := serve(e, http.Header{headerIfNoneMatch: {`"other", ` + etag}})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("matching If-None-Match: status = %d, want 304", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Errorf("304 has a body: %q", rec.Body)
+	}
+
+	rec = serve(e, http.Header{headerIfNoneMatch: {`"other"`}})
+	if rec.Code != http.StatusOK {
+		t.Errorf("other If-None-Match: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestHTTPCacheIfModifiedSince(t *testing.T) {
+	e := newTestCache(testModified, okHandler)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   int
+	}{
+		{"same time", http.Header{headerIfModifiedSince: {"Thu, 01 Oct 2026 08:30:15 GMT"}}, http.StatusNotModified},
+		{"later", http.Header{headerIfModifiedSince: {"Fri, 02 Oct 2026 00:00:00 GMT"}}, http.StatusNotModified},
+		{"earlier", http.Header{headerIfModifiedSince: {"Thu, 01 Oct 2026 08:30:14 GMT"}}, http.StatusOK},
+		{"malformed", http.Header{headerIfModifiedSince: {"yesterday"}}, http.StatusOK},
+		// If-None-Match takes precedence, the date alone would give a 304
+		{"with other etag", http.Header{
+			headerIfModifiedSince: {"Fri, 02 Oct 2026 00:00:00 GMT"},
+			headerIfNoneMatch:     {`"other"`},
+		}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if rec := serve(e, tt.header); rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+
+	// without a Last-Modified there is nothing to compare to
+	rec := serve(newTestCache(time.Time{}, okHandler), http.Header{headerIfModifiedSince: {"Fri, 02 Oct 2026 00:00:00 GMT"}})
+	if rec.Code != http.StatusOK {
+		t.Errorf("no Last-Modified: status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get(headerLastModified); got != "" {
+		t.Errorf("no Last-Modified: got %q", got)
+	}
+}
+
+func TestHTTPCacheHandlerLastModified(t *testing.T) {
+	cached := testModified.Add(-time.Hour)
+	e := newTestCache(testModified, func(c echo.Context) error {
+		c.Response().Header().Set(headerLastModified, cached.Format(http.TimeFormat))
+		return okHandler(c)
+	})
+
+	rec := serve(e, nil)
+	if got, want := rec.Header().Get(headerLastModified), cached.Format(http.TimeFormat); got != want {
+		t.Errorf("Last-Modified = %q, want the earlier %q", got, want)
+	}
+	rec = serve(e, http.Header{headerIfModifiedSince: {cached.Format(http.TimeFormat)}})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("status = %d, want 304", rec.Code)
+	}
+}
+
+func TestHTTPCacheErrorsNotStored(t *testing.T) {
+	handlers := map[string]echo.HandlerFunc{
+		"returned error": func(c echo.Context) error { return echo.ErrNotFound },
+		"written status": func(c echo.Context) error {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"message": "timeout"})
+		},
+	}
+	for name, handler := range handlers {
+		rec := serve(newTestCache(testModified, handler), nil)
+		if rec.Code == http.StatusOK {
+			t.Fatalf("%s: status = 200", name)
+		}
+		if got := rec.Header().Get(echo.HeaderCacheControl); got != "no-store" {
+			t.Errorf("%s: Cache-Control = %q, want no-store", name, got)
+		}
+		if got := rec.Header().Get(headerETag); got != "" {
+			t.Errorf("%s: ETag = %q on an error", name, got)
+		}
+	}
+}
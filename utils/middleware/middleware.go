@@ -2,12 +2,12 @@ package middleware
 
 import (
 	"desadangdang/config"
-	"desadangdang/internal/adapater/handler/response"
+	"desadangdang/internal/core/domain/apperr"
 	"desadangdang/utils/auth"
-	"net/http"
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
 )
 
 type Middleware interface {
@@ -22,22 +22,16 @@ type Options struct {
 func (o *Options) CheckToken() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			var errorResponse response.ErrorResponseDefault
-
 			// Ambil header Authorization
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
-				errorResponse.Meta.Status = false
-				errorResponse.Meta.Message = "Missing Authorization header"
-				return c.JSON(http.StatusUnauthorized, errorResponse)
+				return apperr.Unauthorized("Missing Authorization header")
 			}
 
 			// Validasi format header Authorization
 			parts := strings.Split(authHeader, "Bearer ")
 			if len(parts) != 2 {
-				errorResponse.Meta.Status = false
-				errorResponse.Meta.Message = "Invalid Authorization header format"
-				return c.JSON(http.StatusUnauthorized, errorResponse)
+				return apperr.Unauthorized("Invalid Authorization header format")
 			}
 
 			// Ambil token dari header
@@ -46,14 +40,17 @@ func (o *Options) CheckToken() echo.MiddlewareFunc {
 			// Verifikasi token
 			claims, err := o.authJwt.VerifyAccessToken(tokenString)
 			if err != nil {
-				errorResponse.Meta.Status = false
-				errorResponse.Meta.Message = "Invalid token"
-				return c.JSON(http.StatusUnauthorized, errorResponse)
+				return apperr.Unauthorized("Invalid token")
 			}
 
 			// Simpan claims ke context
 			c.Set("user", claims)
 
+			// Every log line of the request carries the user from here on
+			ctx := c.Request().Context()
+			l := zerolog.Ctx(ctx).With().Int64("user_id", int64(claims.UserID)).Logger()
+			c.SetRequest(c.Request().WithContext(l.WithContext(ctx)))
+
 			// Lanjutkan ke handler berikutnya
 			return next(c)
 		}
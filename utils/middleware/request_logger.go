@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"desadangdang/utils/conv"
+	"desadangdang/utils/tracing"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+// requestIDRegex accepts ids passed in by a proxy, anything else is replaced.
+var requestIDRegex = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestLogger gives every request an id, returned in the X-Request-ID
+// header, and puts a logger carrying the id, route and trace id into the
+// request context. When the request is done one access line is logged with
+// the status, latency and user.
+func RequestLogger(base zerolog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+
+			requestID := req.Header.Get(echo.HeaderXRequestID)
+			if !requestIDRegex.MatchString(requestID) {
+				requestID = uuid.NewString()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			fields := base.With().
+				Str("request_id", requestID).
+				Str("method", req.Method).
+				Str("route", c.Path())
+			if traceID := tracing.TraceID(req.Context()); traceID != "" {
+				fields = fields.Str("trace_id", traceID)
+			}
+			l := fields.Logger()
+			c.SetRequest(req.WithContext(l.WithContext(req.Context())))
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			event := zerolog.Ctx(c.Request().Context()).Info()
+			switch {
+			case status >= 500:
+				event = zerolog.Ctx(c.Request().Context()).Error()
+			case status >= 400:
+				event = zerolog.Ctx(c.Request().Context()).Warn()
+			}
+			event.
+				Int64("user_id", conv.GetUserIDByContext(c)).
+				Int("status", status).
+				Dur("latency", time.Since(start)).
+				Int64("bytes_out", c.Response().Size).
+				Str("remote_ip", c.RealIP()).
+				Msg("request")
+			return nil
+		}
+	}
+}
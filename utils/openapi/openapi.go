@@ -0,0 +1,451 @@
+// Package openapi builds the OpenAPI 3 document of the api from a route table,
+// the schemas are read from the request and response structs.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers,omitempty"`
+	Tags       []Tag                            `json:"tags,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema  *Schema     `json:"schema,omitempty"`
+	Example interface{} `json:"example,omitempty"`
+}
+
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Route documents one registered route. Method and Path are written the way
+// they are given to echo, path parameters and wildcards are converted.
+type Route struct {
+	Method      string
+	Path        string
+	Tag         string
+	Summary     string
+	Description string
+	// Auth is the security scheme of the route, empty for public routes.
+	Auth string
+	// Query is a struct whose query tags are the query parameters.
+	Query interface{}
+	// Params are parameters that are not in the Query struct.
+	Params []Parameter
+	// Body is a struct read as json, or a *Schema sent as BodyType.
+	Body     interface{}
+	BodyType string
+	// Data is the data of the default envelope, nil when the route sends none.
+	Data interface{}
+	// Raw is sent as is instead of the envelope.
+	Raw interface{}
+	// Produces lists other content types of the success response, like exports.
+	Produces []string
+	// Status of the success response, 200 when zero.
+	Status int
+	// NoContent is set when the success response has no body.
+	NoContent bool
+	// Headers are sent with the success response.
+	Headers map[string]*Header
+	// Deprecated routes still work but are going away.
+	Deprecated bool
+	// Conditional routes send an ETag and a Last-Modified, and answer 304
+	// to a request sending them back.
+	Conditional bool
+}
+
+// Builder collects the routes into a document.
+type Builder struct {
+	doc      *Document
+	schemas  *schemaRegistry
+	envelope func(data *Schema) *Schema
+	errors   map[int]string
+}
+
+// New returns a builder for the api described by info. envelope wraps the
+// data of a route into the body sent on success, errorBody is the body
+// sent on failure.
+func New(info Info, envelope interface{}, errorBody interface{}) *Builder {
+	b := &Builder{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   map[string]map[string]*Operation{},
+			Components: Components{
+				Schemas:         map[string]*Schema{},
+				Responses:       map[string]*Response{},
+				SecuritySchemes: map[string]*SecurityScheme{},
+			},
+		},
+		errors: map[int]string{},
+	}
+	b.schemas = newSchemaRegistry(b.doc.Components.Schemas)
+
+	envelopeSchema := b.schemas.schemaOf(reflect.TypeOf(envelope))
+	envelopeName := strings.TrimPrefix(envelopeSchema.Ref, refPrefix)
+	b.envelope = func(data *Schema) *Schema {
+		if data == nil {
+			return envelopeSchema
+		}
+		// The generic envelope has an untyped data, each route narrows it.
+		return &Schema{AllOf: []*Schema{
+			{Ref: refPrefix + envelopeName},
+			{Type: "object", Properties: map[string]*Schema{"data": data}},
+		}}
+	}
+
+	errorSchema := b.schemas.schemaOf(reflect.TypeOf(errorBody))
+	for status, name := range map[int]string{
+		http.StatusBadRequest:            "BadRequest",
+		http.StatusUnauthorized:          "Unauthorized",
+		http.StatusForbidden:             "Forbidden",
+		http.StatusNotFound:              "NotFound",
+		http.StatusConflict:              "Conflict",
+		http.StatusRequestEntityTooLarge: "TooLarge",
+		http.StatusInternalServerError:   "InternalError",
+	} {
+		b.errors[status] = name
+		b.doc.Components.Responses[name] = &Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{echo.MIMEApplicationJSON: {Schema: errorSchema}},
+		}
+	}
+	return b
+}
+
+// Server adds a server the api is reachable on.
+func (b *Builder) Server(url, description string) *Builder {
+	b.doc.Servers = append(b.doc.Servers, Server{URL: url, Description: description})
+	return b
+}
+
+// Tag describes a tag used by the routes.
+func (b *Builder) Tag(name, description string) *Builder {
+	b.doc.Tags = append(b.doc.Tags, Tag{Name: name, Description: description})
+	return b
+}
+
+// BearerAuth adds a bearer token security scheme named name.
+func (b *Builder) BearerAuth(name, format, description string) *Builder {
+	b.doc.Components.SecuritySchemes[name] = &SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: format, Description: description}
+	return b
+}
+
+// Schema returns the component schema named name, to adjust what the struct
+// tags can't express.
+func (b *Builder) Schema(name string) *Schema {
+	return b.doc.Components.Schemas[name]
+}
+
+// ErrorExample sets the example of the error response sent with status.
+func (b *Builder) ErrorExample(status int, example interface{}) *Builder {
+	if name, ok := b.errors[status]; ok {
+		resp := b.doc.Components.Responses[name]
+		media := resp.Content[echo.MIMEApplicationJSON]
+		media.Example = example
+		resp.Content[echo.MIMEApplicationJSON] = media
+	}
+	return b
+}
+
+// Add documents routes.
+func (b *Builder) Add(routes ...Route) *Builder {
+	for _, r := range routes {
+		path, params := convertPath(r.Path)
+		if b.doc.Paths[path] == nil {
+			b.doc.Paths[path] = map[string]*Operation{}
+		}
+		b.doc.Paths[path][strings.ToLower(r.Method)] = b.operation(r, params)
+	}
+	return b
+}
+
+// Document returns the built document.
+func (b *Builder) Document() *Document {
+	sort.Slice(b.doc.Tags, func(i, j int) bool { return b.doc.Tags[i].Name < b.doc.Tags[j].Name })
+	return b.doc
+}
+
+func (b *Builder) operation(r Route, pathParams []string) *Operation {
+	op := &Operation{
+		Summary:     r.Summary,
+		Description: r.Description,
+		OperationID: operationID(r.Method, r.Path),
+		Responses:   map[string]*Response{},
+		Deprecated:  r.Deprecated,
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+
+	for _, name := range pathParams {
+		if hasParam(r.Params, name, "path") {
+			continue
+		}
+		param := Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}}
+		if name == "id" || strings.HasSuffix(name, "_id") {
+			param.Schema = &Schema{Type: "integer", Format: "int64", Minimum: float(1), Example: 1}
+		}
+		op.Parameters = append(op.Parameters, param)
+	}
+	if r.Query != nil {
+		op.Parameters = append(op.Parameters, b.schemas.queryParams(reflect.TypeOf(r.Query))...)
+	}
+	op.Parameters = append(op.Parameters, r.Params...)
+
+	if r.Body != nil {
+		bodyType := r.BodyType
+		if bodyType == "" {
+			bodyType = echo.MIMEApplicationJSON
+		}
+		schema, ok := r.Body.(*Schema)
+		if !ok {
+			schema = b.schemas.schemaOf(reflect.TypeOf(r.Body))
+		}
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{bodyType: {Schema: schema}}}
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status), Headers: r.Headers}
+	if !r.NoContent {
+		var schema *Schema
+		if r.Raw != nil {
+			schema = b.schemas.schemaOf(reflect.TypeOf(r.Raw))
+		} else {
+			var data *Schema
+			if r.Data != nil {
+				data = b.schemas.schemaOf(reflect.TypeOf(r.Data))
+			}
+			schema = b.envelope(data)
+		}
+		success.Content = map[string]MediaType{echo.MIMEApplicationJSON: {Schema: schema}}
+	}
+	for _, contentType := range r.Produces {
+		if success.Content == nil {
+			success.Content = map[string]MediaType{}
+		}
+		success.Content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	if r.Conditional {
+		conditional(op, success)
+	}
+	op.Responses[fmt.Sprint(status)] = success
+
+	if r.Body != nil || r.Query != nil || len(pathParams) > 0 || len(r.Params) > 0 {
+		b.errorResponse(op, http.StatusBadRequest)
+	}
+	if r.Auth != "" {
+		op.Security = []map[string][]string{{r.Auth: {}}}
+		b.errorResponse(op, http.StatusUnauthorized)
+	}
+	if len(pathParams) > 0 {
+		b.errorResponse(op, http.StatusNotFound)
+	}
+	if r.Body != nil && r.Method != http.MethodGet {
+		b.errorResponse(op, http.StatusConflict)
+	}
+	b.errorResponse(op, http.StatusInternalServerError)
+	return op
+}
+
+// conditional documents the validators of the success response, the
+// conditional request headers and the 304 answering them.
+func conditional(op *Operation, success *Response) {
+	validators := map[string]*Header{
+		"ETag":          {Description: "Hash of the body.", Schema: &Schema{Type: "string"}},
+		"Last-Modified": {Description: "When the body last changed.", Schema: &Schema{Type: "string"}},
+	}
+	headers := map[string]*Header{
+		"Cache-Control": {Description: "How long the body may be reused.", Schema: &Schema{Type: "string"}},
+	}
+	for name, h := range success.Headers {
+		headers[name] = h
+	}
+	for name, h := range validators {
+		headers[name] = h
+	}
+	success.Headers = headers
+
+	op.Parameters = append(op.Parameters,
+		Parameter{Name: "If-None-Match", In: "header", Description: "ETag of the body the client has.", Schema: &Schema{Type: "string"}},
+		Parameter{Name: "If-Modified-Since", In: "header", Description: "Last-Modified of the body the client has, ignored with If-None-Match.", Schema: &Schema{Type: "string"}},
+	)
+	op.Responses[fmt.Sprint(http.StatusNotModified)] = &Response{Description: http.StatusText(http.StatusNotModified), Headers: validators}
+}
+
+func hasParam(params []Parameter, name, in string) bool {
+	for _, p := range params {
+		if p.Name == name && p.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Builder) errorResponse(op *Operation, status int) {
+	op.Responses[fmt.Sprint(status)] = &Response{Ref: "#/components/responses/" + b.errors[status]}
+}
+
+// ErrorResponse adds the shared error response of status to an operation,
+// for the failures the route table can't guess.
+func (b *Builder) ErrorResponse(method, path string, statuses ...int) *Builder {
+	converted, _ := convertPath(path)
+	if op := b.doc.Paths[converted][strings.ToLower(method)]; op != nil {
+		for _, status := range statuses {
+			b.errorResponse(op, status)
+		}
+	}
+	return b
+}
+
+var pathParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// convertPath turns an echo path into an OpenAPI one, :id becomes {id} and
+// a trailing wildcard becomes {path}.
+func convertPath(path string) (string, []string) {
+	var params []string
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		params = append(params, m[1])
+	}
+	path = pathParam.ReplaceAllString(path, "{$1}")
+	if strings.HasSuffix(path, "*") {
+		path = strings.TrimSuffix(strings.TrimSuffix(path, "*"), "/") + "/{path}"
+		params = append(params, "path")
+	}
+	if path == "" {
+		path = "/"
+	}
+	return path, params
+}
+
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	upper := true
+	for _, r := range path {
+		switch {
+		case r == '/' || r == '-' || r == '_' || r == ':' || r == '*' || r == '.':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if strings.HasSuffix(path, "*") {
+		b.WriteString("Path")
+	}
+	return b.String()
+}
+
+// Diff compares the registered routes to the document. Missing routes are
+// registered but not documented, stale ones are documented but not registered.
+func Diff(doc *Document, routes []*echo.Route) (missing, stale []string) {
+	registered := map[string]bool{}
+	for _, r := range routes {
+		if r.Method == echo.RouteNotFound {
+			continue
+		}
+		path, _ := convertPath(r.Path)
+		key := r.Method + " " + path
+		if registered[key] {
+			continue
+		}
+		registered[key] = true
+		if _, ok := doc.Paths[path][strings.ToLower(r.Method)]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			key := strings.ToUpper(method) + " " + path
+			if !registered[key] {
+				stale = append(stale, key)
+			}
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+	return missing, stale
+}
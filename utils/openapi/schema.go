@@ -0,0 +1,321 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const refPrefix = "#/components/schemas/"
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+}
+
+func float(v float64) *float64 { return &v }
+
+func integer(v int) *int { return &v }
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry turns go types into schemas, named structs become
+// components referenced by the type name.
+type schemaRegistry struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaRegistry(components map[string]*Schema) *schemaRegistry {
+	return &schemaRegistry{components: components, names: map[reflect.Type]string{}}
+}
+
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := r.schemaOf(t.Elem())
+		if s.Ref != "" {
+			// siblings of $ref are ignored, nullable needs the allOf form
+			return &Schema{AllOf: []*Schema{s}, Nullable: true}
+		}
+		s.Nullable = true
+		return s
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: refPrefix + r.component(t)}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	}
+	return primitive(t.Kind())
+}
+
+func primitive(kind reflect.Kind) *Schema {
+	switch kind {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	}
+	return &Schema{Type: "string"}
+}
+
+// component registers the struct under its name and returns the name. Two
+// structs of the same name in different packages get the package prefixed.
+func (r *schemaRegistry) component(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := r.components[name]; taken {
+		pkg := t.PkgPath()
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	r.names[t] = name
+	// registered before the fields, so a recursive type refers to itself
+	r.components[name] = &Schema{}
+	*r.components[name] = *r.structSchema(t)
+	return name
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	r.addFields(s, t)
+	return s
+}
+
+func (r *schemaRegistry) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitempty, skip := jsonName(f)
+		if skip {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addFields(s, embedded)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := r.schemaOf(f.Type)
+		required := applyTags(prop, f)
+		if required || (!isRequest(t) && !omitempty && f.Type.Kind() != reflect.Ptr) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// isRequest tells request structs apart, their fields are required only when
+// validated so, response fields are always sent unless omitempty.
+func isRequest(t reflect.Type) bool {
+	return strings.HasSuffix(t.PkgPath(), "/request")
+}
+
+func jsonName(f reflect.StructField) (name string, omitempty, skip bool) {
+	if !f.IsExported() {
+		return "", false, true
+	}
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, p := range parts[1:] {
+		if p == "omitempty" {
+			omitempty = true
+		}
+	}
+	return parts[0], omitempty, false
+}
+
+// applyTags copies the validate rules, description and example of the field
+// onto its schema and reports whether the field is required.
+func applyTags(s *Schema, f reflect.StructField) (required bool) {
+	for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+		if rule == "dive" {
+			// the rules after dive apply to the elements
+			break
+		}
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "required":
+			required = true
+		case "email":
+			s.Format = "email"
+		case "url", "http_url":
+			s.Format = "uri"
+		case "uuid", "uuid4":
+			s.Format = "uuid"
+		case "oneof":
+			for _, v := range strings.Fields(value) {
+				s.Enum = append(s.Enum, typedValue(s, v))
+			}
+		case "min", "gte":
+			limit(s, value, false, false)
+		case "max", "lte":
+			limit(s, value, true, false)
+		case "gt":
+			limit(s, value, false, true)
+		case "lt":
+			limit(s, value, true, true)
+		case "len":
+			limit(s, value, false, false)
+			limit(s, value, true, false)
+		}
+	}
+
+	if description := f.Tag.Get("description"); description != "" {
+		s.Description = description
+	}
+	if example, ok := f.Tag.Lookup("example"); ok {
+		s.Example = typedValue(s, example)
+	} else if s.Example == nil {
+		s.Example = exampleOf(s)
+	}
+	return required
+}
+
+// limit sets the bound fitting the schema type: the length of a string, the
+// size of an array or the value of a number.
+func limit(s *Schema, value string, upper, exclusive bool) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+	switch s.Type {
+	case "string":
+		if exclusive {
+			if upper {
+				n--
+			} else {
+				n++
+			}
+		}
+		if upper {
+			s.MaxLength = integer(int(n))
+		} else {
+			s.MinLength = integer(int(n))
+		}
+	case "array":
+		if upper {
+			s.MaxItems = integer(int(n))
+		} else {
+			s.MinItems = integer(int(n))
+		}
+	case "integer", "number":
+		if upper {
+			s.Maximum, s.ExclusiveMaximum = float(n), exclusive
+		} else {
+			s.Minimum, s.ExclusiveMinimum = float(n), exclusive
+		}
+	}
+}
+
+// typedValue parses a tag value into the type of the schema, so an integer
+// enum or example isn't written as a string.
+func typedValue(s *Schema, value string) interface{} {
+	switch s.Type {
+	case "integer", "number", "boolean", "array", "object":
+		var v interface{}
+		if json.Unmarshal([]byte(value), &v) == nil {
+			return v
+		}
+	}
+	return value
+}
+
+func exampleOf(s *Schema) interface{} {
+	switch {
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	case s.Format == "email":
+		return "admin@example.com"
+	case s.Format == "uri":
+		return "https://example.com"
+	case s.Type == "boolean":
+		return true
+	case s.Type == "integer" || s.Type == "number":
+		if s.Minimum == nil {
+			return 1
+		}
+		if s.ExclusiveMinimum {
+			return *s.Minimum + 1
+		}
+		return *s.Minimum
+	}
+	return nil
+}
+
+// queryParams turns the query tags of a struct into parameters.
+func (r *schemaRegistry) queryParams(t reflect.Type) []Parameter {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("query"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		s := r.schemaOf(f.Type)
+		s.Nullable = false
+		required := applyTags(s, f)
+		params = append(params, Parameter{
+			Name:        name,
+			In:          "query",
+			Description: s.Description,
+			Required:    required,
+			Schema:      s,
+		})
+		s.Description = ""
+	}
+	return params
+}
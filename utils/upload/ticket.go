@@ -0,0 +1,66 @@
+package upload
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTicket = errors.New("invalid upload ticket")
+	ErrTicketExpired = errors.New("upload ticket expired")
+)
+
+// Ticket describes a direct upload that was handed out, it comes back
+// signed with the completion callback so the api does not keep state.
+type Ticket struct {
+	FileKey      string `json:"k"`
+	Path         string `json:"p"`
+	Purpose      string `json:"u"`
+	ContentType  string `json:"t"`
+	Size         int64  `json:"s"`
+	OriginalName string `json:"n"`
+	ExpiresAt    int64  `json:"e"`
+}
+
+// SignTicket encodes the ticket as <payload>.<hmac>.
+func SignTicket(secret string, t Ticket) (string, error) {
+	payload, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + ticketSignature(secret, encoded), nil
+}
+
+func VerifyTicket(secret, token string) (*Ticket, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(ticketSignature(secret, encoded))) {
+		return nil, ErrInvalidTicket
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidTicket
+	}
+	var t Ticket
+	if err := json.Unmarshal(payload, &t); err != nil {
+		return nil, ErrInvalidTicket
+	}
+	if time.Now().Unix() > t.ExpiresAt {
+		return nil, ErrTicketExpired
+	}
+	return &t, nil
+}
+
+func ticketSignature(secret, encoded string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(encoded))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,175 @@
+package upload
+
+import (
+	"bytes"
+	"desadangdang/config"
+	"errors"
+	"io"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	PurposeImage = "image"
+	PurposePDF   = "pdf"
+	PurposeDocx  = "docx"
+	PurposeVideo = "video"
+)
+
+const (
+	megabyte = 1 << 20
+	// sniffLength matches the amount of bytes mimetype inspects by default.
+	sniffLength = 3072
+	// formOverhead leaves room for the multipart boundaries and the other
+	// form fields sent next to the file.
+	formOverhead = megabyte
+)
+
+var (
+	ErrUnknownPurpose  = errors.New("unknown upload purpose")
+	ErrFileTooLarge    = errors.New("file is too large")
+	ErrUnsupportedType = errors.New("file type is not allowed")
+	ErrEmptyFile       = errors.New("file is empty")
+	ErrImageNotDirect  = errors.New("images are uploaded through the api so their variants can be generated")
+)
+
+var defaultMaxSizeMB = map[string]int64{PurposeImage: 5, PurposePDF: 20, PurposeDocx: 20, PurposeVideo: 200}
+
+// allowed lists the accepted MIME types per purpose. SVG is left out on purpose
+// since it can carry scripts.
+var allowed = map[string][]string{
+	PurposeImage: {"image/jpeg", "image/png", "image/webp", "image/gif"},
+	PurposePDF:   {"application/pdf"},
+	PurposeDocx:  {"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	PurposeVideo: {"video/mp4", "video/webm", "video/quicktime"},
+}
+
+// Policy holds the size limit for every purpose in bytes.
+type Policy struct {
+	MaxSize map[string]int64
+}
+
+// File is an upload that passed validation.
+// Reader replays the sniffed bytes, so it yields the whole content.
+type File struct {
+	Purpose     string
+	ContentType string
+	Extension   string
+	Size        int64
+	Reader      io.Reader
+}
+
+// NewPolicy builds the size limits from megabytes, a zero value keeps the default.
+func NewPolicy(maxSizeMB map[string]int64) Policy {
+	policy := Policy{MaxSize: map[string]int64{}}
+	for purpose, size := range defaultMaxSizeMB {
+		if custom := maxSizeMB[purpose]; custom > 0 {
+			size = custom
+		}
+		policy.MaxSize[purpose] = size * megabyte
+	}
+	return policy
+}
+
+// PolicyFromConfig reads the UPLOAD_MAX_* limits.
+func PolicyFromConfig(cfg *config.Config) Policy {
+	return NewPolicy(map[string]int64{
+		PurposeImage: cfg.Upload.MaxImageSize,
+		PurposePDF:   cfg.Upload.MaxPDFSize,
+		PurposeDocx:  cfg.Upload.MaxDocxSize,
+		PurposeVideo: cfg.Upload.MaxVideoSize,
+	})
+}
+
+// BodyLimit is the largest request body an upload form may send, the file of
+// the purpose with the highest limit and the rest of the form.
+func (p Policy) BodyLimit() int64 {
+	var largest int64
+	for _, size := range p.MaxSize {
+		if size > largest {
+			largest = size
+		}
+	}
+	return largest + formOverhead
+}
+
+// IsPurpose reports whether purpose has an allowlist.
+func IsPurpose(purpose string) bool {
+	_, ok := allowed[purpose]
+	return ok
+}
+
+// Validate checks size and the content type sniffed from the first bytes.
+// The client supplied filename and Content-Type are never trusted.
+func (p Policy) Validate(purpose string, size int64, r io.Reader) (*File, error) {
+	types, ok := allowed[purpose]
+	if !ok {
+		return nil, ErrUnknownPurpose
+	}
+	if size == 0 {
+		return nil, ErrEmptyFile
+	}
+	if max := p.MaxSize[purpose]; max > 0 && size > max {
+		return nil, ErrFileTooLarge
+	}
+
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	header = header[:n]
+
+	detected := mimetype.Detect(header)
+	for _, t := range types {
+		if detected.Is(t) {
+			return &File{
+				Purpose:     purpose,
+				ContentType: t,
+				Extension:   detected.Extension(),
+				Size:        size,
+				Reader:      io.MultiReader(bytes.NewReader(header), r),
+			}, nil
+		}
+	}
+	return nil, ErrUnsupportedType
+}
+
+// Check validates what a client declares before a direct upload.
+// The stored object is still sniffed with Validate once it arrives.
+func (p Policy) Check(purpose, contentType string, size int64) error {
+	types, ok := allowed[purpose]
+	if !ok {
+		return ErrUnknownPurpose
+	}
+	if size <= 0 {
+		return ErrEmptyFile
+	}
+	if max := p.MaxSize[purpose]; max > 0 && size > max {
+		return ErrFileTooLarge
+	}
+	for _, t := range types {
+		if t == contentType {
+			return nil
+		}
+	}
+	return ErrUnsupportedType
+}
+
+// Extension returns the file extension of an allowed content type.
+func Extension(contentType string) string {
+	if m := mimetype.Lookup(contentType); m != nil {
+		return m.Extension()
+	}
+	return ""
+}
+
+// Allowed returns the accepted MIME types for purpose.
+func Allowed(purpose string) []string {
+	return allowed[purpose]
+}
+
+// MaxSizeMB returns the limit for purpose rounded down to megabytes.
+func (p Policy) MaxSizeMB(purpose string) int64 {
+	return p.MaxSize[purpose] / megabyte
+}
@@ -2,11 +2,11 @@ package validator
 
 import (
 	"errors"
-	"log"
 
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog/log"
 )
 
 type Validator struct {
@@ -19,7 +19,7 @@ func NewValidator() *Validator {
 	uni := ut.New(en, en)
 	trans, found := uni.GetTranslator("en")
 	if !found {
-		log.Fatal("translator not found")
+		log.Fatal().Msg("translator not found")
 	}
 
 	validate := validator.New()